@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+)
+
+// AccountInactiveError is returned when the requested actor's account is not active
+type AccountInactiveError struct {
+	Status string
+}
+
+func (e *AccountInactiveError) Error() string {
+	return fmt.Sprintf("account is %s", e.Status)
+}
+
+// getInactiveAccounts returns the status of each of the dids whose account is not active, keyed by did
+func (s *Server) getInactiveAccounts(ctx context.Context, dids []string) (map[string]string, error) {
+	inactive := make(map[string]string)
+	if len(dids) == 0 {
+		return inactive, nil
+	}
+
+	resp, err := s.client.Account.GetAccountStatuses(ctx, &vyletdatabase.GetAccountStatusesRequest{
+		Dids: dids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting account statuses: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to get account statuses: %s", *resp.Error)
+	}
+
+	for did, status := range resp.AccountStatuses {
+		if !status.Active {
+			inactive[did] = status.Status
+		}
+	}
+
+	return inactive, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -17,12 +18,29 @@ type ActorGetProfileInput struct {
 	Actor string `query:"actor"`
 }
 
-func (s *Server) getProfile(ctx context.Context, actor string) (*vylet.ActorDefs_ProfileView, error) {
+func (s *Server) getProfile(ctx context.Context, actor, viewer string) (*vylet.ActorDefs_ProfileView, error) {
 	did, handle, err := s.fetchDidHandleFromActor(ctx, actor)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching did and handle: %w", err)
 	}
 
+	inactive, err := s.getInactiveAccounts(ctx, []string{did})
+	if err != nil {
+		return nil, err
+	}
+	if status, ok := inactive[did]; ok {
+		return nil, &AccountInactiveError{Status: status}
+	}
+
+	if err := s.checkBlocked(ctx, viewer, did); err != nil {
+		return nil, err
+	}
+
+	muted, err := s.getMutedDids(ctx, viewer, []string{did})
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := s.client.Profile.GetProfile(ctx, &vyletdatabase.GetProfileRequest{
 		Did: did,
 	})
@@ -45,16 +63,33 @@ func (s *Server) getProfile(ctx context.Context, actor string) (*vylet.ActorDefs
 		Pronouns:    resp.Profile.Pronouns,
 		CreatedAt:   resp.Profile.CreatedAt.AsTime().Format(time.RFC3339Nano),
 		IndexedAt:   resp.Profile.IndexedAt.AsTime().Format(time.RFC3339Nano),
-		Viewer:      &vylet.ActorDefs_ViewerState{},
+		Viewer:      &vylet.ActorDefs_ViewerState{Muted: mutedState(muted[did])},
 	}, nil
 }
 
-func (s *Server) getProfileBasic(ctx context.Context, actor string) (*vylet.ActorDefs_ProfileViewBasic, error) {
+func (s *Server) getProfileBasic(ctx context.Context, actor, viewer string) (*vylet.ActorDefs_ProfileViewBasic, error) {
 	did, handle, err := s.fetchDidHandleFromActor(ctx, actor)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching did and handle: %w", err)
 	}
 
+	inactive, err := s.getInactiveAccounts(ctx, []string{did})
+	if err != nil {
+		return nil, err
+	}
+	if status, ok := inactive[did]; ok {
+		return nil, &AccountInactiveError{Status: status}
+	}
+
+	if err := s.checkBlocked(ctx, viewer, did); err != nil {
+		return nil, err
+	}
+
+	muted, err := s.getMutedDids(ctx, viewer, []string{did})
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := s.client.Profile.GetProfile(ctx, &vyletdatabase.GetProfileRequest{
 		Did: did,
 	})
@@ -76,7 +111,7 @@ func (s *Server) getProfileBasic(ctx context.Context, actor string) (*vylet.Acto
 		Pronouns:    resp.Profile.Pronouns,
 		CreatedAt:   resp.Profile.CreatedAt.AsTime().Format(time.RFC3339Nano),
 		IndexedAt:   resp.Profile.IndexedAt.AsTime().Format(time.RFC3339Nano),
-		Viewer:      &vylet.ActorDefs_ViewerState{},
+		Viewer:      &vylet.ActorDefs_ViewerState{Muted: mutedState(muted[did])},
 	}, nil
 }
 
@@ -86,7 +121,9 @@ func (s *Server) ActorGetProfileRequiresAuth() bool {
 
 func (s *Server) HandleActorGetProfile(e echo.Context, input *handlers.ActorGetProfileInput) (*vylet.ActorDefs_ProfileView, *echo.HTTPError) {
 	ctx := e.Request().Context()
-	logger := s.logger.With("name", "HandleActorGetProfile")
+	viewer := getViewer(e)
+
+	logger := s.logger.With("name", "HandleActorGetProfile", "viewer", viewer)
 
 	if input.Actor == "" {
 		return nil, NewValidationError("actor", "actor parameter is required")
@@ -94,7 +131,7 @@ func (s *Server) HandleActorGetProfile(e echo.Context, input *handlers.ActorGetP
 
 	logger = logger.With("actor", input.Actor)
 
-	profile, err := s.getProfile(ctx, input.Actor)
+	profile, err := s.getProfile(ctx, input.Actor, viewer)
 	if err != nil {
 		if errors.Is(err, ErrActorNotValid) {
 			return nil, NewValidationError("actor", "actor parameter must be a valid DID or handle")
@@ -102,6 +139,14 @@ func (s *Server) HandleActorGetProfile(e echo.Context, input *handlers.ActorGetP
 		if errors.Is(err, ErrDatabaseNotFound) {
 			return nil, ErrNotFound
 		}
+		var inactiveErr *AccountInactiveError
+		if errors.As(err, &inactiveErr) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, inactiveErr.Error())
+		}
+		var blockedErr *ActorBlockedError
+		if errors.As(err, &blockedErr) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, blockedErr.Error())
+		}
 		logger.Error("error getting profile", "err", err)
 		return nil, ErrInternalServerErr
 	}
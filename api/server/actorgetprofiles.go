@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -12,7 +11,7 @@ import (
 	"github.com/vylet-app/go/generated/vylet"
 )
 
-func (s *Server) getProfiles(ctx context.Context, dids []string) (map[string]*vylet.ActorDefs_ProfileView, error) {
+func (s *Server) getProfiles(ctx context.Context, dids []string, viewer string) (map[string]*vylet.ActorDefs_ProfileView, error) {
 	resp, err := s.client.Profile.GetProfiles(ctx, &vyletdatabase.GetProfilesRequest{
 		Dids: dids,
 	})
@@ -25,38 +24,63 @@ func (s *Server) getProfiles(ctx context.Context, dids []string) (map[string]*vy
 		}
 	}
 
+	// content from inactive accounts is hidden by leaving their profiles out
+	inactive, err := s.getInactiveAccounts(ctx, dids)
+	if err != nil {
+		return nil, err
+	}
+
+	liveDids := make([]string, 0, len(resp.Profiles))
+	for did := range resp.Profiles {
+		if _, ok := inactive[did]; ok {
+			continue
+		}
+		liveDids = append(liveDids, did)
+	}
+
+	handles, err := s.handlesFromDids(ctx, liveDids)
+	if err != nil {
+		return nil, err
+	}
+
+	relationships, err := s.getBlockRelationships(ctx, viewer, liveDids)
+	if err != nil {
+		return nil, err
+	}
+
+	muted, err := s.getMutedDids(ctx, viewer, liveDids)
+	if err != nil {
+		return nil, err
+	}
+
 	profiles := make(map[string]*vylet.ActorDefs_ProfileView)
-	var wg sync.WaitGroup
-	var lk sync.Mutex
-	for _, profile := range resp.Profiles {
-		wg.Go(func() {
-			_, handle, err := s.fetchDidHandleFromActor(ctx, profile.Did)
-			if err != nil {
-				s.logger.Error("error getting handle for did", "did", profile.Did, "err", err)
-				return
-			}
-
-			lk.Lock()
-			defer lk.Unlock()
-			profiles[profile.Did] = &vylet.ActorDefs_ProfileView{
-				Did:         profile.Did,
-				Handle:      handle,
-				Avatar:      profile.Avatar,
-				Description: profile.Description,
-				DisplayName: profile.DisplayName,
-				Pronouns:    profile.Pronouns,
-				CreatedAt:   profile.CreatedAt.AsTime().Format(time.RFC3339Nano),
-				IndexedAt:   profile.IndexedAt.AsTime().Format(time.RFC3339Nano),
-				Viewer:      &vylet.ActorDefs_ViewerState{},
-			}
-		})
-	}
-	wg.Wait()
+	for _, did := range liveDids {
+		profile := resp.Profiles[did]
+		handle, ok := handles[did]
+		if !ok {
+			continue
+		}
+
+		viewerState := blockViewerState(relationships[did])
+		viewerState.Muted = mutedState(muted[did])
+
+		profiles[did] = &vylet.ActorDefs_ProfileView{
+			Did:         profile.Did,
+			Handle:      handle,
+			Avatar:      profile.Avatar,
+			Description: profile.Description,
+			DisplayName: profile.DisplayName,
+			Pronouns:    profile.Pronouns,
+			CreatedAt:   profile.CreatedAt.AsTime().Format(time.RFC3339Nano),
+			IndexedAt:   profile.IndexedAt.AsTime().Format(time.RFC3339Nano),
+			Viewer:      viewerState,
+		}
+	}
 
 	return profiles, nil
 }
 
-func (s *Server) getProfilesBasic(ctx context.Context, dids []string) (map[string]*vylet.ActorDefs_ProfileViewBasic, error) {
+func (s *Server) getProfilesBasic(ctx context.Context, dids []string, viewer string) (map[string]*vylet.ActorDefs_ProfileViewBasic, error) {
 	resp, err := s.client.Profile.GetProfiles(ctx, &vyletdatabase.GetProfilesRequest{
 		Dids: dids,
 	})
@@ -69,32 +93,57 @@ func (s *Server) getProfilesBasic(ctx context.Context, dids []string) (map[strin
 		}
 	}
 
+	// content from inactive accounts is hidden by leaving their profiles out
+	inactive, err := s.getInactiveAccounts(ctx, dids)
+	if err != nil {
+		return nil, err
+	}
+
+	liveDids := make([]string, 0, len(resp.Profiles))
+	for did := range resp.Profiles {
+		if _, ok := inactive[did]; ok {
+			continue
+		}
+		liveDids = append(liveDids, did)
+	}
+
+	handles, err := s.handlesFromDids(ctx, liveDids)
+	if err != nil {
+		return nil, err
+	}
+
+	relationships, err := s.getBlockRelationships(ctx, viewer, liveDids)
+	if err != nil {
+		return nil, err
+	}
+
+	muted, err := s.getMutedDids(ctx, viewer, liveDids)
+	if err != nil {
+		return nil, err
+	}
+
 	profiles := make(map[string]*vylet.ActorDefs_ProfileViewBasic)
-	var wg sync.WaitGroup
-	var lk sync.Mutex
-	for _, profile := range resp.Profiles {
-		wg.Go(func() {
-			_, handle, err := s.fetchDidHandleFromActor(ctx, profile.Did)
-			if err != nil {
-				s.logger.Error("error getting handle for did", "did", profile.Did, "err", err)
-				return
-			}
-
-			lk.Lock()
-			defer lk.Unlock()
-			profiles[profile.Did] = &vylet.ActorDefs_ProfileViewBasic{
-				Did:         profile.Did,
-				Handle:      handle,
-				Avatar:      profile.Avatar,
-				DisplayName: profile.DisplayName,
-				Pronouns:    profile.Pronouns,
-				CreatedAt:   profile.CreatedAt.AsTime().Format(time.RFC3339Nano),
-				IndexedAt:   profile.IndexedAt.AsTime().Format(time.RFC3339Nano),
-				Viewer:      &vylet.ActorDefs_ViewerState{},
-			}
-		})
-	}
-	wg.Wait()
+	for _, did := range liveDids {
+		profile := resp.Profiles[did]
+		handle, ok := handles[did]
+		if !ok {
+			continue
+		}
+
+		viewerState := blockViewerState(relationships[did])
+		viewerState.Muted = mutedState(muted[did])
+
+		profiles[did] = &vylet.ActorDefs_ProfileViewBasic{
+			Did:         profile.Did,
+			Handle:      handle,
+			Avatar:      profile.Avatar,
+			DisplayName: profile.DisplayName,
+			Pronouns:    profile.Pronouns,
+			CreatedAt:   profile.CreatedAt.AsTime().Format(time.RFC3339Nano),
+			IndexedAt:   profile.IndexedAt.AsTime().Format(time.RFC3339Nano),
+			Viewer:      viewerState,
+		}
+	}
 
 	return profiles, nil
 }
@@ -105,7 +154,9 @@ func (s *Server) ActorGetProfilesRequiresAuth() bool {
 
 func (s *Server) HandleActorGetProfiles(e echo.Context, input *handlers.ActorGetProfilesInput) (*vylet.ActorGetProfiles_Output, *echo.HTTPError) {
 	ctx := e.Request().Context()
-	logger := s.logger.With("name", "HandleActorGetProfiles")
+	viewer := getViewer(e)
+
+	logger := s.logger.With("name", "HandleActorGetProfiles", "viewer", viewer)
 
 	if len(input.Dids) == 0 {
 		return nil, NewValidationError("dids", "at least one DID is required")
@@ -117,7 +168,7 @@ func (s *Server) HandleActorGetProfiles(e echo.Context, input *handlers.ActorGet
 
 	logger = logger.With("dids", input.Dids)
 
-	profiles, err := s.getProfiles(ctx, input.Dids)
+	profiles, err := s.getProfiles(ctx, input.Dids, viewer)
 	if err != nil {
 		logger.Error("error getting profiles", "err", err)
 		return nil, ErrInternalServerErr
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/generated/vylet"
+)
+
+// ActorBlockedError is returned when the viewer and the requested actor block each other
+type ActorBlockedError struct {
+	// BlockedBy is set when the actor blocks the viewer, rather than the viewer blocking the actor
+	BlockedBy bool
+}
+
+func (e *ActorBlockedError) Error() string {
+	if e.BlockedBy {
+		return "requester is blocked by actor"
+	}
+	return "requester has blocked actor"
+}
+
+// getBlockRelationships returns how the viewer and each of the dids block each other, keyed by did. Only dids with a
+// block in either direction are included, and there are none for logged out viewers.
+func (s *Server) getBlockRelationships(ctx context.Context, viewer string, dids []string) (map[string]*vyletdatabase.BlockRelationship, error) {
+	if viewer == "" || len(dids) == 0 {
+		return map[string]*vyletdatabase.BlockRelationship{}, nil
+	}
+
+	resp, err := s.client.Block.GetBlockRelationships(ctx, &vyletdatabase.GetBlockRelationshipsRequest{
+		ViewerDid: viewer,
+		Dids:      dids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting block relationships: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to get block relationships: %s", *resp.Error)
+	}
+
+	return resp.Relationships, nil
+}
+
+// checkBlocked returns an ActorBlockedError when the viewer and the did block each other
+func (s *Server) checkBlocked(ctx context.Context, viewer, did string) error {
+	relationships, err := s.getBlockRelationships(ctx, viewer, []string{did})
+	if err != nil {
+		return err
+	}
+
+	relationship, ok := relationships[did]
+	if !ok {
+		return nil
+	}
+
+	// the viewer's own block takes precedence, as it is the one they can undo
+	return &ActorBlockedError{BlockedBy: relationship.Blocking == nil}
+}
+
+// blockViewerState returns the viewer state for an actor with the given block relationship, which may be nil
+func blockViewerState(relationship *vyletdatabase.BlockRelationship) *vylet.ActorDefs_ViewerState {
+	viewerState := &vylet.ActorDefs_ViewerState{}
+	if relationship == nil {
+		return viewerState
+	}
+
+	viewerState.Blocking = relationship.Blocking
+	if relationship.BlockedBy {
+		viewerState.BlockedBy = &relationship.BlockedBy
+	}
+
+	return viewerState
+}
+
+// isBlocked reports whether an actor's viewer state has a block in either direction, in which case the actor and
+// their content are left out of lists
+func isBlocked(viewerState *vylet.ActorDefs_ViewerState) bool {
+	return viewerState != nil && (viewerState.Blocking != nil || (viewerState.BlockedBy != nil && *viewerState.BlockedBy))
+}
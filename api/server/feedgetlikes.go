@@ -12,7 +12,7 @@ import (
 	"github.com/vylet-app/go/internal/helpers"
 )
 
-func (s *Server) getLikesBySubject(ctx context.Context, subjectUri string, limit int64, cursor *string) ([]*vylet.FeedGetSubjectLikes_Like, *string, error) {
+func (s *Server) getLikesBySubject(ctx context.Context, subjectUri string, limit int64, cursor *string, viewer string) ([]*vylet.FeedGetSubjectLikes_Like, *string, error) {
 	logger := s.logger.With("name", "getLikesBySubject", "uri", subjectUri)
 
 	resp, err := s.client.Like.GetLikesBySubject(ctx, &vyletdatabase.GetLikesBySubjectRequest{
@@ -29,7 +29,7 @@ func (s *Server) getLikesBySubject(ctx context.Context, subjectUri string, limit
 		dids = append(dids, like.AuthorDid)
 	}
 
-	profiles, err := s.getProfiles(ctx, dids)
+	profiles, err := s.getProfiles(ctx, dids, viewer)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get profiles for subject: %w", err)
 	}
@@ -41,6 +41,9 @@ func (s *Server) getLikesBySubject(ctx context.Context, subjectUri string, limit
 			logger.Warn("failed to find profile for like", "did", like.AuthorDid, "uri", like.Uri)
 			continue
 		}
+		if isBlocked(profile.Viewer) || isMuted(profile.Viewer) {
+			continue
+		}
 
 		likes = append(likes, &vylet.FeedGetSubjectLikes_Like{
 			Actor:     profile,
@@ -58,8 +61,9 @@ func (s *Server) FeedGetSubjectLikesRequiresAuth() bool {
 
 func (s *Server) HandleFeedGetSubjectLikes(e echo.Context, input *handlers.FeedGetSubjectLikesInput) (*vylet.FeedGetSubjectLikes_Output, *echo.HTTPError) {
 	ctx := e.Request().Context()
+	viewer := getViewer(e)
 
-	logger := s.logger.With("name", "HandleFeedGetSubjectLikes")
+	logger := s.logger.With("name", "HandleFeedGetSubjectLikes", "viewer", viewer)
 
 	if input.Uri == "" {
 		return nil, NewValidationError("uri", "URI must be provided")
@@ -73,7 +77,7 @@ func (s *Server) HandleFeedGetSubjectLikes(e echo.Context, input *handlers.FeedG
 
 	logger = logger.With("uri", input.Uri)
 
-	likes, cursor, err := s.getLikesBySubject(ctx, input.Uri, *input.Limit, input.Cursor)
+	likes, cursor, err := s.getLikesBySubject(ctx, input.Uri, *input.Limit, input.Cursor, viewer)
 	if err != nil {
 		logger.Error("failed to get subject likes", "err", err)
 		return nil, ErrInternalServerErr
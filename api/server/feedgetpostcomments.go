@@ -0,0 +1,252 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/labstack/echo/v4"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/generated/handlers"
+	"github.com/vylet-app/go/generated/vylet"
+	"github.com/vylet-app/go/internal/helpers"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// maxCommentDepth is the deepest level of replies that is returned under a top-level comment
+	maxCommentDepth = 6
+	// commentRepliesLimit is how many replies are returned for each comment in a thread
+	commentRepliesLimit = 5
+	// maxCommentThreadReplies is how many replies a single request may fetch across all of its threads. Comments whose
+	// replies don't fit are returned with hasMoreReplies set instead.
+	maxCommentThreadReplies = 250
+	// commentThreadConcurrency is how many comments have their replies fetched at once at each level of a thread
+	commentThreadConcurrency = 8
+)
+
+// commentThreadBudget is the number of replies a request may still fetch, shared by every level of its threads
+type commentThreadBudget struct {
+	remaining atomic.Int64
+}
+
+func newCommentThreadBudget() *commentThreadBudget {
+	b := &commentThreadBudget{}
+	b.remaining.Store(maxCommentThreadReplies)
+	return b
+}
+
+// take reserves n replies, reporting false without reserving any if fewer than n are left
+func (b *commentThreadBudget) take(n int64) bool {
+	if b.remaining.Add(-n) < 0 {
+		b.remaining.Add(n)
+		return false
+	}
+	return true
+}
+
+func (s *Server) commentsToCommentViews(ctx context.Context, comments []*vyletdatabase.Comment, viewer string) (map[string]*vylet.FeedDefs_CommentView, error) {
+	logger := s.logger.With("name", "commentsToCommentViews")
+
+	uris := make([]string, 0, len(comments))
+	dids := make([]string, 0, len(comments))
+	addedDids := make(map[string]struct{})
+	for _, comment := range comments {
+		uris = append(uris, comment.Uri)
+
+		if _, ok := addedDids[comment.AuthorDid]; ok {
+			continue
+		}
+		dids = append(dids, comment.AuthorDid)
+		addedDids[comment.AuthorDid] = struct{}{}
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	var profiles map[string]*vylet.ActorDefs_ProfileViewBasic
+	var countsResp *vyletdatabase.GetPostsInteractionCountsResponse
+	g.Go(func() error {
+		maybeProfiles, err := s.getProfilesBasic(gCtx, dids, viewer)
+		if err != nil {
+			return err
+		}
+		profiles = maybeProfiles
+		return nil
+	})
+	g.Go(func() error {
+		maybeCounts, err := s.client.Post.GetPostsInteractionCounts(gCtx, &vyletdatabase.GetPostsInteractionCountsRequest{Uris: uris})
+		if err != nil {
+			return err
+		}
+		if maybeCounts.Error != nil {
+			return fmt.Errorf("failed to get comment interaction counts: %s", *maybeCounts.Error)
+		}
+		countsResp = maybeCounts
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting metadata: %w", err)
+	}
+
+	commentViews := make(map[string]*vylet.FeedDefs_CommentView)
+	for _, comment := range comments {
+		profileBasic, ok := profiles[comment.AuthorDid]
+		if !ok {
+			logger.Warn("failed to get profile for comment", "did", comment.AuthorDid, "uri", comment.Uri)
+			continue
+		}
+		if isBlocked(profileBasic.Viewer) || isMuted(profileBasic.Viewer) {
+			continue
+		}
+
+		commentView := &vylet.FeedDefs_CommentView{
+			Author:    profileBasic,
+			Cid:       comment.Cid,
+			CreatedAt: helpers.ToStringPtr(comment.CreatedAt.AsTime().Format(time.RFC3339Nano)),
+			IndexedAt: comment.IndexedAt.AsTime().Format(time.RFC3339Nano),
+			Root: &comatproto.RepoStrongRef{
+				Uri: comment.RootUri,
+				Cid: comment.RootCid,
+			},
+			Parent: &comatproto.RepoStrongRef{
+				Uri: comment.ParentUri,
+				Cid: comment.ParentCid,
+			},
+			Text:   comment.Text,
+			Uri:    comment.Uri,
+			Viewer: &vylet.FeedDefs_ViewerState{},
+		}
+
+		if counts, ok := countsResp.Counts[comment.Uri]; ok {
+			commentView.ReplyCount = helpers.ToInt64Ptr(counts.Replies)
+		}
+
+		if comment.Facets != nil {
+			var facets []*vylet.RichtextFacet
+			if err := json.Unmarshal(comment.Facets, &facets); err != nil {
+				logger.Error("failed to unmarshal comment facets", "uri", comment.Uri, "err", err)
+				continue
+			}
+			commentView.Facets = facets
+		}
+
+		commentViews[comment.Uri] = commentView
+	}
+
+	return commentViews, nil
+}
+
+// getCommentThreads returns a page of the comments replying to parentUri, each with up to depth levels of their own
+// replies below them, for as long as the request's budget allows
+func (s *Server) getCommentThreads(ctx context.Context, budget *commentThreadBudget, parentUri string, limit int64, cursor *string, depth int64, viewer string) ([]*vylet.FeedGetPostComments_ThreadComment, *string, error) {
+	resp, err := s.client.Comment.GetCommentsByParent(ctx, &vyletdatabase.GetCommentsByParentRequest{
+		ParentUri: parentUri,
+		Limit:     limit,
+		Cursor:    cursor,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get comments by parent: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, nil, fmt.Errorf("failed to get comments by parent: %s", *resp.Error)
+	}
+
+	if len(resp.Comments) == 0 {
+		return []*vylet.FeedGetPostComments_ThreadComment{}, resp.Cursor, nil
+	}
+
+	commentViews, err := s.commentsToCommentViews(ctx, resp.Comments, viewer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	threads := make([]*vylet.FeedGetPostComments_ThreadComment, 0, len(resp.Comments))
+	for _, comment := range resp.Comments {
+		commentView, ok := commentViews[comment.Uri]
+		if !ok {
+			continue
+		}
+		threads = append(threads, &vylet.FeedGetPostComments_ThreadComment{
+			Comment: commentView,
+		})
+	}
+
+	// replies are only fetched for comments that have any, which keeps deep threads from fanning out needlessly
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(commentThreadConcurrency)
+	for _, thread := range threads {
+		if thread.Comment.ReplyCount == nil || *thread.Comment.ReplyCount == 0 {
+			continue
+		}
+
+		if depth == 0 || !budget.take(commentRepliesLimit) {
+			thread.HasMoreReplies = helpers.ToBoolPtr(true)
+			continue
+		}
+
+		g.Go(func() error {
+			replies, repliesCursor, err := s.getCommentThreads(gCtx, budget, thread.Comment.Uri, commentRepliesLimit, nil, depth-1, viewer)
+			if err != nil {
+				return err
+			}
+			thread.Replies = replies
+			if repliesCursor != nil {
+				thread.HasMoreReplies = helpers.ToBoolPtr(true)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return threads, resp.Cursor, nil
+}
+
+func (s *Server) FeedGetPostCommentsRequiresAuth() bool {
+	return false
+}
+
+func (s *Server) HandleFeedGetPostComments(e echo.Context, input *handlers.FeedGetPostCommentsInput) (*vylet.FeedGetPostComments_Output, *echo.HTTPError) {
+	ctx := e.Request().Context()
+	viewer := getViewer(e)
+
+	logger := s.logger.With("name", "HandleFeedGetPostComments", "viewer", viewer)
+
+	if input.Uri == "" {
+		return nil, NewValidationError("uri", "URI must be provided")
+	}
+
+	if _, err := syntax.ParseATURI(input.Uri); err != nil {
+		return nil, NewValidationError("uri", "URI must be a valid AT-URI")
+	}
+
+	if input.Limit != nil && (*input.Limit < 1 || *input.Limit > 100) {
+		return nil, NewValidationError("limit", "limit must be between 1 and 100")
+	} else if input.Limit == nil {
+		input.Limit = helpers.ToInt64Ptr(25)
+	}
+
+	if input.Depth != nil && (*input.Depth < 0 || *input.Depth > maxCommentDepth) {
+		return nil, NewValidationError("depth", fmt.Sprintf("depth must be between 0 and %d", maxCommentDepth))
+	} else if input.Depth == nil {
+		input.Depth = helpers.ToInt64Ptr(3)
+	}
+
+	logger = logger.With("uri", input.Uri)
+
+	comments, cursor, err := s.getCommentThreads(ctx, newCommentThreadBudget(), input.Uri, *input.Limit, input.Cursor, *input.Depth, viewer)
+	if err != nil {
+		logger.Error("failed to get comment threads", "err", err)
+		return nil, ErrInternalServerErr
+	}
+
+	return &vylet.FeedGetPostComments_Output{
+		Comments: comments,
+		Cursor:   cursor,
+		Uri:      input.Uri,
+	}, nil
+}
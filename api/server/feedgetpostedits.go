@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/labstack/echo/v4"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/generated/handlers"
+	"github.com/vylet-app/go/generated/vylet"
+	"github.com/vylet-app/go/internal/helpers"
+)
+
+func (s *Server) getPostEdits(ctx context.Context, uri string, limit int64, cursor *string) ([]*vylet.FeedGetPostEdits_Edit, *string, error) {
+	logger := s.logger.With("name", "getPostEdits", "uri", uri)
+
+	resp, err := s.client.Post.GetPostEdits(ctx, &vyletdatabase.GetPostEditsRequest{
+		Uri:    uri,
+		Limit:  limit,
+		Cursor: cursor,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get post edits: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, nil, fmt.Errorf("failed to get post edits: %s", *resp.Error)
+	}
+
+	edits := make([]*vylet.FeedGetPostEdits_Edit, 0, len(resp.Edits))
+	for _, edit := range resp.Edits {
+		feedEdit := &vylet.FeedGetPostEdits_Edit{
+			Caption:  edit.Caption,
+			Cid:      edit.Cid,
+			EditedAt: edit.EditedAt.AsTime().Format(time.RFC3339Nano),
+		}
+
+		if edit.Facets != nil {
+			var facets []*vylet.RichtextFacet
+			if err := json.Unmarshal(edit.Facets, &facets); err != nil {
+				logger.Error("failed to unmarshal edit facets", "cid", edit.Cid, "err", err)
+			} else {
+				feedEdit.Facets = facets
+			}
+		}
+
+		edits = append(edits, feedEdit)
+	}
+
+	return edits, resp.Cursor, nil
+}
+
+func (s *Server) FeedGetPostEditsRequiresAuth() bool {
+	return false
+}
+
+func (s *Server) HandleFeedGetPostEdits(e echo.Context, input *handlers.FeedGetPostEditsInput) (*vylet.FeedGetPostEdits_Output, *echo.HTTPError) {
+	ctx := e.Request().Context()
+
+	logger := s.logger.With("name", "HandleFeedGetPostEdits")
+
+	if input.Uri == "" {
+		return nil, NewValidationError("uri", "URI must be provided")
+	}
+
+	if _, err := syntax.ParseATURI(input.Uri); err != nil {
+		return nil, NewValidationError("uri", "URI must be a valid AT-URI")
+	}
+
+	if input.Limit != nil && (*input.Limit < 1 || *input.Limit > 100) {
+		return nil, NewValidationError("limit", "limit must be between 1 and 100")
+	} else if input.Limit == nil {
+		input.Limit = helpers.ToInt64Ptr(25)
+	}
+
+	logger = logger.With("uri", input.Uri)
+
+	edits, cursor, err := s.getPostEdits(ctx, input.Uri, *input.Limit, input.Cursor)
+	if err != nil {
+		logger.Error("failed to get post edits", "err", err)
+		return nil, ErrInternalServerErr
+	}
+
+	return &vylet.FeedGetPostEdits_Output{
+		Edits:  edits,
+		Cursor: cursor,
+		Uri:    input.Uri,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"sort"
 	"time"
 
@@ -119,7 +120,7 @@ func (s *Server) postsToPostViews(ctx context.Context, posts map[string]*vyletda
 	var profiles map[string]*vylet.ActorDefs_ProfileViewBasic
 	var countsResp *vyletdatabase.GetPostsInteractionCountsResponse
 	g.Go(func() error {
-		maybeProfiles, err := s.getProfilesBasic(gCtx, dids)
+		maybeProfiles, err := s.getProfilesBasic(gCtx, dids, viewer)
 		if err != nil {
 			return err
 		}
@@ -143,11 +144,16 @@ func (s *Server) postsToPostViews(ctx context.Context, posts map[string]*vyletda
 
 	feedPostViews := make(map[string]*vylet.FeedDefs_PostView)
 	for _, post := range posts {
+		// inactive accounts have no profile, so their posts are hidden here too
 		profileBasic, ok := profiles[post.AuthorDid]
 		if !ok {
 			logger.Warn("failed to get profile for post", "did", post.AuthorDid, "uri", post.Uri)
 			continue
 		}
+		// posts of actors the viewer blocks or is blocked by are hidden in every view
+		if isBlocked(profileBasic.Viewer) {
+			continue
+		}
 		counts, ok := countsResp.Counts[post.Uri]
 		if !ok {
 			logger.Warn("failed to get counts for post", "uri", post.Uri)
@@ -169,6 +175,10 @@ func (s *Server) postsToPostViews(ctx context.Context, posts map[string]*vyletda
 			IndexedAt:  post.IndexedAt.AsTime().Format(time.RFC3339Nano),
 		}
 
+		if post.EditedAt != nil {
+			postView.EditedAt = helpers.ToStringPtr(post.EditedAt.AsTime().Format(time.RFC3339Nano))
+		}
+
 		media := vylet.FeedDefs_PostView_Media{
 			MediaImages_View: &vylet.MediaImages_View{
 				Images: make([]*vylet.MediaImages_ViewImage, 0, len(post.Images)),
@@ -281,6 +291,15 @@ func (s *Server) HandleFeedGetActorPosts(e echo.Context, input *handlers.FeedGet
 		return nil, ErrInternalServerErr
 	}
 
+	if err := s.checkBlocked(ctx, viewer, did); err != nil {
+		var blockedErr *ActorBlockedError
+		if errors.As(err, &blockedErr) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, blockedErr.Error())
+		}
+		logger.Error("error checking blocks", "err", err)
+		return nil, ErrInternalServerErr
+	}
+
 	resp, err := s.client.Post.GetPostsByActor(ctx, &vyletdatabase.GetPostsByActorRequest{
 		Did:    did,
 		Limit:  *input.Limit,
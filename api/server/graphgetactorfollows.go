@@ -2,6 +2,7 @@ package server
 
 import (
 	"errors"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
 	vyletdatabase "github.com/vylet-app/go/database/proto"
@@ -16,8 +17,9 @@ func (s *Server) GraphGetActorFollowsRequiresAuth() bool {
 
 func (s *Server) HandleGraphGetActorFollows(e echo.Context, input *handlers.GraphGetActorFollowsInput) (*vylet.GraphGetActorFollows_Output, *echo.HTTPError) {
 	ctx := e.Request().Context()
+	viewer := getViewer(e)
 
-	logger := s.logger.With("name", "HandleGraphGetActorFollows")
+	logger := s.logger.With("name", "HandleGraphGetActorFollows", "viewer", viewer)
 
 	if input.Limit != nil && (*input.Limit < 1 || *input.Limit > 100) {
 		return nil, NewValidationError("limit", "limit must be between 1 and 100")
@@ -34,6 +36,15 @@ func (s *Server) HandleGraphGetActorFollows(e echo.Context, input *handlers.Grap
 		return nil, ErrInternalServerErr
 	}
 
+	if err := s.checkBlocked(ctx, viewer, did); err != nil {
+		var blockedErr *ActorBlockedError
+		if errors.As(err, &blockedErr) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, blockedErr.Error())
+		}
+		logger.Error("error checking blocks", "err", err)
+		return nil, ErrInternalServerErr
+	}
+
 	resp, err := s.client.Follow.GetFollowsByActor(ctx, &vyletdatabase.GetFollowsByActorRequest{
 		Did:    did,
 		Limit:  *input.Limit,
@@ -49,7 +60,7 @@ func (s *Server) HandleGraphGetActorFollows(e echo.Context, input *handlers.Grap
 		dids = append(dids, f.SubjectDid)
 	}
 
-	profiles, err := s.getProfiles(ctx, dids)
+	profiles, err := s.getProfiles(ctx, dids, viewer)
 	if err != nil {
 		logger.Error("error getting profiles", "err", err)
 		return nil, ErrInternalServerErr
@@ -62,6 +73,9 @@ func (s *Server) HandleGraphGetActorFollows(e echo.Context, input *handlers.Grap
 			logger.Warn("unable to find profile", "did", did)
 			continue
 		}
+		if isBlocked(profile.Viewer) || isMuted(profile.Viewer) {
+			continue
+		}
 		sortedProfiles = append(sortedProfiles, profile)
 	}
 
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/vylet-app/go/database/client"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
 )
 
 var (
@@ -13,15 +16,133 @@ var (
 	ErrActorNotValid      = errors.New("actor was not a valid did or handle")
 )
 
+// handlesFromDids returns the handle of each did, keyed by did. Handles come from the index kept by the indexer, and
+// only dids missing from it are resolved through the directory, then written back to it. Dids that fail to resolve
+// are left out.
+func (s *Server) handlesFromDids(ctx context.Context, dids []string) (map[string]string, error) {
+	handles := make(map[string]string, len(dids))
+	if len(dids) == 0 {
+		return handles, nil
+	}
+
+	resp, err := s.client.Identity.GetHandles(ctx, &vyletdatabase.GetHandlesRequest{
+		Dids: dids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting handles: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to get handles: %s", *resp.Error)
+	}
+
+	// indexed handles are filled in before any lookup starts, since the lookups write to the map concurrently
+	var missing []string
+	for _, did := range dids {
+		if handle, ok := resp.Handles[did]; ok {
+			handles[did] = handle.Handle
+			continue
+		}
+		missing = append(missing, did)
+	}
+
+	var wg sync.WaitGroup
+	var lk sync.Mutex
+	for _, did := range missing {
+		parsed, err := syntax.ParseDID(did)
+		if err != nil {
+			s.logger.Warn("invalid did", "did", did, "err", err)
+			continue
+		}
+
+		wg.Go(func() {
+			doc, err := s.directory.LookupDID(ctx, parsed)
+			if err != nil {
+				s.logger.Error("error getting handle for did", "did", did, "err", err)
+				return
+			}
+
+			s.storeHandle(ctx, parsed, doc.Handle)
+
+			lk.Lock()
+			defer lk.Unlock()
+			handles[did] = doc.Handle.String()
+		})
+	}
+	wg.Wait()
+
+	return handles, nil
+}
+
 func (s *Server) handleFromDid(ctx context.Context, did syntax.DID) (syntax.Handle, error) {
+	resp, err := s.client.Identity.GetHandles(ctx, &vyletdatabase.GetHandlesRequest{
+		Dids: []string{did.String()},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting handles: %w", err)
+	}
+	if resp.Error != nil {
+		return "", fmt.Errorf("failed to get handles: %s", *resp.Error)
+	}
+
+	if handle, ok := resp.Handles[did.String()]; ok {
+		return syntax.Handle(handle.Handle), nil
+	}
+
 	doc, err := s.directory.LookupDID(ctx, did)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch did doc: %w", err)
 	}
 
+	s.storeHandle(ctx, did, doc.Handle)
+
 	return doc.Handle, nil
 }
 
+func (s *Server) didFromHandle(ctx context.Context, handle syntax.Handle) (syntax.DID, error) {
+	resp, err := s.client.Identity.GetDidByHandle(ctx, &vyletdatabase.GetDidByHandleRequest{
+		Handle: handle.String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting did by handle: %w", err)
+	}
+	if resp.Error != nil && !client.IsNotFoundError(resp.Error) {
+		return "", fmt.Errorf("failed to get did by handle: %s", *resp.Error)
+	}
+
+	if resp.Did != nil {
+		return syntax.DID(*resp.Did), nil
+	}
+
+	// looked up rather than only resolved, so that the handle is verified against the did document before it is stored
+	ident, err := s.directory.LookupHandle(ctx, handle)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve handle: %w", err)
+	}
+
+	s.storeHandle(ctx, ident.DID, ident.Handle)
+
+	return ident.DID, nil
+}
+
+// storeHandle writes a handle resolved through the directory back to the index, so that the next lookup of the actor
+// does not miss it again. The directory caches, so the handle is stored without an update time, which only applies it
+// when the indexer has not stored one meanwhile. Failures are only logged, since the handle was resolved either way.
+func (s *Server) storeHandle(ctx context.Context, did syntax.DID, handle syntax.Handle) {
+	resp, err := s.client.Identity.SetHandle(ctx, &vyletdatabase.SetHandleRequest{
+		Handle: &vyletdatabase.Handle{
+			Did:    did.String(),
+			Handle: handle.String(),
+		},
+	})
+	if err != nil {
+		s.logger.Error("error storing resolved handle", "did", did, "handle", handle, "err", err)
+		return
+	}
+	if resp.Error != nil {
+		s.logger.Error("failed to store resolved handle", "did", did, "handle", handle, "err", *resp.Error)
+	}
+}
+
 // Given either a valid DID or handle, finds both the DID and handle for said actor and returns them.
 // Returns ErrActorNotValid if the actor is not a valid DID or handle.
 func (s *Server) fetchDidHandleFromActor(ctx context.Context, actor string) (string, string, error) {
@@ -50,7 +171,7 @@ func (s *Server) fetchDidHandleFromActor(ctx context.Context, actor string) (str
 		}
 		handle = &maybeHandle
 	} else if handle != nil {
-		maybeDid, err := s.directory.ResolveHandle(ctx, *handle)
+		maybeDid, err := s.didFromHandle(ctx, *handle)
 		if err != nil {
 			logger.Error("error getting did", "err", err)
 			return "", "", err
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/generated/handlers"
+	"github.com/vylet-app/go/generated/vylet"
+	"github.com/vylet-app/go/internal/helpers"
+)
+
+// getMutedDids returns the dids that the viewer has muted. Mutes are private, so this is only ever asked on behalf of
+// the viewer themselves, and there are none for logged out viewers.
+func (s *Server) getMutedDids(ctx context.Context, viewer string, dids []string) (map[string]bool, error) {
+	muted := make(map[string]bool)
+	if viewer == "" || len(dids) == 0 {
+		return muted, nil
+	}
+
+	resp, err := s.client.Mute.GetMutedDids(ctx, &vyletdatabase.GetMutedDidsRequest{
+		ActorDid: viewer,
+		Dids:     dids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting muted dids: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to get muted dids: %s", *resp.Error)
+	}
+
+	for _, did := range resp.MutedDids {
+		muted[did] = true
+	}
+
+	return muted, nil
+}
+
+// mutedState returns the muted field of a viewer state, which is left unset for actors the viewer has not muted
+func mutedState(muted bool) *bool {
+	if !muted {
+		return nil
+	}
+	return &muted
+}
+
+// isMuted reports whether the viewer has muted an actor, in which case the actor is left out of lists
+func isMuted(viewerState *vylet.ActorDefs_ViewerState) bool {
+	return viewerState != nil && viewerState.Muted != nil && *viewerState.Muted
+}
+
+// resolveMuteSubject resolves the actor of a mute or unmute to a did, rejecting the viewer's own account
+func (s *Server) resolveMuteSubject(ctx context.Context, viewer, actor string) (string, *echo.HTTPError) {
+	if actor == "" {
+		return "", NewValidationError("actor", "actor is required")
+	}
+
+	did, _, err := s.fetchDidHandleFromActor(ctx, actor)
+	if err != nil {
+		if errors.Is(err, ErrActorNotValid) {
+			return "", NewValidationError("actor", "actor must be a valid DID or handle")
+		}
+		s.logger.Error("error did from actor", "actor", actor, "err", err)
+		return "", ErrInternalServerErr
+	}
+
+	if did == viewer {
+		return "", NewValidationError("actor", "cannot mute oneself")
+	}
+
+	return did, nil
+}
+
+func (s *Server) GraphMuteActorRequiresAuth() bool {
+	return true
+}
+
+func (s *Server) HandleGraphMuteActor(e echo.Context, input *handlers.GraphMuteActorInput) *echo.HTTPError {
+	ctx := e.Request().Context()
+	viewer := getViewer(e)
+
+	logger := s.logger.With("name", "HandleGraphMuteActor", "viewer", viewer, "actor", input.Actor)
+
+	did, httpErr := s.resolveMuteSubject(ctx, viewer, input.Actor)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	resp, err := s.client.Mute.CreateMute(ctx, &vyletdatabase.CreateMuteRequest{
+		ActorDid:   viewer,
+		SubjectDid: did,
+	})
+	if err != nil {
+		logger.Error("error creating mute", "err", err)
+		return ErrInternalServerErr
+	}
+	if resp.Error != nil {
+		logger.Error("failed to create mute", "err", *resp.Error)
+		return ErrInternalServerErr
+	}
+
+	return nil
+}
+
+func (s *Server) GraphUnmuteActorRequiresAuth() bool {
+	return true
+}
+
+func (s *Server) HandleGraphUnmuteActor(e echo.Context, input *handlers.GraphUnmuteActorInput) *echo.HTTPError {
+	ctx := e.Request().Context()
+	viewer := getViewer(e)
+
+	logger := s.logger.With("name", "HandleGraphUnmuteActor", "viewer", viewer, "actor", input.Actor)
+
+	did, httpErr := s.resolveMuteSubject(ctx, viewer, input.Actor)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	resp, err := s.client.Mute.DeleteMute(ctx, &vyletdatabase.DeleteMuteRequest{
+		ActorDid:   viewer,
+		SubjectDid: did,
+	})
+	if err != nil {
+		logger.Error("error deleting mute", "err", err)
+		return ErrInternalServerErr
+	}
+	if resp.Error != nil {
+		logger.Error("failed to delete mute", "err", *resp.Error)
+		return ErrInternalServerErr
+	}
+
+	return nil
+}
+
+func (s *Server) GraphGetMutesRequiresAuth() bool {
+	return true
+}
+
+func (s *Server) HandleGraphGetMutes(e echo.Context, input *handlers.GraphGetMutesInput) (*vylet.GraphGetMutes_Output, *echo.HTTPError) {
+	ctx := e.Request().Context()
+	viewer := getViewer(e)
+
+	logger := s.logger.With("name", "HandleGraphGetMutes", "viewer", viewer)
+
+	if input.Limit != nil && (*input.Limit < 1 || *input.Limit > 100) {
+		return nil, NewValidationError("limit", "limit must be between 1 and 100")
+	} else if input.Limit == nil {
+		input.Limit = helpers.ToInt64Ptr(50)
+	}
+
+	resp, err := s.client.Mute.GetMutesByActor(ctx, &vyletdatabase.GetMutesByActorRequest{
+		Did:    viewer,
+		Limit:  *input.Limit,
+		Cursor: input.Cursor,
+	})
+	if err != nil {
+		logger.Error("error getting mutes", "err", err)
+		return nil, ErrInternalServerErr
+	}
+	if resp.Error != nil {
+		logger.Error("failed to get mutes", "err", *resp.Error)
+		return nil, ErrInternalServerErr
+	}
+
+	dids := make([]string, 0, len(resp.Mutes))
+	for _, m := range resp.Mutes {
+		dids = append(dids, m.SubjectDid)
+	}
+
+	profiles, err := s.getProfiles(ctx, dids, viewer)
+	if err != nil {
+		logger.Error("error getting profiles", "err", err)
+		return nil, ErrInternalServerErr
+	}
+
+	// muted actors are listed even when blocked, as this is the only place the viewer can unmute them
+	sortedProfiles := make([]*vylet.ActorDefs_ProfileView, 0, len(profiles))
+	for _, did := range dids {
+		profile, ok := profiles[did]
+		if !ok {
+			logger.Warn("unable to find profile", "did", did)
+			continue
+		}
+		sortedProfiles = append(sortedProfiles, profile)
+	}
+
+	return &vylet.GraphGetMutes_Output{
+		Mutes:  sortedProfiles,
+		Cursor: resp.Cursor,
+	}, nil
+}
@@ -0,0 +1,275 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/labstack/echo/v4"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/generated/handlers"
+	"github.com/vylet-app/go/generated/vylet"
+	"github.com/vylet-app/go/internal/helpers"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) NotificationListNotificationsRequiresAuth() bool {
+	return true
+}
+
+func (s *Server) HandleNotificationListNotifications(e echo.Context, input *handlers.NotificationListNotificationsInput) (*vylet.NotificationListNotifications_Output, *echo.HTTPError) {
+	ctx := e.Request().Context()
+	viewer := getViewer(e)
+
+	logger := s.logger.With("name", "HandleNotificationListNotifications", "viewer", viewer)
+
+	if input.Limit != nil && (*input.Limit < 1 || *input.Limit > 100) {
+		return nil, NewValidationError("limit", "limit must be between 1 and 100")
+	} else if input.Limit == nil {
+		input.Limit = helpers.ToInt64Ptr(50)
+	}
+
+	resp, err := s.client.Notification.GetNotifications(ctx, &vyletdatabase.GetNotificationsRequest{
+		Did:    viewer,
+		Limit:  *input.Limit,
+		Cursor: input.Cursor,
+	})
+	if err != nil {
+		logger.Error("error getting notifications", "err", err)
+		return nil, ErrInternalServerErr
+	}
+	if resp.Error != nil {
+		logger.Error("failed to get notifications", "err", *resp.Error)
+		return nil, ErrInternalServerErr
+	}
+
+	notifications, err := s.notificationsToViews(ctx, resp.Notifications, resp.SeenAt.AsTime(), viewer)
+	if err != nil {
+		logger.Error("error hydrating notifications", "err", err)
+		return nil, ErrInternalServerErr
+	}
+
+	output := &vylet.NotificationListNotifications_Output{
+		Notifications: notifications,
+		Cursor:        resp.Cursor,
+	}
+	if resp.SeenAt != nil {
+		output.SeenAt = helpers.ToStringPtr(resp.SeenAt.AsTime().Format(time.RFC3339Nano))
+	}
+
+	return output, nil
+}
+
+// notificationsToViews hydrates the author of every notification, along with the posts and comments that its record
+// and reason subject point to. Notifications from actors that are blocked or muted, or whose profiles are gone, are
+// left out.
+func (s *Server) notificationsToViews(ctx context.Context, notifications []*vyletdatabase.Notification, seenAt time.Time, viewer string) ([]*vylet.NotificationListNotifications_Notification, error) {
+	logger := s.logger.With("name", "notificationsToViews")
+
+	var (
+		dids        []string
+		postUris    []string
+		commentUris []string
+	)
+	addedDids := make(map[string]struct{})
+	addedUris := make(map[string]struct{})
+	addUri := func(uri string) {
+		if _, ok := addedUris[uri]; ok {
+			return
+		}
+		addedUris[uri] = struct{}{}
+
+		switch notificationUriCollection(uri) {
+		case "app.vylet.feed.post":
+			postUris = append(postUris, uri)
+		case "app.vylet.feed.comment":
+			commentUris = append(commentUris, uri)
+		}
+	}
+	for _, n := range notifications {
+		if _, ok := addedDids[n.AuthorDid]; !ok {
+			dids = append(dids, n.AuthorDid)
+			addedDids[n.AuthorDid] = struct{}{}
+		}
+
+		addUri(n.Uri)
+		if n.ReasonSubject != nil {
+			addUri(*n.ReasonSubject)
+		}
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	var profiles map[string]*vylet.ActorDefs_ProfileView
+	posts := make(map[string]*vylet.FeedDefs_PostView)
+	comments := make(map[string]*vylet.FeedDefs_CommentView)
+	g.Go(func() error {
+		maybeProfiles, err := s.getProfiles(gCtx, dids, viewer)
+		if err != nil {
+			return err
+		}
+		profiles = maybeProfiles
+		return nil
+	})
+	if len(postUris) > 0 {
+		g.Go(func() error {
+			maybePosts, err := s.getPostViews(gCtx, postUris, viewer)
+			if err != nil {
+				// the posts may all have been deleted since
+				if errors.Is(err, ErrDatabaseNotFound) {
+					return nil
+				}
+				return err
+			}
+			posts = maybePosts
+			return nil
+		})
+	}
+	if len(commentUris) > 0 {
+		g.Go(func() error {
+			resp, err := s.client.Comment.GetComments(gCtx, &vyletdatabase.GetCommentsRequest{
+				Uris: commentUris,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get comments: %w", err)
+			}
+			if resp.Error != nil {
+				return fmt.Errorf("failed to get comments: %s", *resp.Error)
+			}
+			if len(resp.Comments) == 0 {
+				return nil
+			}
+
+			dbComments := make([]*vyletdatabase.Comment, 0, len(resp.Comments))
+			for _, comment := range resp.Comments {
+				dbComments = append(dbComments, comment)
+			}
+
+			maybeComments, err := s.commentsToCommentViews(gCtx, dbComments, viewer)
+			if err != nil {
+				return err
+			}
+			comments = maybeComments
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting metadata: %w", err)
+	}
+
+	views := make([]*vylet.NotificationListNotifications_Notification, 0, len(notifications))
+	for _, n := range notifications {
+		profile, ok := profiles[n.AuthorDid]
+		if !ok {
+			logger.Warn("failed to get profile for notification", "did", n.AuthorDid, "uri", n.Uri)
+			continue
+		}
+		if isBlocked(profile.Viewer) || isMuted(profile.Viewer) {
+			continue
+		}
+
+		indexedAt := n.IndexedAt.AsTime()
+		view := &vylet.NotificationListNotifications_Notification{
+			Author:        profile,
+			Cid:           n.Cid,
+			IndexedAt:     indexedAt.Format(time.RFC3339Nano),
+			IsRead:        !indexedAt.After(seenAt),
+			Reason:        n.Reason,
+			ReasonSubject: n.ReasonSubject,
+			Uri:           n.Uri,
+		}
+
+		// the notification's own record takes precedence over its reason subject, so a comment replying to a comment
+		// shows the reply
+		uris := []string{n.Uri}
+		if n.ReasonSubject != nil {
+			uris = append(uris, *n.ReasonSubject)
+		}
+		for _, uri := range uris {
+			if post, ok := posts[uri]; ok && view.Post == nil {
+				view.Post = post
+			}
+			if comment, ok := comments[uri]; ok && view.Comment == nil {
+				view.Comment = comment
+			}
+		}
+
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+// notificationUriCollection returns the collection of a record uri, or an empty string if it is not a valid at-uri
+func notificationUriCollection(uri string) string {
+	parsed, err := syntax.ParseATURI(uri)
+	if err != nil {
+		return ""
+	}
+	return parsed.Collection().String()
+}
+
+func (s *Server) NotificationGetUnreadCountRequiresAuth() bool {
+	return true
+}
+
+func (s *Server) HandleNotificationGetUnreadCount(e echo.Context, input *handlers.NotificationGetUnreadCountInput) (*vylet.NotificationGetUnreadCount_Output, *echo.HTTPError) {
+	ctx := e.Request().Context()
+	viewer := getViewer(e)
+
+	logger := s.logger.With("name", "HandleNotificationGetUnreadCount", "viewer", viewer)
+
+	resp, err := s.client.Notification.GetUnreadNotificationCount(ctx, &vyletdatabase.GetUnreadNotificationCountRequest{
+		Did: viewer,
+	})
+	if err != nil {
+		logger.Error("error getting unread count", "err", err)
+		return nil, ErrInternalServerErr
+	}
+	if resp.Error != nil {
+		logger.Error("failed to get unread count", "err", *resp.Error)
+		return nil, ErrInternalServerErr
+	}
+
+	return &vylet.NotificationGetUnreadCount_Output{
+		Count: resp.Count,
+	}, nil
+}
+
+func (s *Server) NotificationUpdateSeenRequiresAuth() bool {
+	return true
+}
+
+func (s *Server) HandleNotificationUpdateSeen(e echo.Context, input *handlers.NotificationUpdateSeenInput) *echo.HTTPError {
+	ctx := e.Request().Context()
+	viewer := getViewer(e)
+
+	logger := s.logger.With("name", "HandleNotificationUpdateSeen", "viewer", viewer)
+
+	seenAt, err := time.Parse(time.RFC3339Nano, input.SeenAt)
+	if err != nil {
+		return NewValidationError("seenAt", "seenAt must be a valid datetime")
+	}
+
+	// a time in the future would mark notifications that have not happened yet as read
+	if now := time.Now().UTC(); seenAt.After(now) {
+		seenAt = now
+	}
+
+	resp, err := s.client.Notification.UpdateNotificationsSeen(ctx, &vyletdatabase.UpdateNotificationsSeenRequest{
+		Did:    viewer,
+		SeenAt: timestamppb.New(seenAt),
+	})
+	if err != nil {
+		logger.Error("error updating seen at", "err", err)
+		return ErrInternalServerErr
+	}
+	if resp.Error != nil {
+		logger.Error("failed to update seen at", "err", *resp.Error)
+		return ErrInternalServerErr
+	}
+
+	return nil
+}
@@ -0,0 +1,341 @@
+package kafkabackfill
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/vylet-app/go/bus/headers"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	"github.com/vylet-app/go/generated/vylet"
+	"github.com/vylet-app/go/internal/helpers"
+	"github.com/vylet-app/go/internal/repotest"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testOutputTopic = "firehose-events-backfill"
+	testCreatedAt   = "2025-01-01T00:00:00Z"
+)
+
+// testKafka records what the backfill produces and marks for commit
+type testKafka struct {
+	lk        sync.Mutex
+	produced  []*kgo.Record
+	committed []*kgo.Record
+}
+
+func (k *testKafka) produceSync(_ context.Context, recs ...*kgo.Record) kgo.ProduceResults {
+	k.lk.Lock()
+	defer k.lk.Unlock()
+
+	var results kgo.ProduceResults
+	for _, r := range recs {
+		k.produced = append(k.produced, r)
+		results = append(results, kgo.ProduceResult{Record: r})
+	}
+	return results
+}
+
+func (k *testKafka) markCommit(recs ...*kgo.Record) {
+	k.lk.Lock()
+	defer k.lk.Unlock()
+
+	k.committed = append(k.committed, recs...)
+}
+
+// events summarizes the events produced to the output topic as "did op collection/rkey rev", suffixed with
+// " backfill" for synthetic creates
+func (k *testKafka) events(t *testing.T) []string {
+	k.lk.Lock()
+	defer k.lk.Unlock()
+
+	var out []string
+	for _, r := range k.produced {
+		if r.Topic != testOutputTopic {
+			continue
+		}
+
+		var evt vyletkafka.FirehoseEvent
+		if err := proto.Unmarshal(r.Value, &evt); err != nil {
+			t.Fatalf("failed to unmarshal produced event: %v", err)
+		}
+
+		s := fmt.Sprintf("%s %s %s/%s %s", evt.Did, strings.ToLower(strings.TrimPrefix(evt.Commit.Operation.String(), "COMMIT_OPERATION_")), evt.Commit.Collection, evt.Commit.Rkey, evt.Commit.Rev)
+		if slices.ContainsFunc(r.Headers, func(h kgo.RecordHeader) bool { return h.Key == headers.Backfill }) {
+			s += " backfill"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (k *testKafka) progress(t *testing.T) map[string]*vyletkafka.BackfillProgress {
+	k.lk.Lock()
+	defer k.lk.Unlock()
+
+	out := make(map[string]*vyletkafka.BackfillProgress)
+	for _, r := range k.produced {
+		if r.Topic != ProgressTopic(testOutputTopic) {
+			continue
+		}
+
+		var progress vyletkafka.BackfillProgress
+		if err := proto.Unmarshal(r.Value, &progress); err != nil {
+			t.Fatalf("failed to unmarshal progress: %v", err)
+		}
+		out[progress.Did] = &progress
+	}
+	return out
+}
+
+// committedOffset is the last offset marked for commit, or -1
+func (k *testKafka) committedOffset() int64 {
+	k.lk.Lock()
+	defer k.lk.Unlock()
+
+	if len(k.committed) == 0 {
+		return -1
+	}
+	return k.committed[len(k.committed)-1].Offset
+}
+
+func newTestBackfill(t *testing.T, maxBufferedEvents int, repos ...*repotest.Repo) (*KafkaBackfill, *testKafka) {
+	relay := repotest.NewRelay(t, repos...)
+	pds := repotest.NewPDS(t, repos...)
+
+	k := &testKafka{}
+
+	return &KafkaBackfill{
+		logger: slog.New(slog.DiscardHandler),
+
+		relay:     atclient.NewAPIClient(relay.URL),
+		pdsClient: http.DefaultClient,
+
+		outputTopic:   testOutputTopic,
+		progressTopic: ProgressTopic(testOutputTopic),
+		pdsHost:       pds.URL,
+		collections: []string{
+			records.CollectionActorProfile,
+			records.CollectionFeedPost,
+			records.CollectionFeedLike,
+			records.CollectionFeedComment,
+			records.CollectionGraphFollow,
+			records.CollectionGraphBlock,
+		},
+		workers:           2,
+		maxBufferedEvents: maxBufferedEvents,
+
+		produceSync: k.produceSync,
+		markCommit:  k.markCommit,
+
+		repos:      make(map[string]*repoState),
+		partitions: make(map[int32]*partitionOffsets),
+	}, k
+}
+
+func newPost(caption string) *vylet.FeedPost {
+	return &vylet.FeedPost{
+		LexiconTypeID: records.CollectionFeedPost,
+		Caption:       helpers.ToStringPtr(caption),
+		CreatedAt:     testCreatedAt,
+	}
+}
+
+// liveCommit builds a consumed firehose record for a commit on partition 0
+func liveCommit(t *testing.T, offset int64, did, rev string, op vyletkafka.CommitOperation, collection, rkey string) *kgo.Record {
+	b, err := proto.Marshal(&vyletkafka.FirehoseEvent{
+		Did: did,
+		Commit: &vyletkafka.Commit{
+			Rev:        rev,
+			Operation:  op,
+			Collection: collection,
+			Rkey:       rkey,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+
+	return &kgo.Record{
+		Key:       []byte(did),
+		Value:     b,
+		Partition: 0,
+		Offset:    offset,
+	}
+}
+
+func TestBackfillEmitsCreates(t *testing.T) {
+	ctx := t.Context()
+
+	alice := repotest.NewRepo(t, "did:plc:alice")
+	alice.Put(records.CollectionActorProfile, "self", &vylet.ActorProfile{
+		LexiconTypeID: records.CollectionActorProfile,
+		DisplayName:   helpers.ToStringPtr("Alice"),
+		CreatedAt:     testCreatedAt,
+	})
+	alice.Put(records.CollectionFeedPost, "3kpost", newPost("hello"))
+	alice.Put(records.CollectionGraphFollow, "3kfollow", &vylet.GraphFollow{
+		LexiconTypeID: records.CollectionGraphFollow,
+		Subject:       "did:plc:bob",
+		CreatedAt:     testCreatedAt,
+	})
+	// records outside of the backfilled collections are skipped
+	alice.Put("app.bsky.feed.post", "3kother", newPost("elsewhere"))
+	aliceRev := alice.Commit()
+
+	bob := repotest.NewRepo(t, "did:plc:bob")
+	bob.Put(records.CollectionFeedPost, "3kbob", newPost("already backfilled"))
+	bob.Commit()
+
+	carol := repotest.NewRepo(t, "did:plc:carol")
+	carol.Put("app.bsky.feed.post", "3kcarol", newPost("not a vylet user"))
+	carol.Commit()
+
+	kb, k := newTestBackfill(t, 100, alice, bob, carol)
+
+	dids, err := kb.enumerateRepos(ctx, map[string]struct{}{bob.Did(): {}})
+	if err != nil {
+		t.Fatalf("failed to enumerate repos: %v", err)
+	}
+	if want := []string{alice.Did()}; !slices.Equal(dids, want) {
+		t.Fatalf("enumerated %v, want %v", dids, want)
+	}
+
+	kb.backfillRepos(ctx, dids)
+
+	want := []string{
+		"did:plc:alice create app.vylet.actor.profile/self " + aliceRev + " backfill",
+		"did:plc:alice create app.vylet.feed.post/3kpost " + aliceRev + " backfill",
+		"did:plc:alice create app.vylet.graph.follow/3kfollow " + aliceRev + " backfill",
+	}
+	if got := k.events(t); !slices.Equal(got, want) {
+		t.Fatalf("produced events\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	progress := k.progress(t)[alice.Did()]
+	if progress == nil || progress.State != vyletkafka.BackfillState_BACKFILL_STATE_COMPLETE || progress.Rev != aliceRev || progress.Records != 3 {
+		t.Fatalf("unexpected progress %v", progress)
+	}
+
+	if len(kb.repos) != 0 {
+		t.Fatalf("repos still tracked after backfill: %v", kb.repos)
+	}
+}
+
+func TestBackfillReplaysHeldEvents(t *testing.T) {
+	ctx := t.Context()
+
+	alice := repotest.NewRepo(t, "did:plc:alice")
+	alice.Put(records.CollectionFeedPost, "3kkept", newPost("kept"))
+	alice.Put(records.CollectionFeedPost, "3kedited", newPost("edited"))
+	aliceRev := alice.Commit()
+
+	kb, k := newTestBackfill(t, 100, alice)
+
+	dids, err := kb.enumerateRepos(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to enumerate repos: %v", err)
+	}
+
+	// a delete of a record the fetched repo no longer has, older than the fetched rev, and an update newer than it
+	if err := kb.forwardRecords(ctx, []*kgo.Record{
+		liveCommit(t, 0, alice.Did(), "3aaaaaaaaaaaa", vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE, records.CollectionFeedPost, "3kdeleted"),
+		liveCommit(t, 1, "did:plc:bob", "3aaaaaaaaaaab", vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE, records.CollectionFeedPost, "3kbob"),
+		liveCommit(t, 2, alice.Did(), "3zzzzzzzzzzzz", vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE, records.CollectionFeedPost, "3kedited"),
+	}); err != nil {
+		t.Fatalf("failed to forward records: %v", err)
+	}
+
+	// only the repo that isn't queued is forwarded, and nothing is committable past the held delete
+	if got, want := k.events(t), []string{"did:plc:bob create app.vylet.feed.post/3kbob 3aaaaaaaaaaab"}; !slices.Equal(got, want) {
+		t.Fatalf("produced events %v, want %v", got, want)
+	}
+	if offset := k.committedOffset(); offset != -1 {
+		t.Fatalf("committed offset %d while events are held back", offset)
+	}
+
+	kb.backfillRepos(ctx, dids)
+
+	want := []string{
+		"did:plc:bob create app.vylet.feed.post/3kbob 3aaaaaaaaaaab",
+		"did:plc:alice create app.vylet.feed.post/3kedited " + aliceRev + " backfill",
+		"did:plc:alice create app.vylet.feed.post/3kkept " + aliceRev + " backfill",
+		"did:plc:alice delete app.vylet.feed.post/3kdeleted 3aaaaaaaaaaaa",
+		"did:plc:alice update app.vylet.feed.post/3kedited 3zzzzzzzzzzzz",
+	}
+	if got := k.events(t); !slices.Equal(got, want) {
+		t.Fatalf("produced events\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if offset := k.committedOffset(); offset != 2 {
+		t.Fatalf("committed offset %d, want 2", offset)
+	}
+
+	// once backfilled, the repo's live events pass straight through
+	if err := kb.forwardRecords(ctx, []*kgo.Record{
+		liveCommit(t, 3, alice.Did(), "3zzzzzzzzzzzy", vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE, records.CollectionFeedPost, "3kkept"),
+	}); err != nil {
+		t.Fatalf("failed to forward records: %v", err)
+	}
+	if got := k.events(t); got[len(got)-1] != "did:plc:alice delete app.vylet.feed.post/3kkept 3zzzzzzzzzzzy" {
+		t.Fatalf("live event after backfill not forwarded, produced %v", got)
+	}
+	if offset := k.committedOffset(); offset != 3 {
+		t.Fatalf("committed offset %d, want 3", offset)
+	}
+}
+
+func TestBackfillOverflowFailsRepo(t *testing.T) {
+	ctx := t.Context()
+
+	alice := repotest.NewRepo(t, "did:plc:alice")
+	alice.Put(records.CollectionFeedPost, "3kpost", newPost("hello"))
+	alice.Commit()
+
+	kb, k := newTestBackfill(t, 2, alice)
+
+	dids, err := kb.enumerateRepos(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to enumerate repos: %v", err)
+	}
+
+	var recs []*kgo.Record
+	for i, rkey := range []string{"3ka", "3kb", "3kc"} {
+		recs = append(recs, liveCommit(t, int64(i), alice.Did(), "3zzzzzzzzzzz"+fmt.Sprint(i), vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE, records.CollectionFeedPost, rkey))
+	}
+	if err := kb.forwardRecords(ctx, recs); err != nil {
+		t.Fatalf("failed to forward records: %v", err)
+	}
+
+	// the held events are forwarded in order ahead of the one that overflowed, rather than dropped
+	want := []string{
+		"did:plc:alice delete app.vylet.feed.post/3ka 3zzzzzzzzzzz0",
+		"did:plc:alice delete app.vylet.feed.post/3kb 3zzzzzzzzzzz1",
+		"did:plc:alice delete app.vylet.feed.post/3kc 3zzzzzzzzzzz2",
+	}
+	if got := k.events(t); !slices.Equal(got, want) {
+		t.Fatalf("produced events %v, want %v", got, want)
+	}
+	if offset := k.committedOffset(); offset != 2 {
+		t.Fatalf("committed offset %d, want 2", offset)
+	}
+
+	kb.backfillRepos(ctx, dids)
+
+	if got := k.events(t); !slices.Equal(got, want) {
+		t.Fatalf("failed repo produced events %v", got[len(want):])
+	}
+
+	progress := k.progress(t)[alice.Did()]
+	if progress == nil || progress.State != vyletkafka.BackfillState_BACKFILL_STATE_FAILED || !strings.Contains(progress.Error, "overflowed") {
+		t.Fatalf("unexpected progress %v", progress)
+	}
+}
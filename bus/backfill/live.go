@@ -0,0 +1,208 @@
+package kafkabackfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// errHeldOverflowed fails a repo's backfill once more live events arrived for it than can be held back
+var errHeldOverflowed = errors.New("held back live events overflowed")
+
+// repoState tracks a repo that is queued for or being backfilled. Repos without a state are either backfilled or
+// were never enumerated, and their live events are forwarded as they arrive.
+type repoState struct {
+	// held are the live events received since the repo was queued, replayed in order once its records are produced.
+	// Deletes and updates among them are not reflected by the synthetic creates, so none of them may be dropped.
+	held []*liveRecord
+	// cancel stops the repo's fetch, set once it started
+	cancel context.CancelFunc
+}
+
+// liveRecord is a consumed record along with the partition tracking it
+type liveRecord struct {
+	record    *kgo.Record
+	partition *partitionOffsets
+}
+
+// partitionOffsets tracks the records of an assigned partition that were consumed but not yet forwarded, in offset
+// order, so that a held back event's offset is only committed once it was produced
+type partitionOffsets struct {
+	records []*kgo.Record
+	done    map[int64]bool
+}
+
+// releaseRepo forgets the repo's state, returning its held back events and whether the repo was still tracked. A
+// repo is no longer tracked once it failed because of overflowing. Must be called with the lock held.
+func (kb *KafkaBackfill) releaseRepo(did string) ([]*liveRecord, bool) {
+	state, ok := kb.repos[did]
+	if !ok {
+		return nil, false
+	}
+	delete(kb.repos, did)
+
+	if state.cancel != nil {
+		state.cancel()
+	}
+
+	return state.held, true
+}
+
+// forwardLive copies the input topic to the output topic, holding back the events of repos that are queued for or
+// being backfilled. Offsets are committed up to the first record that was not forwarded yet, so held back events are
+// consumed again after a restart.
+func (kb *KafkaBackfill) forwardLive(ctx context.Context) error {
+	for {
+		fetches := kb.consumer.PollFetches(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errs := fetches.Errors(); len(errs) > 0 {
+			var err error
+			for _, e := range errs {
+				err = errors.Join(err, fmt.Errorf("topic %s partition %d: %w", e.Topic, e.Partition, e.Err))
+			}
+			return err
+		}
+
+		if err := kb.forwardRecords(ctx, fetches.Records()); err != nil {
+			return err
+		}
+	}
+}
+
+// forwardRecords forwards a batch of consumed records, holding back those of repos queued for backfill
+func (kb *KafkaBackfill) forwardRecords(ctx context.Context, recs []*kgo.Record) error {
+	kb.lk.Lock()
+	defer kb.lk.Unlock()
+
+	var forward []*liveRecord
+	for _, r := range recs {
+		lr := kb.track(r)
+		released, held := kb.holdLive(lr)
+		forward = append(forward, released...)
+		if !held {
+			forward = append(forward, lr)
+		}
+	}
+
+	// produced under the lock so that they are ordered consistently with released events
+	return kb.forward(ctx, forward)
+}
+
+// track adds a consumed record to its partition's uncommitted records. Must be called with the lock held.
+func (kb *KafkaBackfill) track(r *kgo.Record) *liveRecord {
+	po, ok := kb.partitions[r.Partition]
+	if !ok {
+		po = &partitionOffsets{done: make(map[int64]bool)}
+		kb.partitions[r.Partition] = po
+	}
+	po.records = append(po.records, r)
+
+	return &liveRecord{record: r, partition: po}
+}
+
+// holdLive reports whether a live record was held back instead of being forwarded. When holding it would overflow the
+// repo's buffer, the repo's backfill fails instead and the events held so far are returned, to be forwarded ahead of
+// the record. Must be called with the lock held.
+func (kb *KafkaBackfill) holdLive(lr *liveRecord) ([]*liveRecord, bool) {
+	if len(kb.repos) == 0 {
+		return nil, false
+	}
+
+	var evt vyletkafka.FirehoseEvent
+	if err := proto.Unmarshal(lr.record.Value, &evt); err != nil {
+		return nil, false
+	}
+
+	state, ok := kb.repos[evt.Did]
+	if !ok {
+		return nil, false
+	}
+
+	if len(state.held) >= kb.maxBufferedEvents {
+		released, _ := kb.releaseRepo(evt.Did)
+		kb.logger.Warn("held back events overflowed, failing repo backfill", "did", evt.Did, "held", len(released))
+		liveEvents.WithLabelValues("overflowed").Add(float64(len(released)))
+		return released, false
+	}
+
+	state.held = append(state.held, lr)
+	liveEvents.WithLabelValues("held").Inc()
+
+	return nil, true
+}
+
+// forward produces copies of live records to the output topic, then marks the offset after the last contiguous
+// forwarded record of each of their partitions to be committed. Must be called with the lock held.
+func (kb *KafkaBackfill) forward(ctx context.Context, lrs []*liveRecord) error {
+	if len(lrs) == 0 {
+		return nil
+	}
+
+	recs := make([]*kgo.Record, 0, len(lrs))
+	for _, lr := range lrs {
+		recs = append(recs, &kgo.Record{
+			Key:     lr.record.Key,
+			Value:   lr.record.Value,
+			Headers: lr.record.Headers,
+		})
+	}
+
+	if err := kb.produce(ctx, recs); err != nil {
+		return err
+	}
+
+	liveEvents.WithLabelValues("forwarded").Add(float64(len(lrs)))
+
+	var last []*kgo.Record
+	for _, lr := range lrs {
+		po := lr.partition
+		// the partition was revoked while the event was held, and is forwarded again by its new owner
+		if kb.partitions[lr.record.Partition] != po {
+			continue
+		}
+
+		po.done[lr.record.Offset] = true
+
+		var committable *kgo.Record
+		for len(po.records) > 0 && po.done[po.records[0].Offset] {
+			committable = po.records[0]
+			delete(po.done, committable.Offset)
+			po.records = po.records[1:]
+		}
+		if committable != nil {
+			last = append(last, committable)
+		}
+	}
+
+	if len(last) > 0 {
+		kb.markCommit(last...)
+	}
+
+	return nil
+}
+
+// revoked forgets the uncommitted records of partitions that are no longer assigned, then commits what was forwarded
+func (kb *KafkaBackfill) revoked(ctx context.Context, client *kgo.Client, revoked map[string][]int32) {
+	kb.lost(ctx, client, revoked)
+
+	if err := client.CommitMarkedOffsets(ctx); err != nil {
+		kb.logger.Error("failed to commit marked offsets", "err", err)
+	}
+}
+
+func (kb *KafkaBackfill) lost(_ context.Context, _ *kgo.Client, lost map[string][]int32) {
+	kb.lk.Lock()
+	defer kb.lk.Unlock()
+
+	for _, partitions := range lost {
+		for _, partition := range partitions {
+			delete(kb.partitions, partition)
+		}
+	}
+}
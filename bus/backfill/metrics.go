@@ -0,0 +1,32 @@
+package kafkabackfill
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace = "kafkabackfill"
+)
+
+var (
+	reposQueued = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "repos_queued",
+	})
+
+	reposBackfilled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "repos_backfilled",
+	}, []string{"status"})
+
+	recordsEmitted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "records_emitted",
+	}, []string{"status"})
+
+	liveEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "live_events",
+	}, []string{"status"})
+)
@@ -0,0 +1,99 @@
+package kafkabackfill
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// loadProgress reads the progress topic to its end, returning the repos whose latest progress is complete
+func (kb *KafkaBackfill) loadProgress(ctx context.Context) (map[string]struct{}, error) {
+	admin := kadm.NewClient(kb.producer)
+
+	startOffsets, err := admin.ListStartOffsets(ctx, kb.progressTopic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list start offsets: %w", err)
+	}
+
+	endOffsets, err := admin.ListEndOffsets(ctx, kb.progressTopic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list end offsets: %w", err)
+	}
+
+	partitions := make(map[int32]kgo.Offset)
+	ends := make(map[int32]int64)
+	startOffsets.Each(func(o kadm.ListedOffset) {
+		end, ok := endOffsets.Lookup(o.Topic, o.Partition)
+		if o.Err != nil || !ok || end.Err != nil || o.Offset >= end.Offset {
+			return
+		}
+		partitions[o.Partition] = kgo.NewOffset().At(o.Offset)
+		ends[o.Partition] = end.Offset
+	})
+
+	completed := make(map[string]struct{})
+	if len(partitions) == 0 {
+		return completed, nil
+	}
+
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(kb.bootstrapServers...),
+		kgo.ClientID("vylet-backfill-progress-reader"),
+		kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{kb.progressTopic: partitions}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	defer client.Close()
+
+	for len(ends) > 0 {
+		fetches := client.PollFetches(ctx)
+		if errs := fetches.Errors(); len(errs) > 0 {
+			return nil, fmt.Errorf("failed to fetch progress: %v", errs)
+		}
+
+		fetches.EachRecord(func(r *kgo.Record) {
+			if r.Offset >= ends[r.Partition]-1 {
+				delete(ends, r.Partition)
+			}
+
+			var progress vyletkafka.BackfillProgress
+			if err := proto.Unmarshal(r.Value, &progress); err != nil {
+				return
+			}
+
+			if progress.State == vyletkafka.BackfillState_BACKFILL_STATE_COMPLETE {
+				completed[progress.Did] = struct{}{}
+			} else {
+				delete(completed, progress.Did)
+			}
+		})
+	}
+
+	return completed, nil
+}
+
+func (kb *KafkaBackfill) saveProgress(ctx context.Context, progress *vyletkafka.BackfillProgress) error {
+	progress.UpdatedAt = timestamppb.New(time.Now())
+
+	b, err := proto.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to marshal progress: %w", err)
+	}
+
+	if err := kb.produceSync(ctx, &kgo.Record{
+		Topic: kb.progressTopic,
+		Key:   []byte(progress.Did),
+		Value: b,
+	}).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce progress: %w", err)
+	}
+
+	return nil
+}
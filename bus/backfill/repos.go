@@ -0,0 +1,325 @@
+package kafkabackfill
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/atdata"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/bluesky-social/indigo/repo"
+	"github.com/ipfs/go-cid"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/vylet-app/go/bus/headers"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// listReposPageSize is the page size used when enumerating repos from the relay
+	listReposPageSize = 1000
+	// produceBatchSize is how many records of a repo are produced before waiting for their acks
+	produceBatchSize = 500
+)
+
+// enumerateRepos lists every repo holding records in one of the collections, skipping those already backfilled. The
+// returned repos are marked as queued, so that their live events are held back from then on.
+func (kb *KafkaBackfill) enumerateRepos(ctx context.Context, completed map[string]struct{}) ([]string, error) {
+	var dids []string
+
+	for _, collection := range kb.collections {
+		cursor := ""
+		for {
+			out, err := comatproto.SyncListReposByCollection(ctx, kb.relay, collection, cursor, listReposPageSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repos by collection %s: %w", collection, err)
+			}
+
+			kb.lk.Lock()
+			for _, r := range out.Repos {
+				if _, ok := completed[r.Did]; ok {
+					continue
+				}
+				if _, ok := kb.repos[r.Did]; ok {
+					continue
+				}
+				kb.repos[r.Did] = &repoState{}
+				dids = append(dids, r.Did)
+			}
+			kb.lk.Unlock()
+
+			if out.Cursor == nil || *out.Cursor == "" || len(out.Repos) == 0 {
+				break
+			}
+			cursor = *out.Cursor
+		}
+	}
+
+	reposQueued.Set(float64(len(dids)))
+
+	return dids, nil
+}
+
+func (kb *KafkaBackfill) backfillRepos(ctx context.Context, dids []string) {
+	queue := make(chan string)
+
+	var wg sync.WaitGroup
+	for range kb.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for did := range queue {
+				kb.backfillRepo(ctx, did)
+			}
+		}()
+	}
+
+	for _, did := range dids {
+		select {
+		case queue <- did:
+		case <-ctx.Done():
+		}
+	}
+	close(queue)
+
+	wg.Wait()
+}
+
+// backfillRepo emits the repo's records, then replays its held back live events and records its progress. A repo
+// whose held back events overflowed fails, and is backfilled again on the next run.
+func (kb *KafkaBackfill) backfillRepo(ctx context.Context, did string) {
+	logger := kb.logger.With("did", did)
+	defer reposQueued.Dec()
+
+	emitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	kb.lk.Lock()
+	state, ok := kb.repos[did]
+	if ok {
+		state.cancel = cancel
+	}
+	kb.lk.Unlock()
+
+	var (
+		rev   string
+		count int
+		err   = errHeldOverflowed
+	)
+	if ok {
+		rev, count, err = kb.emitRepo(emitCtx, did)
+	}
+
+	kb.lk.Lock()
+	released, ok := kb.releaseRepo(did)
+	if !ok {
+		err = errHeldOverflowed
+	}
+	// produced under the lock so that no later live event for the repo overtakes them. Events held while the fetch
+	// failed are forwarded all the same, as the repo won't be backfilled until the next run.
+	releaseErr := kb.forward(ctx, released)
+	kb.lk.Unlock()
+	if releaseErr != nil {
+		logger.Error("failed to release held back events", "err", releaseErr)
+	}
+
+	if err != nil {
+		logger.Error("failed to backfill repo", "err", err)
+		status := "error"
+		if errors.Is(err, errHeldOverflowed) {
+			status = "overflowed"
+		}
+		reposBackfilled.WithLabelValues(status).Inc()
+
+		if err := kb.saveProgress(ctx, &vyletkafka.BackfillProgress{
+			Did:   did,
+			State: vyletkafka.BackfillState_BACKFILL_STATE_FAILED,
+			Error: err.Error(),
+		}); err != nil {
+			logger.Error("failed to save progress", "err", err)
+		}
+		return
+	}
+
+	if err := kb.saveProgress(ctx, &vyletkafka.BackfillProgress{
+		Did:     did,
+		State:   vyletkafka.BackfillState_BACKFILL_STATE_COMPLETE,
+		Rev:     rev,
+		Records: int64(count),
+	}); err != nil {
+		logger.Error("failed to save progress", "err", err)
+	}
+
+	logger.Info("backfilled repo", "rev", rev, "records", count, "released", len(released))
+	reposBackfilled.WithLabelValues("ok").Inc()
+}
+
+// emitRepo fetches the repo and produces a create for every record in the wanted collections, returning the rev of
+// the fetched repo and the number of records produced
+func (kb *KafkaBackfill) emitRepo(ctx context.Context, did string) (string, int, error) {
+	carBytes, err := kb.fetchRepo(ctx, did)
+	if err != nil {
+		return "", 0, err
+	}
+
+	rr, err := repo.ReadRepoFromCar(ctx, bytes.NewReader(carBytes))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read repo from car: %w", err)
+	}
+
+	if rr.RepoDid() != did {
+		return "", 0, fmt.Errorf("fetched repo belongs to %s", rr.RepoDid())
+	}
+
+	rev := rr.SignedCommit().Rev
+	now := timestamppb.Now()
+
+	var (
+		batch []*kgo.Record
+		count int
+	)
+	if err := rr.ForEach(ctx, "", func(k string, v cid.Cid) error {
+		collection, rkey, ok := strings.Cut(k, "/")
+		if !ok || !slices.Contains(kb.collections, collection) {
+			return nil
+		}
+
+		r, err := kb.recordFor(ctx, rr, did, rev, collection, rkey, v, now)
+		if err != nil {
+			recordsEmitted.WithLabelValues("error").Inc()
+			kb.logger.Warn("failed to build record event", "did", did, "path", k, "err", err)
+			return nil
+		}
+
+		batch = append(batch, r)
+		if len(batch) >= produceBatchSize {
+			if err := kb.produce(ctx, batch); err != nil {
+				return err
+			}
+			count += len(batch)
+			batch = batch[:0]
+		}
+
+		return nil
+	}); err != nil && !errors.Is(err, repo.ErrDoneIterating) {
+		return "", 0, fmt.Errorf("failed to walk repo: %w", err)
+	}
+
+	if err := kb.produce(ctx, batch); err != nil {
+		return "", 0, err
+	}
+	count += len(batch)
+
+	recordsEmitted.WithLabelValues("ok").Add(float64(count))
+
+	return rev, count, nil
+}
+
+func (kb *KafkaBackfill) fetchRepo(ctx context.Context, did string) ([]byte, error) {
+	host := kb.pdsHost
+	if host == "" {
+		parsedDid, err := syntax.ParseDID(did)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse did: %w", err)
+		}
+
+		ident, err := kb.directory.LookupDID(ctx, parsedDid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve did: %w", err)
+		}
+
+		if host = ident.PDSEndpoint(); host == "" {
+			return nil, fmt.Errorf("did document has no pds endpoint")
+		}
+	}
+
+	pdsClient := atclient.NewAPIClient(host)
+	pdsClient.Client = kb.pdsClient
+
+	carBytes, err := comatproto.SyncGetRepo(ctx, pdsClient, did, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo from %s: %w", host, err)
+	}
+
+	return carBytes, nil
+}
+
+// recordFor builds the record for a synthetic create of a record read from the repo, shaped like the firehose's own
+func (kb *KafkaBackfill) recordFor(ctx context.Context, rr *repo.Repo, did, rev, collection, rkey string, c cid.Cid, now *timestamppb.Timestamp) (*kgo.Record, error) {
+	blk, err := rr.Blockstore().Get(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get record block: %w", err)
+	}
+	recCbor := blk.RawData()
+
+	rec, err := atdata.UnmarshalCBOR(recCbor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal record: %w", err)
+	}
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record map to json: %w", err)
+	}
+
+	commit := &vyletkafka.Commit{
+		Rev:        rev,
+		Operation:  vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE,
+		Collection: collection,
+		Rkey:       rkey,
+		Record:     b,
+		Cid:        c.String(),
+		RecordCbor: recCbor,
+	}
+
+	// consumers fall back to the json record, so a record we can't type is still produced
+	if err := records.SetFromCBOR(commit, recCbor); err != nil {
+		kb.logger.Warn("failed to build typed record", "did", did, "collection", collection, "rkey", rkey, "err", err)
+	}
+
+	evt := &vyletkafka.FirehoseEvent{
+		Did:       did,
+		Timestamp: now,
+		Commit:    commit,
+	}
+
+	payload, err := proto.Marshal(evt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	hdrs := headers.ForEvent(evt, -1, records.HasBlobs(commit))
+	hdrs = append(hdrs, kgo.RecordHeader{Key: headers.Backfill, Value: []byte("true")})
+
+	return &kgo.Record{
+		Key:     []byte(did),
+		Value:   payload,
+		Headers: hdrs,
+	}, nil
+}
+
+// produce synchronously produces the records to the output topic
+func (kb *KafkaBackfill) produce(ctx context.Context, recs []*kgo.Record) error {
+	if len(recs) == 0 {
+		return nil
+	}
+
+	for _, r := range recs {
+		r.Topic = kb.outputTopic
+	}
+
+	if err := kb.produceSync(ctx, recs...).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce records: %w", err)
+	}
+
+	return nil
+}
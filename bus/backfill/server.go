@@ -0,0 +1,256 @@
+package kafkabackfill
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/bluesky-social/go-util/pkg/bus/kafka"
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/identity"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/vylet-app/go/bus/records"
+)
+
+// KafkaBackfill emits a create event for every record in repos that hold app.vylet.* records, so that consumers
+// learn about records created before the firehose cursor started. When reading a live firehose topic it forwards it
+// to the same output, holding back each repo's live events until that repo's backfill completes.
+type KafkaBackfill struct {
+	logger *slog.Logger
+
+	consumer  *kgo.Client
+	producer  *kgo.Client
+	relay     *atclient.APIClient
+	directory identity.Directory
+	pdsClient *http.Client
+
+	bootstrapServers  []string
+	inputTopic        string
+	outputTopic       string
+	progressTopic     string
+	pdsHost           string
+	collections       []string
+	workers           int
+	maxBufferedEvents int
+
+	// produceSync and markCommit are the producer's and consumer's, replaced in tests
+	produceSync func(context.Context, ...*kgo.Record) kgo.ProduceResults
+	markCommit  func(...*kgo.Record)
+
+	lk         sync.Mutex
+	repos      map[string]*repoState
+	partitions map[int32]*partitionOffsets
+}
+
+type Args struct {
+	Logger *slog.Logger
+
+	BootstrapServers []string
+	// InputTopic is the live firehose topic to forward, backfill only runs when empty
+	InputTopic    string
+	ConsumerGroup string
+	OutputTopic   string
+
+	RelayHost string
+	PLCHost   string
+	// PDSHost is used for every repo instead of the PDS in the DID document, for running against a local PDS
+	PDSHost string
+
+	Collections       []string
+	Workers           int
+	MaxBufferedEvents int
+}
+
+func New(ctx context.Context, args *Args) (*KafkaBackfill, error) {
+	if args.Logger == nil {
+		args.Logger = slog.Default()
+	}
+
+	logger := args.Logger
+
+	if args.RelayHost == "" {
+		return nil, fmt.Errorf("a relay host is required to enumerate repos")
+	}
+
+	if args.InputTopic != "" && args.ConsumerGroup == "" {
+		return nil, fmt.Errorf("a consumer group is required when forwarding an input topic")
+	}
+
+	if args.PLCHost == "" {
+		args.PLCHost = "https://plc.directory"
+	}
+
+	if len(args.Collections) == 0 {
+		args.Collections = []string{
+			records.CollectionActorProfile,
+			records.CollectionFeedPost,
+			records.CollectionFeedLike,
+			records.CollectionFeedComment,
+			records.CollectionGraphFollow,
+			records.CollectionGraphBlock,
+		}
+	}
+
+	if args.Workers <= 0 {
+		args.Workers = 8
+	}
+
+	if args.MaxBufferedEvents <= 0 {
+		args.MaxBufferedEvents = 10_000
+	}
+
+	producer, err := kafka.NewKafkaClient(kafka.Config{
+		BootstrapServers: args.BootstrapServers,
+		ClientID:         "vylet-kafka-backfill",
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer client: %w", err)
+	}
+
+	if err := kafka.EnsureTopic(ctx, producer, kafka.Config{
+		BootstrapServers:  args.BootstrapServers,
+		Topic:             args.OutputTopic,
+		TopicPartitions:   24,
+		ReplicationFactor: 1,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to ensure topic %s: %w", args.OutputTopic, err)
+	}
+
+	progressTopic := ProgressTopic(args.OutputTopic)
+	if err := kafka.EnsureTopic(ctx, producer, kafka.Config{
+		BootstrapServers:  args.BootstrapServers,
+		Topic:             progressTopic,
+		TopicConfig:       []string{"cleanup.policy=compact"},
+		TopicPartitions:   1,
+		ReplicationFactor: 1,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to ensure topic %s: %w", progressTopic, err)
+	}
+
+	baseDirectory := identity.BaseDirectory{
+		PLCURL: args.PLCHost,
+		HTTPClient: http.Client{
+			Timeout: time.Second * 5,
+		},
+		TryAuthoritativeDNS:   false,
+		SkipDNSDomainSuffixes: []string{".bsky.social", ".staging.bsky.dev"},
+	}
+	directory := identity.NewCacheDirectory(&baseDirectory, 50_000, time.Hour, time.Minute*15, time.Minute*15)
+
+	kb := KafkaBackfill{
+		logger: logger,
+
+		producer:  producer,
+		relay:     atclient.NewAPIClient(args.RelayHost),
+		directory: &directory,
+		pdsClient: &http.Client{Timeout: 5 * time.Minute},
+
+		bootstrapServers:  args.BootstrapServers,
+		inputTopic:        args.InputTopic,
+		outputTopic:       args.OutputTopic,
+		progressTopic:     progressTopic,
+		pdsHost:           args.PDSHost,
+		collections:       args.Collections,
+		workers:           args.Workers,
+		maxBufferedEvents: args.MaxBufferedEvents,
+
+		produceSync: producer.ProduceSync,
+
+		repos:      make(map[string]*repoState),
+		partitions: make(map[int32]*partitionOffsets),
+	}
+
+	if args.InputTopic != "" {
+		consumerOpts := kafka.DefaultConsumerOpts()
+		consumerOpts.AutoCommitMarks = true
+		consumerOpts.OnPartitionsRevoked = kb.revoked
+		consumerOpts.OnPartitionsLost = kb.lost
+
+		kb.consumer, err = kafka.NewKafkaClient(kafka.Config{
+			BootstrapServers: args.BootstrapServers,
+			ClientID:         "vylet-kafka-backfill",
+			Group:            args.ConsumerGroup,
+			Topic:            args.InputTopic,
+		}, consumerOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create kafka consumer client: %w", err)
+		}
+		kb.markCommit = kb.consumer.MarkCommitRecords
+	}
+
+	return &kb, nil
+}
+
+// ProgressTopic is the compacted topic the backfill writing to outputTopic records per-repo progress to
+func ProgressTopic(outputTopic string) string {
+	return outputTopic + "-backfill"
+}
+
+// Run enumerates and backfills every repo not yet backfilled. Without an input topic it returns once every repo has
+// been attempted, otherwise it keeps forwarding the input topic until stopped.
+func (kb *KafkaBackfill) Run(ctx context.Context) error {
+	logger := kb.logger.With("name", "Run")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	defer kb.producer.Close()
+	if kb.consumer != nil {
+		defer kb.consumer.Close()
+	}
+
+	completed, err := kb.loadProgress(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("loaded backfill progress", "completed", len(completed))
+
+	dids, err := kb.enumerateRepos(ctx, completed)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("enumerated repos to backfill", "count", len(dids))
+
+	backfillDone := make(chan struct{})
+	go func() {
+		kb.backfillRepos(ctx, dids)
+		close(backfillDone)
+	}()
+
+	liveErr := make(chan error, 1)
+	if kb.consumer != nil {
+		go func() {
+			liveErr <- kb.forwardLive(ctx)
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	for {
+		select {
+		case sig := <-signals:
+			logger.Info("received exit signal", "signal", sig)
+			return nil
+		case <-ctx.Done():
+			logger.Info("context cancelled")
+			return nil
+		case err := <-liveErr:
+			return fmt.Errorf("failed to forward live events: %w", err)
+		case <-backfillDone:
+			logger.Info("backfill complete")
+			if kb.consumer == nil {
+				return nil
+			}
+			backfillDone = nil
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Package capture writes produced firehose events to compressed segment files and reads them back, so that real
+// traffic can be replayed offline against the indexer or the cdn.
+//
+// A segment is a zstd stream of length-delimited CapturedEvent messages. Segments are named after the time they were
+// opened, so that sorting a capture directory by name yields events in the order they were captured.
+package capture
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+const segmentSuffix = ".seg.zst"
+
+type Writer struct {
+	logger *slog.Logger
+
+	dir           string
+	segmentEvents int
+
+	lk      sync.Mutex
+	file    *os.File
+	enc     *zstd.Encoder
+	written int
+}
+
+type Args struct {
+	Logger *slog.Logger
+
+	Dir string
+	// SegmentEvents is the number of events written to a segment before starting the next one
+	SegmentEvents int
+}
+
+func NewWriter(args *Args) (*Writer, error) {
+	if args.Logger == nil {
+		args.Logger = slog.Default()
+	}
+
+	if args.SegmentEvents <= 0 {
+		args.SegmentEvents = 100_000
+	}
+
+	if err := os.MkdirAll(args.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create capture directory: %w", err)
+	}
+
+	return &Writer{
+		logger:        args.Logger.With("component", "capture-writer", "dir", args.Dir),
+		dir:           args.Dir,
+		segmentEvents: args.SegmentEvents,
+	}, nil
+}
+
+// Write appends an event to the current segment, starting a new segment when the current one is full. Safe for
+// concurrent use.
+func (w *Writer) Write(evt *vyletkafka.CapturedEvent) error {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	if w.enc == nil || w.written >= w.segmentEvents {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := protodelim.MarshalTo(w.enc, evt); err != nil {
+		return fmt.Errorf("failed to write captured event: %w", err)
+	}
+	w.written++
+
+	return nil
+}
+
+func (w *Writer) rotate() error {
+	if err := w.closeSegment(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(w.dir, fmt.Sprintf("%020d%s", time.Now().UnixNano(), segmentSuffix))
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create segment: %w", err)
+	}
+
+	enc, err := zstd.NewWriter(file)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to create segment encoder: %w", err)
+	}
+
+	w.logger.Info("started capture segment", "path", path)
+
+	w.file = file
+	w.enc = enc
+	w.written = 0
+
+	return nil
+}
+
+func (w *Writer) closeSegment() error {
+	if w.enc == nil {
+		return nil
+	}
+
+	if err := w.enc.Close(); err != nil {
+		return fmt.Errorf("failed to flush segment: %w", err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close segment: %w", err)
+	}
+
+	w.file = nil
+	w.enc = nil
+
+	return nil
+}
+
+// Close flushes and closes the current segment
+func (w *Writer) Close() error {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	return w.closeSegment()
+}
@@ -0,0 +1,119 @@
+package capture
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+// Reader reads captured events back from a single segment or from every segment in a capture directory
+type Reader struct {
+	paths []string
+
+	file *os.File
+	dec  *zstd.Decoder
+	br   *bufio.Reader
+}
+
+// Open opens a segment file, or a capture directory whose segments are read in the order they were written
+func Open(path string) (*Reader, error) {
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat capture: %w", err)
+	}
+
+	if !st.IsDir() {
+		return &Reader{paths: []string{path}}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read capture directory: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), segmentSuffix) {
+			paths = append(paths, filepath.Join(path, entry.Name()))
+		}
+	}
+	slices.Sort(paths)
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no segments found in %s", path)
+	}
+
+	return &Reader{paths: paths}, nil
+}
+
+// Next returns the next captured event, or io.EOF once every segment has been read
+func (r *Reader) Next() (*vyletkafka.CapturedEvent, error) {
+	for {
+		if r.br == nil {
+			if len(r.paths) == 0 {
+				return nil, io.EOF
+			}
+			if err := r.openSegment(r.paths[0]); err != nil {
+				return nil, err
+			}
+			r.paths = r.paths[1:]
+		}
+
+		var evt vyletkafka.CapturedEvent
+		err := protodelim.UnmarshalFrom(r.br, &evt)
+		if err == nil {
+			return &evt, nil
+		}
+		if !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read captured event: %w", err)
+		}
+
+		r.closeSegment()
+	}
+}
+
+func (r *Reader) openSegment(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open segment: %w", err)
+	}
+
+	dec, err := zstd.NewReader(file)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to create segment decoder: %w", err)
+	}
+
+	r.file = file
+	r.dec = dec
+	r.br = bufio.NewReader(dec)
+
+	return nil
+}
+
+func (r *Reader) closeSegment() {
+	if r.dec != nil {
+		r.dec.Close()
+	}
+	if r.file != nil {
+		r.file.Close()
+	}
+
+	r.file = nil
+	r.dec = nil
+	r.br = nil
+}
+
+// Close releases the segment currently being read
+func (r *Reader) Close() {
+	r.closeSegment()
+}
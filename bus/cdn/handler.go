@@ -0,0 +1,88 @@
+package kafkacdn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bluesky-social/indigo/atproto/atdata"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/vylet-app/go/bus/deadletter"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+)
+
+func (kc *KafkaCdn) handleEvent(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
+	defer kc.lag.Observe(evt)
+
+	if evt.Commit == nil {
+		return nil
+	}
+
+	blobEvts, err := blobEventsFor(evt)
+	if err != nil {
+		// the consumer does not retry failed messages, so keep them around to be re-driven once fixed
+		kc.deadLetters.SendEvent(ctx, deadletter.StageCdn, evt, err)
+		recordsProcessed.WithLabelValues(evt.Commit.Operation.String(), "error").Inc()
+		return nil
+	}
+
+	recordsProcessed.WithLabelValues(evt.Commit.Operation.String(), "ok").Inc()
+
+	for _, blobEvt := range blobEvts {
+		// keyed by did so that every blob event for a repo lands on the same partition, in commit order
+		if err := kc.producer.ProduceSync(ctx, blobEvt.Did, blobEvt); err != nil {
+			blobEventsProduced.WithLabelValues(blobEvt.Operation.String(), "error").Inc()
+			return fmt.Errorf("failed to produce blob event: %w", err)
+		}
+		blobEventsProduced.WithLabelValues(blobEvt.Operation.String(), "ok").Inc()
+	}
+
+	return nil
+}
+
+// blobEventsFor returns one event per blob referenced by a created or updated record. Deleted records no longer
+// carry their contents, so a delete produces a single event without a cid that consumers resolve by uri.
+func blobEventsFor(evt *vyletkafka.FirehoseEvent) ([]*vyletkafka.BlobEvent, error) {
+	commit := evt.Commit
+
+	uri := fmt.Sprintf("at://%s/%s/%s", evt.Did, commit.Collection, commit.Rkey)
+	if _, err := syntax.ParseATURI(uri); err != nil {
+		return nil, fmt.Errorf("failed to build record uri: %w", err)
+	}
+
+	newBlobEvent := func() *vyletkafka.BlobEvent {
+		return &vyletkafka.BlobEvent{
+			Did:        evt.Did,
+			Uri:        uri,
+			Operation:  commit.Operation,
+			Collection: commit.Collection,
+			Rev:        commit.Rev,
+			Timestamp:  evt.Timestamp,
+		}
+	}
+
+	switch commit.Operation {
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE, vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE:
+		rec, err := records.Data(commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal record: %w", err)
+		}
+
+		blobs := atdata.ExtractBlobs(rec)
+		blobsExtracted.Add(float64(len(blobs)))
+
+		blobEvts := make([]*vyletkafka.BlobEvent, 0, len(blobs))
+		for _, blob := range blobs {
+			blobEvt := newBlobEvent()
+			blobEvt.Cid = blob.Ref.String()
+			blobEvt.MimeType = blob.MimeType
+			blobEvt.Size = blob.Size
+			blobEvts = append(blobEvts, blobEvt)
+		}
+		return blobEvts, nil
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE:
+		return []*vyletkafka.BlobEvent{newBlobEvent()}, nil
+	}
+
+	return nil, nil
+}
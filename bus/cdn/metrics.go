@@ -0,0 +1,27 @@
+package kafkacdn
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace = "kafkacdn"
+)
+
+var (
+	recordsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "records_processed",
+	}, []string{"operation", "status"})
+
+	blobsExtracted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blobs_extracted",
+	})
+
+	blobEventsProduced = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blob_events_produced",
+	}, []string{"operation", "status"})
+)
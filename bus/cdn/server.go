@@ -1,13 +1,159 @@
 package kafkacdn
 
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bluesky-social/go-util/pkg/bus/producer"
+	"github.com/vylet-app/go/bus/consume"
+	"github.com/vylet-app/go/bus/deadletter"
+	"github.com/vylet-app/go/bus/headers"
+	"github.com/vylet-app/go/bus/lag"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+)
+
+// KafkaCdn consumes firehose events and emits a BlobEvent for every blob referenced by a record, so that blob
+// consumers don't each have to parse every record
 type KafkaCdn struct {
+	logger *slog.Logger
+
+	consumer    *consume.Consumer
+	producer    *producer.Producer[*vyletkafka.BlobEvent]
+	deadLetters *deadletter.Producer
+	lag         *lag.Exporter
 }
 
 type Args struct {
+	Logger *slog.Logger
+
+	BootstrapServers []string
+	InputTopic       string
+	ConsumerGroup    string
+	OutputTopic      string
 }
 
-func New(args *Args) (*KafkaCdn, error) {
-	kf := KafkaCdn{}
+func New(ctx context.Context, args *Args) (*KafkaCdn, error) {
+	if args.Logger == nil {
+		args.Logger = slog.Default()
+	}
+
+	logger := args.Logger
+
+	busProducer, err := producer.New(
+		ctx,
+		logger.With("component", "producer"),
+		args.BootstrapServers,
+		args.OutputTopic,
+		producer.WithEnsureTopic[*vyletkafka.BlobEvent](true),
+		producer.WithTopicPartitions[*vyletkafka.BlobEvent](24),
+		producer.WithRetentionTime[*vyletkafka.BlobEvent](24*time.Hour),
+		producer.WithReplicationFactor[*vyletkafka.BlobEvent](1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new producer: %w", err)
+	}
+
+	deadLetters, err := deadletter.New(ctx, &deadletter.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lagExporter, err := lag.New(&lag.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lag exporter: %w", err)
+	}
+
+	kc := KafkaCdn{
+		logger: logger,
+
+		producer:    busProducer,
+		deadLetters: deadLetters,
+		lag:         lagExporter,
+	}
+
+	busConsumer, err := consume.New(&consume.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+		ClientID:         "vylet-kafka-cdn",
+		// deletes are kept, as they don't carry the record to tell whether it referenced any blob
+		Filter: &headers.Filter{
+			Kinds:     []string{headers.KindCommit},
+			BlobsOnly: true,
+		},
+		Handler: kc.handleEvent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new consumer: %w", err)
+	}
+	kc.consumer = busConsumer
+
+	return &kc, nil
+}
+
+func (kc *KafkaCdn) Run(ctx context.Context) error {
+	logger := kc.logger.With("name", "Run")
+
+	ctx, cancelLag := context.WithCancel(ctx)
+	defer cancelLag()
+
+	go kc.lag.Run(ctx)
+
+	shutdownConsumer := make(chan struct{}, 1)
+	consumerShutdown := make(chan struct{}, 1)
+	consumerErr := make(chan error, 1)
+	go func() {
+		go func() {
+			if err := kc.consumer.Consume(ctx); err != nil {
+				consumerErr <- err
+			}
+		}()
+
+		select {
+		case <-shutdownConsumer:
+		case err := <-consumerErr:
+			kc.logger.Error("error consuming", "err", err)
+		}
+
+		kc.consumer.Close()
+
+		close(consumerShutdown)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case sig := <-signals:
+		logger.Info("received exit signal", "signal", sig)
+		close(shutdownConsumer)
+	case <-ctx.Done():
+		logger.Info("context cancelled")
+		close(shutdownConsumer)
+	case <-consumerShutdown:
+		logger.Warn("consumer shut down unexpectedly")
+	}
+
+	kc.consumer.Close()
+	kc.producer.Close()
+	kc.deadLetters.Close()
+	kc.lag.Close()
 
-	return &kf, nil
+	return nil
 }
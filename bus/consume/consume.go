@@ -0,0 +1,148 @@
+// Package consume reads firehose events from a topic as part of a consumer group. Unlike go-util's bus consumer it
+// looks at each record's headers first, so that a consumer skips the records it has no use for without decoding them.
+package consume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bluesky-social/go-util/pkg/bus/kafka"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/vylet-app/go/bus/headers"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Consumer hands every event it does not skip to a handler. The partitions of a fetch are handled concurrently, and
+// the records of each partition in order. Like go-util's consumer, a record is committed once handled, whether or not
+// the handler failed.
+type Consumer struct {
+	logger *slog.Logger
+
+	client  *kgo.Client
+	topic   string
+	filter  *headers.Filter
+	handler func(context.Context, *vyletkafka.FirehoseEvent) error
+
+	closeOnce sync.Once
+}
+
+type Args struct {
+	Logger *slog.Logger
+
+	BootstrapServers []string
+	Topic            string
+	ConsumerGroup    string
+	ClientID         string
+
+	// Filter skips the records the handler has no use for, none if nil
+	Filter  *headers.Filter
+	Handler func(context.Context, *vyletkafka.FirehoseEvent) error
+}
+
+func New(args *Args) (*Consumer, error) {
+	if args.Logger == nil {
+		args.Logger = slog.Default()
+	}
+
+	if args.Filter == nil {
+		args.Filter = &headers.Filter{}
+	}
+
+	consumerOpts := kafka.DefaultConsumerOpts()
+	consumerOpts.AutoCommitMarks = true
+	// a rebalance waits for the records already polled to be handled, so that none is handled by two consumers
+	consumerOpts.BlockRebalanceOnPoll = true
+
+	client, err := kafka.NewKafkaClient(kafka.Config{
+		BootstrapServers: args.BootstrapServers,
+		ClientID:         args.ClientID,
+		Group:            args.ConsumerGroup,
+		Topic:            args.Topic,
+	}, consumerOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+
+	return &Consumer{
+		logger: args.Logger.With("component", "consumer", "topic", args.Topic),
+
+		client:  client,
+		topic:   args.Topic,
+		filter:  args.Filter,
+		handler: args.Handler,
+	}, nil
+}
+
+// Consume handles records until ctx is cancelled or the consumer is closed
+func (c *Consumer) Consume(ctx context.Context) error {
+	for {
+		fetches := c.client.PollFetches(ctx)
+		if fetches.IsClientClosed() || ctx.Err() != nil {
+			return nil
+		}
+		if errs := fetches.Errors(); len(errs) > 0 {
+			var err error
+			for _, e := range errs {
+				err = errors.Join(err, fmt.Errorf("topic %s partition %d: %w", e.Topic, e.Partition, e.Err))
+			}
+			return err
+		}
+
+		var wg sync.WaitGroup
+		fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, r := range p.Records {
+					c.handle(ctx, r)
+				}
+			}()
+		})
+		wg.Wait()
+
+		c.client.AllowRebalance()
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, r *kgo.Record) {
+	defer c.client.MarkCommitRecords(r)
+
+	if c.filter.Skip(r) {
+		recordsConsumed.WithLabelValues(c.topic, "skipped").Inc()
+		return
+	}
+
+	var evt vyletkafka.FirehoseEvent
+	if err := proto.Unmarshal(r.Value, &evt); err != nil {
+		c.logger.Error("failed to unmarshal event", "partition", r.Partition, "offset", r.Offset, "err", err)
+		recordsConsumed.WithLabelValues(c.topic, "malformed").Inc()
+		return
+	}
+
+	if err := c.handler(ctx, &evt); err != nil {
+		c.logger.Error("failed to handle event", "partition", r.Partition, "offset", r.Offset, "err", err)
+		recordsConsumed.WithLabelValues(c.topic, "error").Inc()
+		return
+	}
+
+	recordsConsumed.WithLabelValues(c.topic, "ok").Inc()
+}
+
+// Close commits the records handled so far and leaves the group. It is safe to call more than once.
+func (c *Consumer) Close() {
+	c.closeOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := c.client.CommitMarkedOffsets(ctx); err != nil {
+			c.logger.Error("failed to commit marked offsets", "err", err)
+		}
+
+		c.client.Close()
+	})
+}
@@ -0,0 +1,17 @@
+package consume
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace = "consumer"
+)
+
+var (
+	recordsConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "records_consumed",
+	}, []string{"topic", "status"})
+)
@@ -0,0 +1,135 @@
+package deadletter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bluesky-social/go-util/pkg/bus/producer"
+	"github.com/twmb/franz-go/pkg/kgo"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	StageFirehose = "firehose"
+	StageIndexer  = "indexer"
+	StageCdn      = "cdn"
+	StageNotifier = "notifier"
+)
+
+// Producer writes events that could not be processed, along with why, to the dead-letter topic of a main topic
+type Producer struct {
+	logger *slog.Logger
+
+	producer *producer.Producer[*vyletkafka.DeadLetter]
+
+	topic         string
+	consumerGroup string
+}
+
+type Args struct {
+	Logger *slog.Logger
+
+	BootstrapServers []string
+	// Topic is the main topic, entries are written to <Topic>-dlq
+	Topic string
+	// ConsumerGroup is recorded on every entry when set
+	ConsumerGroup string
+}
+
+// Topic returns the dead-letter topic for a main topic
+func Topic(topic string) string {
+	return topic + "-dlq"
+}
+
+func New(ctx context.Context, args *Args) (*Producer, error) {
+	if args.Logger == nil {
+		args.Logger = slog.Default()
+	}
+
+	// a single partition keeps offsets unique so that entries can be picked out by offset when re-driving
+	busProducer, err := producer.New(
+		ctx,
+		args.Logger.With("component", "dlq-producer"),
+		args.BootstrapServers,
+		Topic(args.Topic),
+		producer.WithEnsureTopic[*vyletkafka.DeadLetter](true),
+		producer.WithTopicPartitions[*vyletkafka.DeadLetter](1),
+		producer.WithRetentionTime[*vyletkafka.DeadLetter](14*24*time.Hour),
+		producer.WithReplicationFactor[*vyletkafka.DeadLetter](1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dead-letter producer: %w", err)
+	}
+
+	return &Producer{
+		logger: args.Logger,
+
+		producer: busProducer,
+
+		topic:         args.Topic,
+		consumerGroup: args.ConsumerGroup,
+	}, nil
+}
+
+// Send asynchronously produces the letter, filling in the topic, consumer group and failure time
+func (p *Producer) Send(ctx context.Context, letter *vyletkafka.DeadLetter) {
+	logger := p.logger.With("name", "Send", "stage", letter.Stage, "did", letter.Did, "collection", letter.Collection)
+
+	letter.Topic = p.topic
+	letter.ConsumerGroup = p.consumerGroup
+	if letter.FailedAt == nil {
+		letter.FailedAt = timestamppb.Now()
+	}
+
+	logger.Warn("sending event to dead-letter topic", "reason", letter.Reason)
+
+	if err := p.producer.ProduceAsync(ctx, letter.Did, letter, func(r *kgo.Record, err error) {
+		status := "error"
+		defer func() {
+			lettersProduced.WithLabelValues(letter.Stage, status).Inc()
+		}()
+
+		if err != nil {
+			logger.Error("error after producing dead letter async", "err", err)
+			return
+		}
+
+		status = "ok"
+	}); err != nil {
+		lettersProduced.WithLabelValues(letter.Stage, "error").Inc()
+		logger.Error("failed to produce dead letter async", "err", err)
+	}
+}
+
+// SendEvent dead-letters a firehose event that failed while being consumed, keeping the event itself as the payload
+// so that it can be re-driven into the main topic later
+func (p *Producer) SendEvent(ctx context.Context, stage string, evt *vyletkafka.FirehoseEvent, reason error) {
+	payload, err := proto.Marshal(evt)
+	if err != nil {
+		p.logger.Error("failed to marshal event for dead-letter topic", "did", evt.Did, "err", err)
+		return
+	}
+
+	letter := vyletkafka.DeadLetter{
+		Stage:       stage,
+		Reason:      reason.Error(),
+		Did:         evt.Did,
+		PayloadType: vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_FIREHOSE_EVENT,
+		Payload:     payload,
+	}
+	if evt.Commit != nil {
+		letter.Collection = evt.Commit.Collection
+		letter.Rkey = evt.Commit.Rkey
+		letter.Rev = evt.Commit.Rev
+	}
+
+	p.Send(ctx, &letter)
+}
+
+func (p *Producer) Close() {
+	p.producer.Close()
+}
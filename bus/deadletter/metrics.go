@@ -0,0 +1,17 @@
+package deadletter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace = "deadletter"
+)
+
+var (
+	lettersProduced = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "letters_produced",
+	}, []string{"stage", "status"})
+)
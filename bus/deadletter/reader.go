@@ -0,0 +1,86 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Entry is a dead letter along with its offset in the dead-letter topic
+type Entry struct {
+	Offset int64
+	Letter *vyletkafka.DeadLetter
+}
+
+// List reads every entry currently in the dead-letter topic of the given main topic, oldest first
+func List(ctx context.Context, bootstrapServers []string, topic string) ([]*Entry, error) {
+	dlqTopic := Topic(topic)
+
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(bootstrapServers...),
+		kgo.ClientID("vylet-dlq-reader"),
+		kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{
+			dlqTopic: {0: kgo.NewOffset().AtStart()},
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	defer client.Close()
+
+	admin := kadm.NewClient(client)
+
+	startOffsets, err := admin.ListStartOffsets(ctx, dlqTopic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list start offsets: %w", err)
+	}
+
+	endOffsets, err := admin.ListEndOffsets(ctx, dlqTopic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list end offsets: %w", err)
+	}
+
+	start, startOk := startOffsets.Lookup(dlqTopic, 0)
+	end, endOk := endOffsets.Lookup(dlqTopic, 0)
+	if !startOk || !endOk {
+		return nil, fmt.Errorf("dead-letter topic %s does not exist", dlqTopic)
+	}
+	if start.Err != nil || end.Err != nil {
+		return nil, fmt.Errorf("failed to list offsets for %s: %w", dlqTopic, errors.Join(start.Err, end.Err))
+	}
+
+	// nothing retained, and polling would block until the next letter arrives
+	if start.Offset >= end.Offset {
+		return nil, nil
+	}
+
+	var entries []*Entry
+	for done := false; !done; {
+		fetches := client.PollFetches(ctx)
+		if errs := fetches.Errors(); len(errs) > 0 {
+			return nil, fmt.Errorf("failed to fetch records: %v", errs)
+		}
+
+		fetches.EachRecord(func(r *kgo.Record) {
+			if r.Offset >= end.Offset-1 {
+				done = true
+			}
+			if r.Offset >= end.Offset {
+				return
+			}
+
+			var letter vyletkafka.DeadLetter
+			if err := proto.Unmarshal(r.Value, &letter); err != nil {
+				return
+			}
+			entries = append(entries, &Entry{Offset: r.Offset, Letter: &letter})
+		})
+	}
+
+	return entries, nil
+}
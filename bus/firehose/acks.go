@@ -0,0 +1,153 @@
+package kafkafirehose
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bluesky-social/indigo/events"
+	"github.com/bluesky-social/indigo/events/schedulers/parallel"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ackEntry follows a single upstream event from the moment it is read off the stream until every record produced for
+// it has been acknowledged by kafka
+type ackEntry struct {
+	seq         int64
+	outstanding int
+	dispatched  bool
+}
+
+// ackTracker computes the highest sequence whose events, and all events before it, have been fully acknowledged.
+// Events are tracked in stream order and may complete in any order, as the parallel scheduler handles different
+// repos concurrently.
+type ackTracker struct {
+	lk      sync.Mutex
+	pending []*ackEntry
+
+	unacked prometheus.Gauge
+
+	// called with the new watermark whenever it advances
+	onAdvance func(seq int64)
+}
+
+func newAckTracker(host string, onAdvance func(seq int64)) *ackTracker {
+	return &ackTracker{
+		unacked:   unackedEvents.WithLabelValues(host),
+		onAdvance: onAdvance,
+	}
+}
+
+// track registers an event as it is read off the stream. Must be called in stream order.
+func (t *ackTracker) track(seq int64) *ackEntry {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	e := &ackEntry{seq: seq}
+	t.pending = append(t.pending, e)
+	t.unacked.Set(float64(len(t.pending)))
+	return e
+}
+
+// produced records that n records were handed to the kafka client for the event
+func (t *ackTracker) produced(e *ackEntry, n int) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	e.outstanding += n
+}
+
+// acked records that one of the event's records was acknowledged, or failed and was dead-lettered
+func (t *ackTracker) acked(e *ackEntry) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	e.outstanding--
+	t.advance()
+}
+
+// dispatched records that the handler is done with the event and will produce nothing further for it. Safe to call
+// more than once.
+func (t *ackTracker) dispatched(e *ackEntry) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	e.dispatched = true
+	t.advance()
+}
+
+// reset forgets every pending event. Called whenever the stream is reconnected, since the upstream replays everything
+// after the watermark and any event still pending from the old connection would otherwise hold it back forever.
+func (t *ackTracker) reset() {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	t.pending = nil
+	t.unacked.Set(0)
+}
+
+func (t *ackTracker) advance() {
+	watermark := int64(-1)
+	for len(t.pending) > 0 && t.pending[0].dispatched && t.pending[0].outstanding <= 0 {
+		// events without a sequence, like #info frames, never move the cursor
+		if seq := t.pending[0].seq; seq >= 0 {
+			watermark = seq
+		}
+		t.pending = t.pending[1:]
+	}
+	t.unacked.Set(float64(len(t.pending)))
+
+	if watermark >= 0 {
+		t.onAdvance(watermark)
+	}
+}
+
+// trackingScheduler registers every event with the ack tracker in stream order before handing it to the parallel
+// scheduler, which runs handlers for different repos concurrently
+type trackingScheduler struct {
+	*parallel.Scheduler
+
+	acks *ackTracker
+
+	lk      sync.Mutex
+	entries map[*events.XRPCStreamEvent]*ackEntry
+}
+
+func newTrackingScheduler(acks *ackTracker, parallelism int, ident string, handler func(context.Context, *events.XRPCStreamEvent, *ackEntry) error) *trackingScheduler {
+	s := &trackingScheduler{
+		acks:    acks,
+		entries: make(map[*events.XRPCStreamEvent]*ackEntry),
+	}
+
+	s.Scheduler = parallel.NewScheduler(parallelism, 1000, ident, func(ctx context.Context, evt *events.XRPCStreamEvent) error {
+		entry := s.take(evt)
+		defer acks.dispatched(entry)
+		return handler(ctx, evt, entry)
+	})
+
+	return s
+}
+
+func (s *trackingScheduler) AddWork(ctx context.Context, repo string, evt *events.XRPCStreamEvent) error {
+	entry := s.acks.track(evt.Sequence())
+
+	s.lk.Lock()
+	s.entries[evt] = entry
+	s.lk.Unlock()
+
+	if err := s.Scheduler.AddWork(ctx, repo, evt); err != nil {
+		// the entry stays pending, holding the cursor back until the tracker is reset on reconnect
+		s.take(evt)
+		return err
+	}
+
+	return nil
+}
+
+func (s *trackingScheduler) take(evt *events.XRPCStreamEvent) *ackEntry {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	entry := s.entries[evt]
+	delete(s.entries, evt)
+	return entry
+}
@@ -0,0 +1,257 @@
+package kafkafirehose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bluesky-social/go-util/pkg/bus/cursor"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/vylet-app/go/bus/headers"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// CursorTopic is the topic the firehose writing to outputTopic saves its cursors to
+func CursorTopic(outputTopic string) string {
+	return outputTopic + "-cursor"
+}
+
+// SavedCursor is a cursor read back from the cursor topic along with when it was saved
+type SavedCursor struct {
+	Offset  int64
+	SavedAt time.Time
+	Cursor  *vyletkafka.SequenceCursor
+}
+
+// ListCursors returns up to limit of the most recently saved cursors, oldest first. Cursors from aborted
+// transactions are skipped.
+func ListCursors(ctx context.Context, bootstrapServers []string, outputTopic string, limit int) ([]*SavedCursor, error) {
+	topic := CursorTopic(outputTopic)
+
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(bootstrapServers...),
+		kgo.ClientID("vylet-cursor-reader"),
+		kgo.FetchIsolationLevel(kgo.ReadCommitted()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	defer client.Close()
+
+	admin := kadm.NewClient(client)
+
+	startOffsets, err := admin.ListStartOffsets(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list start offsets: %w", err)
+	}
+
+	// the committed end, so that we don't wait on cursors that may still be aborted
+	endOffsets, err := admin.ListCommittedOffsets(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list end offsets: %w", err)
+	}
+
+	start, startOk := startOffsets.Lookup(topic, 0)
+	end, endOk := endOffsets.Lookup(topic, 0)
+	if !startOk || !endOk {
+		return nil, fmt.Errorf("cursor topic %s does not exist", topic)
+	}
+	if start.Err != nil || end.Err != nil {
+		return nil, fmt.Errorf("failed to list offsets for %s: %w", topic, errors.Join(start.Err, end.Err))
+	}
+
+	if start.Offset >= end.Offset {
+		return nil, nil
+	}
+
+	// transaction markers take up offsets too, so read a little further back than strictly needed
+	from := max(start.Offset, end.Offset-int64(limit)*2)
+
+	client.AddConsumePartitions(map[string]map[int32]kgo.Offset{
+		topic: {0: kgo.NewOffset().At(from)},
+	})
+
+	var cursors []*SavedCursor
+	for done := false; !done; {
+		fetchCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		fetches := client.PollFetches(fetchCtx)
+		cancel()
+
+		if errs := fetches.Errors(); len(errs) > 0 {
+			// past the last committed record there may be nothing but markers left to read
+			if errors.Is(errs[0].Err, context.DeadlineExceeded) {
+				break
+			}
+			return nil, fmt.Errorf("failed to fetch records: %v", errs)
+		}
+
+		fetches.EachRecord(func(r *kgo.Record) {
+			if r.Offset >= end.Offset-1 {
+				done = true
+			}
+			if r.Offset >= end.Offset {
+				return
+			}
+
+			var c vyletkafka.SequenceCursor
+			if err := proto.Unmarshal(r.Value, &c); err != nil {
+				return
+			}
+			cursors = append(cursors, &SavedCursor{Offset: r.Offset, SavedAt: r.Timestamp, Cursor: &c})
+		})
+	}
+
+	if len(cursors) > limit {
+		cursors = cursors[len(cursors)-limit:]
+	}
+
+	return cursors, nil
+}
+
+// ActiveCursorHolders returns the consumer groups with live members reading the cursor topic. Every running firehose
+// holds one for as long as it is up, so an empty result means no firehose is currently writing cursors.
+func ActiveCursorHolders(ctx context.Context, bootstrapServers []string, outputTopic string) ([]string, error) {
+	topic := CursorTopic(outputTopic)
+
+	client, err := kgo.NewClient(kgo.SeedBrokers(bootstrapServers...), kgo.ClientID("vylet-cursor-reader"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	defer client.Close()
+
+	admin := kadm.NewClient(client)
+
+	groups, err := admin.ListGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consumer groups: %w", err)
+	}
+
+	// the cursor library names its groups cursor-<id>
+	var cursorGroups []string
+	for _, group := range groups.Groups() {
+		if strings.HasPrefix(group, "cursor-") {
+			cursorGroups = append(cursorGroups, group)
+		}
+	}
+	if len(cursorGroups) == 0 {
+		return nil, nil
+	}
+
+	described, err := admin.DescribeGroups(ctx, cursorGroups...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe consumer groups: %w", err)
+	}
+
+	var holders []string
+	for _, group := range described.Sorted() {
+		if group.Err != nil {
+			continue
+		}
+		for _, member := range group.Members {
+			assigned, ok := member.Assigned.AsConsumer()
+			if !ok {
+				continue
+			}
+			for _, t := range assigned.Topics {
+				if t.Topic == topic {
+					holders = append(holders, group.Group)
+				}
+			}
+		}
+	}
+
+	return holders, nil
+}
+
+// SaveCursor writes a cursor to the cursor topic. The cursor is marked as saved on exit, so that the next firehose to
+// start picks it up without waiting for a later one.
+func SaveCursor(ctx context.Context, bootstrapServers []string, outputTopic string, c *vyletkafka.SequenceCursor) error {
+	cursorProducer, err := cursor.New[*vyletkafka.SequenceCursor](ctx, bootstrapServers, CursorTopic(outputTopic))
+	if err != nil {
+		return fmt.Errorf("failed to create cursor producer: %w", err)
+	}
+	defer cursorProducer.Close()
+
+	c.SavedOnExit = true
+
+	if err := cursorProducer.Save(ctx, c); err != nil {
+		return fmt.Errorf("failed to save cursor: %w", err)
+	}
+
+	return nil
+}
+
+// SequenceAt approximates the relay sequence at a point in time from the seq headers of the first records produced
+// to the output topic at or after it. With several relays the sequence belongs to whichever relay delivered those
+// events first, so an explicit sequence should be preferred in that case.
+func SequenceAt(ctx context.Context, bootstrapServers []string, outputTopic string, t time.Time) (int64, error) {
+	client, err := kgo.NewClient(kgo.SeedBrokers(bootstrapServers...), kgo.ClientID("vylet-cursor-reader"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	defer client.Close()
+
+	admin := kadm.NewClient(client)
+
+	offsets, err := admin.ListOffsetsAfterMilli(ctx, t.UnixMilli(), outputTopic)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list offsets after %s: %w", t, err)
+	}
+
+	endOffsets, err := admin.ListEndOffsets(ctx, outputTopic)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list end offsets: %w", err)
+	}
+
+	partitions := make(map[int32]kgo.Offset)
+	offsets.Each(func(o kadm.ListedOffset) {
+		end, ok := endOffsets.Lookup(o.Topic, o.Partition)
+		if o.Err != nil || !ok || o.Offset >= end.Offset {
+			return
+		}
+		partitions[o.Partition] = kgo.NewOffset().At(o.Offset)
+	})
+	if len(partitions) == 0 {
+		return 0, fmt.Errorf("no records in %s at or after %s", outputTopic, t)
+	}
+
+	client.AddConsumePartitions(map[string]map[int32]kgo.Offset{outputTopic: partitions})
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// relay sequences are assigned in time order, so the smallest sequence across partitions is the earliest event
+	seq := int64(math.MaxInt64)
+	seen := make(map[int32]bool)
+	for len(seen) < len(partitions) {
+		fetches := client.PollFetches(ctx)
+		if errs := fetches.Errors(); len(errs) > 0 {
+			return 0, fmt.Errorf("failed to fetch records: %v", errs)
+		}
+
+		fetches.EachRecord(func(r *kgo.Record) {
+			if seen[r.Partition] {
+				return
+			}
+			seen[r.Partition] = true
+
+			if v, ok := headers.Get(r, headers.Sequence); ok {
+				if s, err := strconv.ParseInt(v, 10, 64); err == nil && s >= 0 {
+					seq = min(seq, s)
+				}
+			}
+		})
+	}
+
+	if seq == math.MaxInt64 {
+		return 0, fmt.Errorf("no records at or after %s carry a sequence header", t)
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,121 @@
+package kafkafirehose
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+)
+
+// dedupWindow remembers the most recent keys that were produced, evicting the oldest once full. Keys being produced
+// are held as pending until their records are acked, so that a copy arriving meanwhile is still dropped, but one
+// arriving after a failed produce is not.
+type dedupWindow struct {
+	lk      sync.Mutex
+	seen    map[string]struct{}
+	pending map[string]struct{}
+	ring    []string
+	next    int
+}
+
+func newDedupWindow(size int) *dedupWindow {
+	return &dedupWindow{
+		seen:    make(map[string]struct{}, size),
+		pending: make(map[string]struct{}),
+		ring:    make([]string, size),
+	}
+}
+
+// reserve marks the key as pending and reports whether it was neither produced nor pending already
+func (w *dedupWindow) reserve(key string) bool {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	if _, ok := w.seen[key]; ok {
+		return false
+	}
+	if _, ok := w.pending[key]; ok {
+		return false
+	}
+
+	w.pending[key] = struct{}{}
+
+	return true
+}
+
+// done releases a pending key, remembering it as produced if its records were all acked
+func (w *dedupWindow) done(key string, produced bool) {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	delete(w.pending, key)
+	if !produced {
+		return
+	}
+
+	if evicted := w.ring[w.next]; evicted != "" {
+		delete(w.seen, evicted)
+	}
+	w.ring[w.next] = key
+	w.next = (w.next + 1) % len(w.ring)
+	w.seen[key] = struct{}{}
+}
+
+// dedupKey identifies an event independently of the upstream sequence it arrived with. Commits are keyed by
+// (did, rev, path) and identity, account and sync events by (did, time). Info events are specific to the connection
+// they arrived on, so they are never deduplicated.
+func dedupKey(evt *vyletkafka.FirehoseEvent) (string, string) {
+	switch {
+	case evt.Commit != nil:
+		return "commit", evt.Did + "|" + evt.Commit.Rev + "|" + evt.Commit.Collection + "/" + evt.Commit.Rkey
+	case evt.Identity != nil:
+		return "identity", evt.Did + "|" + evt.Timestamp.AsTime().Format(time.RFC3339Nano)
+	case evt.Account != nil:
+		return "account", evt.Did + "|" + evt.Timestamp.AsTime().Format(time.RFC3339Nano)
+	case evt.Sync != nil:
+		return "sync", evt.Did + "|" + evt.Timestamp.AsTime().Format(time.RFC3339Nano)
+	}
+	return "", ""
+}
+
+// dedupEvents drops events that were already produced or are being produced, typically because another upstream
+// delivered them first. Every event returned must be passed to dedupDone once it was produced or dropped.
+func (kf *KafkaFirehose) dedupEvents(kafkaEvts []*vyletkafka.FirehoseEvent) []*vyletkafka.FirehoseEvent {
+	out := kafkaEvts[:0]
+	for _, kafkaEvt := range kafkaEvts {
+		kind, key := dedupKey(kafkaEvt)
+		if kind != "" && !kf.dedup.reserve(kind+"|"+key) {
+			duplicatesDropped.WithLabelValues(kind).Inc()
+			if kafkaEvt.Commit != nil {
+				recordsHandled.WithLabelValues(statusDuplicate, kafkaEvt.Commit.Collection).Inc()
+			}
+			continue
+		}
+		out = append(out, kafkaEvt)
+	}
+	return out
+}
+
+// dedupDone releases an event returned by dedupEvents. Only events whose records were all acked are remembered, so
+// that a copy from another upstream is still produced when this one failed.
+func (kf *KafkaFirehose) dedupDone(kafkaEvt *vyletkafka.FirehoseEvent, produced bool) {
+	if kind, key := dedupKey(kafkaEvt); kind != "" {
+		kf.dedup.done(kind+"|"+key, produced)
+	}
+}
+
+// eventAcks counts the records an event is produced as that were not acked yet
+type eventAcks struct {
+	remaining atomic.Int32
+	failed    atomic.Bool
+}
+
+// acked records the ack of one of the event's records, reporting whether it was the last one and whether all of them
+// succeeded
+func (a *eventAcks) acked(err error) (last bool, produced bool) {
+	if err != nil {
+		a.failed.Store(true)
+	}
+	return a.remaining.Add(-1) == 0, !a.failed.Load()
+}
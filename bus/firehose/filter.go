@@ -0,0 +1,235 @@
+package kafkafirehose
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"golang.org/x/time/rate"
+)
+
+// records_handled statuses of ops that are not forwarded. Ops that become events are only counted once produceEvents
+// decided whether to forward them, so that dropped ones are never counted as ok.
+const (
+	statusDenied    = "denied"
+	statusThrottled = "throttled"
+	statusDuplicate = "duplicate"
+)
+
+// didList is a set of DIDs loaded from a file with one DID per line. Blank lines and lines starting with # are
+// ignored. The file is reloaded whenever its modification time changes.
+type didList struct {
+	path    string
+	modTime time.Time
+	dids    map[string]struct{}
+}
+
+func (l *didList) load() (bool, error) {
+	st, err := os.Stat(l.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat did list %s: %w", l.path, err)
+	}
+
+	if l.dids != nil && st.ModTime().Equal(l.modTime) {
+		return false, nil
+	}
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open did list %s: %w", l.path, err)
+	}
+	defer f.Close()
+
+	dids := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dids[line] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read did list %s: %w", l.path, err)
+	}
+
+	l.dids = dids
+	l.modTime = st.ModTime()
+
+	return true, nil
+}
+
+// didFilter decides which repos' records are forwarded. When an allow list is configured only the DIDs on it pass,
+// and DIDs on the deny list never do.
+type didFilter struct {
+	logger *slog.Logger
+
+	lk    sync.RWMutex
+	allow *didList
+	deny  *didList
+}
+
+func newDidFilter(logger *slog.Logger, allowFile, denyFile string) (*didFilter, error) {
+	f := &didFilter{logger: logger.With("component", "did-filter")}
+
+	if allowFile != "" {
+		f.allow = &didList{path: allowFile}
+	}
+	if denyFile != "" {
+		f.deny = &didList{path: denyFile}
+	}
+
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *didFilter) allowed(did string) bool {
+	f.lk.RLock()
+	defer f.lk.RUnlock()
+
+	if f.deny != nil {
+		if _, ok := f.deny.dids[did]; ok {
+			return false
+		}
+	}
+
+	if f.allow != nil {
+		_, ok := f.allow.dids[did]
+		return ok
+	}
+
+	return true
+}
+
+// reload re-reads any list whose file changed, keeping the previous contents of a list that fails to load
+func (f *didFilter) reload() error {
+	f.lk.Lock()
+	defer f.lk.Unlock()
+
+	// a list that fails to load keeps its previous contents, and doesn't keep the other from being reloaded
+	var errs []error
+	for _, l := range []*didList{f.allow, f.deny} {
+		if l == nil {
+			continue
+		}
+
+		changed, err := l.load()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if changed {
+			f.logger.Info("loaded did list", "path", l.path, "count", len(l.dids))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (f *didFilter) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.reload(); err != nil {
+				f.logger.Error("failed to reload did lists", "err", err)
+			}
+		}
+	}
+}
+
+// didLimiter is a token bucket per repo, limiting how fast any single repo's creates and updates are forwarded
+type didLimiter struct {
+	limit rate.Limit
+	burst int
+
+	lk       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newDidLimiter(perMinute float64, burst int) *didLimiter {
+	return &didLimiter{
+		limit:    rate.Limit(perMinute / 60),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+func (l *didLimiter) allow(did string) bool {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	limiter, ok := l.limiters[did]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[did] = limiter
+	}
+
+	return limiter.Allow()
+}
+
+// sweep periodically forgets repos whose bucket has refilled, as a fresh limiter would behave identically
+func (l *didLimiter) sweep(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.lk.Lock()
+			for did, limiter := range l.limiters {
+				if limiter.TokensAt(now) >= float64(l.burst) {
+					delete(l.limiters, did)
+				}
+			}
+			l.lk.Unlock()
+		}
+	}
+}
+
+// admitOp reports whether an op from the repo should be forwarded, returning the records_handled status to use when
+// it is not. Rate limits are applied later, by throttleEvents, once duplicates are dropped.
+func (kf *KafkaFirehose) admitOp(did string) (string, bool) {
+	if kf.didFilter != nil && !kf.didFilter.allowed(did) {
+		return statusDenied, false
+	}
+
+	return "", true
+}
+
+// throttleEvents drops the creates and updates of repos that are over their rate limit. It runs after dedupEvents, so
+// that the copies of an event delivered by several upstreams only spend one token. Deletes are never throttled, so
+// that removing spam is never held back.
+func (kf *KafkaFirehose) throttleEvents(kafkaEvts []*vyletkafka.FirehoseEvent) []*vyletkafka.FirehoseEvent {
+	if kf.didLimiter == nil {
+		return kafkaEvts
+	}
+
+	out := kafkaEvts[:0]
+	for _, kafkaEvt := range kafkaEvts {
+		commit := kafkaEvt.Commit
+		if commit != nil && commit.Operation != vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE && !kf.didLimiter.allow(kafkaEvt.Did) {
+			recordsHandled.WithLabelValues(statusThrottled, commit.Collection).Inc()
+			kf.dedupDone(kafkaEvt, false)
+			continue
+		}
+		out = append(out, kafkaEvt)
+	}
+	return out
+}
@@ -5,22 +5,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
 	"github.com/bluesky-social/indigo/atproto/atdata"
 	"github.com/bluesky-social/indigo/events"
 	"github.com/bluesky-social/indigo/repo"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/vylet-app/go/bus/deadletter"
+	"github.com/vylet-app/go/bus/headers"
 	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func (kf *KafkaFirehose) handleEvent(ctx context.Context, evt *events.XRPCStreamEvent) error {
-	logger := kf.logger.With("name", "handleEvent", "seq", evt.Sequence())
+func (kf *KafkaFirehose) handleEvent(ctx context.Context, up *upstream, evt *events.XRPCStreamEvent, entry *ackEntry) error {
+	logger := kf.logger.With("name", "handleEvent", "upstream", up.host, "seq", evt.Sequence())
 
 	logger.Debug("received event")
 
+	up.setLastEventAt(time.Now())
+
 	var kind string
 	if evt.RepoIdentity != nil {
 		kind = "identity"
@@ -38,13 +46,11 @@ func (kf *KafkaFirehose) handleEvent(ctx context.Context, evt *events.XRPCStream
 
 	eventsReceived.WithLabelValues(kind).Inc()
 
-	if evt.RepoCommit == nil && evt.RepoIdentity == nil && evt.RepoAccount == nil {
+	if evt.RepoCommit == nil && evt.RepoIdentity == nil && evt.RepoAccount == nil && evt.RepoSync == nil && evt.RepoInfo == nil {
 		logger.Debug("not a handled operation, skipping")
 		return nil
 	}
 
-	kf.setCursor(evt.Sequence())
-
 	var kafkaEvts []*vyletkafka.FirehoseEvent
 
 	if evt.RepoIdentity != nil {
@@ -57,6 +63,7 @@ func (kf *KafkaFirehose) handleEvent(ctx context.Context, evt *events.XRPCStream
 		if err != nil {
 			return fmt.Errorf("failed to marshal identity event time %s to go time: %w", evt.RepoIdentity.Time, err)
 		}
+		eventLag.WithLabelValues(up.host).Set(time.Since(parsedTime).Seconds())
 
 		kafkaEvts = append(kafkaEvts, &vyletkafka.FirehoseEvent{
 			Did:       evt.RepoIdentity.Did,
@@ -73,16 +80,68 @@ func (kf *KafkaFirehose) handleEvent(ctx context.Context, evt *events.XRPCStream
 		if err != nil {
 			return fmt.Errorf("failed to marshal account event time %s to go time: %w", evt.RepoAccount.Time, err)
 		}
+		eventLag.WithLabelValues(up.host).Set(time.Since(parsedTime).Seconds())
 
 		kafkaEvts = append(kafkaEvts, &vyletkafka.FirehoseEvent{
 			Did:       evt.RepoAccount.Did,
 			Timestamp: timestamppb.New(parsedTime),
 			Account:   b,
 		})
+	} else if evt.RepoSync != nil {
+		if kf.didFilter != nil && !kf.didFilter.allowed(evt.RepoSync.Did) {
+			logger.Debug("sync for denied repo, skipping", "did", evt.RepoSync.Did)
+			return nil
+		}
+
+		// the blocks only hold the signed commit, which consumers resyncing the repo fetch again anyway
+		b, err := json.Marshal(&comatproto.SyncSubscribeRepos_Sync{
+			Did:  evt.RepoSync.Did,
+			Rev:  evt.RepoSync.Rev,
+			Seq:  evt.RepoSync.Seq,
+			Time: evt.RepoSync.Time,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal sync event into bytes: %w", err)
+		}
+
+		parsedTime, err := time.Parse(time.RFC3339Nano, evt.RepoSync.Time)
+		if err != nil {
+			return fmt.Errorf("failed to marshal sync event time %s to go time: %w", evt.RepoSync.Time, err)
+		}
+		eventLag.WithLabelValues(up.host).Set(time.Since(parsedTime).Seconds())
+
+		kafkaEvts = append(kafkaEvts, &vyletkafka.FirehoseEvent{
+			Did:       evt.RepoSync.Did,
+			Timestamp: timestamppb.New(parsedTime),
+			Sync:      b,
+		})
+	} else if evt.RepoInfo != nil {
+		b, err := json.Marshal(evt.RepoInfo)
+		if err != nil {
+			return fmt.Errorf("failed to marshal info event into bytes: %w", err)
+		}
+
+		logger.Info("received info event", "info", evt.RepoInfo.Name)
+
+		// info events carry no time of their own
+		kafkaEvts = append(kafkaEvts, &vyletkafka.FirehoseEvent{
+			Timestamp: timestamppb.Now(),
+			Info:      b,
+		})
 	} else {
+		if kf.directory != nil && kf.commitHasWantedOps(evt.RepoCommit) {
+			if rejection := kf.verifyCommit(ctx, evt.RepoCommit); rejection != nil {
+				kf.rejectCommit(ctx, logger, evt.RepoCommit, rejection)
+				return nil
+			}
+		}
+
 		rr, err := repo.ReadRepoFromCar(ctx, bytes.NewReader(evt.RepoCommit.Blocks))
 		if err != nil {
 			logger.Error("failed to read repo from car", "did", evt.RepoCommit.Repo, "err", err)
+			if kf.commitHasWantedOps(evt.RepoCommit) {
+				kf.deadLetterCommit(ctx, evt.RepoCommit, "", "", fmt.Errorf("failed to read repo from car: %w", err), vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_COMMIT_CAR, evt.RepoCommit.Blocks)
+			}
 			return nil
 		}
 
@@ -90,6 +149,7 @@ func (kf *KafkaFirehose) handleEvent(ctx context.Context, evt *events.XRPCStream
 		if err != nil {
 			return fmt.Errorf("failed to marshal commit event time %s to go time: %w", evt.RepoCommit.Time, err)
 		}
+		eventLag.WithLabelValues(up.host).Set(time.Since(parsedTime).Seconds())
 
 		protoTime := timestamppb.New(parsedTime)
 
@@ -101,7 +161,10 @@ func (kf *KafkaFirehose) handleEvent(ctx context.Context, evt *events.XRPCStream
 				logger := logger.With("collection", collection)
 
 				defer func() {
-					recordsHandled.WithLabelValues(status, collection).Inc()
+					// ops that became events are counted by produceEvents
+					if status != "" {
+						recordsHandled.WithLabelValues(status, collection).Inc()
+					}
 				}()
 
 				pts := strings.Split(op.Path, "/")
@@ -113,19 +176,18 @@ func (kf *KafkaFirehose) handleEvent(ctx context.Context, evt *events.XRPCStream
 				collection = pts[0]
 				rkey := pts[1]
 
-				wantsCollection := false
-				for _, desiredCollection := range kf.desiredCollections {
-					if collection == desiredCollection || strings.HasPrefix(collection, desiredCollection) {
-						wantsCollection = true
-						break
-					}
-				}
-				if !wantsCollection {
+				if !kf.wantsCollection(collection) {
 					logger.Debug("collection undesired, skipping", "collection", collection)
 					status = "skipped"
 					return
 				}
 
+				if rejectStatus, ok := kf.admitOp(evt.RepoCommit.Repo); !ok {
+					logger.Debug("op not admitted, skipping", "did", evt.RepoCommit.Repo, "status", rejectStatus)
+					status = rejectStatus
+					return
+				}
+
 				var operation vyletkafka.CommitOperation
 
 				switch op.Action {
@@ -138,26 +200,39 @@ func (kf *KafkaFirehose) handleEvent(ctx context.Context, evt *events.XRPCStream
 				}
 
 				var rec map[string]any
+				var recCbor []byte
 				var recCid string
 
 				if op.Action == "create" || op.Action == "update" {
 					rcid, recB, err := rr.GetRecordBytes(ctx, op.Path)
 					if err != nil {
 						logger.Error("failed to read record bytes", "err", err)
+						kf.deadLetterCommit(ctx, evt.RepoCommit, collection, rkey, fmt.Errorf("failed to read record bytes: %w", err), vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_COMMIT_CAR, evt.RepoCommit.Blocks)
 						return
 					}
 
 					recCid = rcid.String()
 					if recCid != op.Cid.String() {
 						logger.Error("record cid mismatch", "expected", *op.Cid, "actual", recCid)
+						kf.deadLetterCommit(ctx, evt.RepoCommit, collection, rkey, fmt.Errorf("record cid mismatch, expected %s got %s", op.Cid, recCid), vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_RECORD_CBOR, *recB)
 						return
 					}
 
 					maybeRec, err := atdata.UnmarshalCBOR(*recB)
 					if err != nil {
 						logger.Error("failed to unmarshal record", "err", err)
+						kf.deadLetterCommit(ctx, evt.RepoCommit, collection, rkey, fmt.Errorf("failed to unmarshal record: %w", err), vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_RECORD_CBOR, *recB)
+						return
+					}
+
+					if err := kf.validateRecord(collection, maybeRec); err != nil {
+						kf.rejectInvalidRecord(ctx, logger, evt.RepoCommit.Repo, evt.RepoCommit.Rev, collection, rkey, err, vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_RECORD_CBOR, *recB)
+						status = "invalid"
+						return
 					}
+
 					rec = maybeRec
+					recCbor = *recB
 				}
 
 				var b []byte
@@ -165,48 +240,150 @@ func (kf *KafkaFirehose) handleEvent(ctx context.Context, evt *events.XRPCStream
 					maybeB, err := json.Marshal(rec)
 					if err != nil {
 						logger.Error("failed to marshal record map to json", "err", err)
+						kf.deadLetterCommit(ctx, evt.RepoCommit, collection, rkey, fmt.Errorf("failed to marshal record map to json: %w", err), vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_UNSPECIFIED, nil)
 						return
 					}
 					b = maybeB
 				}
 
+				commit := &vyletkafka.Commit{
+					Rev:        evt.RepoCommit.Rev,
+					Operation:  operation,
+					Collection: collection,
+					Rkey:       rkey,
+					Record:     b,
+					Cid:        recCid,
+					RecordCbor: recCbor,
+				}
+
+				if recCbor != nil {
+					// consumers fall back to the json record, so a record we can't type is still produced
+					if err := records.SetFromCBOR(commit, recCbor); err != nil {
+						logger.Warn("failed to build typed record", "err", err)
+					}
+				}
+
 				kafkaEvts = append(kafkaEvts,
 					&vyletkafka.FirehoseEvent{
 						Did:       evt.RepoCommit.Repo,
 						Timestamp: protoTime,
-						Commit: &vyletkafka.Commit{
-							Rev:        evt.RepoCommit.Rev,
-							Operation:  operation,
-							Collection: collection,
-							Rkey:       rkey,
-							Record:     b,
-							Cid:        recCid,
-						},
+						Commit:    commit,
 					})
 
-				status = "ok"
+				status = ""
 			}()
 		}
 	}
 
-	for _, kafkaEvt := range kafkaEvts {
-		if err := kf.producer.ProduceAsync(ctx, kafkaEvt.Did, kafkaEvt, func(r *kgo.Record, err error) {
-			status := "error"
-			defer func() {
-				messagesProduced.WithLabelValues(status).Inc()
-			}()
+	kf.produceEvents(ctx, logger, up, entry, kafkaEvts)
 
-			if err != nil {
-				logger.Error("error after producting event async", "err", err)
-				return
-			}
+	return nil
+}
+
+// deadLetterCommit sends the payload of a commit, or of one of its ops when collection and rkey are set, to the
+// dead-letter topic
+func (kf *KafkaFirehose) deadLetterCommit(ctx context.Context, commit *comatproto.SyncSubscribeRepos_Commit, collection, rkey string, reason error, payloadType vyletkafka.DeadLetterPayload, payload []byte) {
+	kf.deadLetters.Send(ctx, &vyletkafka.DeadLetter{
+		Stage:       deadletter.StageFirehose,
+		Reason:      reason.Error(),
+		Collection:  collection,
+		Did:         commit.Repo,
+		Rkey:        rkey,
+		Rev:         commit.Rev,
+		PayloadType: payloadType,
+		Payload:     payload,
+	})
+}
 
-			status = "ok"
-			logger.Debug("produced event")
-		}); err != nil {
-			logger.Error("failed to produce event async", "err", err)
+func (kf *KafkaFirehose) wantsCollection(collection string) bool {
+	for _, desiredCollection := range kf.desiredCollections {
+		if collection == desiredCollection || strings.HasPrefix(collection, desiredCollection) {
+			return true
 		}
 	}
+	return false
+}
 
-	return nil
+// produceEvents asynchronously produces each event to the main output topic and any routed topics, stamping the
+// records with headers describing the event. The upstream event is marked dispatched once everything is handed to
+// the client, and is complete once every record has been acked.
+func (kf *KafkaFirehose) produceEvents(ctx context.Context, logger *slog.Logger, up *upstream, entry *ackEntry, kafkaEvts []*vyletkafka.FirehoseEvent) {
+	kf.txnLk.RLock()
+	defer kf.txnLk.RUnlock()
+	defer up.acks.dispatched(entry)
+
+	for _, kafkaEvt := range kf.throttleEvents(kf.dedupEvents(kafkaEvts)) {
+		payload, err := proto.Marshal(kafkaEvt)
+		if err != nil {
+			logger.Error("failed to marshal event", "err", err)
+			messagesProduced.WithLabelValues("error").Inc()
+			if kafkaEvt.Commit != nil {
+				recordsHandled.WithLabelValues("error", kafkaEvt.Commit.Collection).Inc()
+			}
+			kf.dedupDone(kafkaEvt, false)
+			continue
+		}
+
+		if kafkaEvt.Commit != nil {
+			recordsHandled.WithLabelValues("ok", kafkaEvt.Commit.Collection).Inc()
+		}
+
+		hasBlobs := records.HasBlobs(kafkaEvt.Commit)
+		hdrs := headers.ForEvent(kafkaEvt, entry.seq, hasBlobs)
+
+		if kf.capture != nil {
+			if err := kf.capture.Write(&vyletkafka.CapturedEvent{
+				Sequence:   entry.seq,
+				Upstream:   up.host,
+				CapturedAt: timestamppb.Now(),
+				HasBlobs:   hasBlobs,
+				Event:      kafkaEvt,
+			}); err != nil {
+				logger.Error("failed to capture event", "err", err)
+			}
+		}
+
+		topics := kf.topicsFor(kafkaEvt, hasBlobs)
+		up.acks.produced(entry, len(topics))
+
+		if len(topics) == 0 {
+			kf.dedupDone(kafkaEvt, true)
+			continue
+		}
+
+		evtAcks := &eventAcks{}
+		evtAcks.remaining.Store(int32(len(topics)))
+
+		for _, topic := range topics {
+			kf.client.Produce(ctx, &kgo.Record{
+				Topic:   topic,
+				Key:     []byte(kafkaEvt.Did),
+				Value:   payload,
+				Headers: hdrs,
+			}, func(r *kgo.Record, err error) {
+				status := "error"
+				defer func() {
+					messagesProduced.WithLabelValues(status).Inc()
+					up.acks.acked(entry)
+				}()
+
+				if last, produced := evtAcks.acked(err); last {
+					kf.dedupDone(kafkaEvt, produced)
+				}
+
+				if err != nil {
+					logger.Error("error after producting event async", "topic", r.Topic, "err", err)
+					// a failed record aborts the whole transaction, which is then replayed, so only dead-letter
+					// when the cursor is about to move past it
+					if !kf.transactional {
+						kf.deadLetters.SendEvent(context.Background(), deadletter.StageFirehose, kafkaEvt, err)
+					}
+					return
+				}
+
+				status = "ok"
+				logger.Debug("produced event", "topic", r.Topic)
+			})
+		}
+	}
 }
@@ -0,0 +1,284 @@
+package kafkafirehose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	"github.com/bluesky-social/indigo/atproto/atdata"
+	"github.com/gorilla/websocket"
+	"github.com/vylet-app/go/bus/deadletter"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// jetstreamEvent is a single message from a Jetstream subscription. Identity and account payloads share their shape
+// with the relay's #identity and #account frames, so they decode straight into the com.atproto types.
+type jetstreamEvent struct {
+	Did      string                                  `json:"did"`
+	TimeUS   int64                                   `json:"time_us"`
+	Kind     string                                  `json:"kind"`
+	Commit   *jetstreamCommit                        `json:"commit,omitempty"`
+	Identity *comatproto.SyncSubscribeRepos_Identity `json:"identity,omitempty"`
+	Account  *comatproto.SyncSubscribeRepos_Account  `json:"account,omitempty"`
+}
+
+type jetstreamCommit struct {
+	Rev        string          `json:"rev"`
+	Operation  string          `json:"operation"`
+	Collection string          `json:"collection"`
+	Rkey       string          `json:"rkey"`
+	Record     json.RawMessage `json:"record,omitempty"`
+	Cid        string          `json:"cid"`
+}
+
+// subscribeJetstream dials the jetstream host and handles messages until the stream ends, the context is cancelled,
+// or the watchdog observes that no events have arrived within the stall timeout. The cursor is the time_us of the
+// last handled message.
+func (kf *KafkaFirehose) subscribeJetstream(ctx context.Context, logger *slog.Logger, up *upstream) error {
+	u, err := url.Parse(up.host)
+	if err != nil {
+		return fmt.Errorf("failed to parse jetstream host: %w", err)
+	}
+
+	u.Path = "/subscribe"
+
+	query := url.Values{}
+	for _, coll := range kf.wantedCollections {
+		query.Add("wantedCollections", coll)
+	}
+	if cursor := up.getCursor(); cursor != nil {
+		query.Set("cursor", strconv.FormatInt(*cursor, 10))
+	}
+	u.RawQuery = query.Encode()
+
+	logger.Info("subscribing to jetstream", "url", u.String())
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u.String(), http.Header{
+		"User-Agent": []string{"vylet-kafka/0.0.0"},
+	})
+	if err != nil {
+		return fmt.Errorf("error dialing websocket: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logger.Debug("error closing websocket", "err", err)
+		} else {
+			logger.Info("websocket closed")
+		}
+	}()
+
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stalled atomic.Bool
+	go kf.watchForStall(connCtx, cancel, logger, up, &stalled)
+
+	// reads don't observe the context, so closing the connection is what unblocks them
+	go func() {
+		<-connCtx.Done()
+		conn.Close()
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			if stalled.Load() {
+				return fmt.Errorf("jetstream stalled")
+			}
+			if connCtx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("error reading from jetstream: %w", err)
+		}
+
+		var evt jetstreamEvent
+		if err := json.Unmarshal(msg, &evt); err != nil {
+			logger.Error("failed to unmarshal jetstream event", "err", err)
+			continue
+		}
+
+		entry := up.acks.track(evt.TimeUS)
+		if err := kf.handleJetstreamEvent(connCtx, up, &evt, entry); err != nil {
+			logger.Error("failed to handle jetstream event", "err", err)
+		}
+		up.acks.dispatched(entry)
+	}
+}
+
+func (kf *KafkaFirehose) handleJetstreamEvent(ctx context.Context, up *upstream, evt *jetstreamEvent, entry *ackEntry) error {
+	logger := kf.logger.With("name", "handleJetstreamEvent", "time_us", evt.TimeUS)
+
+	logger.Debug("received event")
+
+	up.setLastEventAt(time.Now())
+
+	eventsReceived.WithLabelValues(evt.Kind).Inc()
+
+	if evt.Kind != "commit" && evt.Kind != "identity" && evt.Kind != "account" {
+		logger.Debug("not a handled operation, skipping")
+		return nil
+	}
+
+	var kafkaEvts []*vyletkafka.FirehoseEvent
+
+	switch evt.Kind {
+	case "identity":
+		if evt.Identity == nil {
+			return fmt.Errorf("identity event is missing identity payload")
+		}
+
+		b, err := json.Marshal(evt.Identity)
+		if err != nil {
+			return fmt.Errorf("failed to marshal identity event into bytes: %w", err)
+		}
+
+		parsedTime, err := time.Parse(time.RFC3339Nano, evt.Identity.Time)
+		if err != nil {
+			return fmt.Errorf("failed to marshal identity event time %s to go time: %w", evt.Identity.Time, err)
+		}
+		eventLag.WithLabelValues(up.host).Set(time.Since(parsedTime).Seconds())
+
+		kafkaEvts = append(kafkaEvts, &vyletkafka.FirehoseEvent{
+			Did:       evt.Identity.Did,
+			Timestamp: timestamppb.New(parsedTime),
+			Identity:  b,
+		})
+	case "account":
+		if evt.Account == nil {
+			return fmt.Errorf("account event is missing account payload")
+		}
+
+		b, err := json.Marshal(evt.Account)
+		if err != nil {
+			return fmt.Errorf("failed to marshal account event into bytes: %w", err)
+		}
+
+		parsedTime, err := time.Parse(time.RFC3339Nano, evt.Account.Time)
+		if err != nil {
+			return fmt.Errorf("failed to marshal account event time %s to go time: %w", evt.Account.Time, err)
+		}
+		eventLag.WithLabelValues(up.host).Set(time.Since(parsedTime).Seconds())
+
+		kafkaEvts = append(kafkaEvts, &vyletkafka.FirehoseEvent{
+			Did:       evt.Account.Did,
+			Timestamp: timestamppb.New(parsedTime),
+			Account:   b,
+		})
+	case "commit":
+		if evt.Commit == nil {
+			return fmt.Errorf("commit event is missing commit payload")
+		}
+
+		// jetstream only carries the time it processed the event, which is the closest thing to a commit time
+		parsedTime := time.UnixMicro(evt.TimeUS)
+		eventLag.WithLabelValues(up.host).Set(time.Since(parsedTime).Seconds())
+
+		// commits that became events are counted by produceEvents
+		kafkaEvt, status := kf.jetstreamCommitToEvent(ctx, logger, evt, parsedTime)
+		if kafkaEvt == nil {
+			recordsHandled.WithLabelValues(status, evt.Commit.Collection).Inc()
+		} else {
+			kafkaEvts = append(kafkaEvts, kafkaEvt)
+		}
+	}
+
+	kf.produceEvents(ctx, logger, up, entry, kafkaEvts)
+
+	return nil
+}
+
+// jetstreamCommitToEvent maps a jetstream commit onto the firehose envelope, or returns the records_handled status of
+// a commit that is dropped instead
+func (kf *KafkaFirehose) jetstreamCommitToEvent(ctx context.Context, logger *slog.Logger, evt *jetstreamEvent, parsedTime time.Time) (*vyletkafka.FirehoseEvent, string) {
+	commit := evt.Commit
+	logger = logger.With("collection", commit.Collection)
+
+	if !kf.wantsCollection(commit.Collection) {
+		logger.Debug("collection undesired, skipping")
+		return nil, "skipped"
+	}
+
+	if rejectStatus, ok := kf.admitOp(evt.Did); !ok {
+		logger.Debug("op not admitted, skipping", "did", evt.Did, "status", rejectStatus)
+		return nil, rejectStatus
+	}
+
+	var operation vyletkafka.CommitOperation
+
+	switch commit.Operation {
+	case "create":
+		operation = vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE
+	case "update":
+		operation = vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE
+	case "delete":
+		operation = vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE
+	}
+
+	var b []byte
+	if (commit.Operation == "create" || commit.Operation == "update") && len(commit.Record) > 0 {
+		// round trip through the data model so records come out exactly as they do in relay mode
+		rec, err := atdata.UnmarshalJSON(commit.Record)
+		if err != nil {
+			logger.Error("failed to unmarshal record", "err", err)
+			kf.deadLetterJetstreamCommit(ctx, evt, fmt.Errorf("failed to unmarshal record: %w", err))
+			return nil, "error"
+		}
+
+		if err := kf.validateRecord(commit.Collection, rec); err != nil {
+			kf.rejectInvalidRecord(ctx, logger, evt.Did, commit.Rev, commit.Collection, commit.Rkey, err, vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_RECORD_JSON, commit.Record)
+			return nil, "invalid"
+		}
+
+		maybeB, err := json.Marshal(rec)
+		if err != nil {
+			logger.Error("failed to marshal record map to json", "err", err)
+			kf.deadLetterJetstreamCommit(ctx, evt, fmt.Errorf("failed to marshal record map to json: %w", err))
+			return nil, "error"
+		}
+		b = maybeB
+	}
+
+	kafkaCommit := &vyletkafka.Commit{
+		Rev:        commit.Rev,
+		Operation:  operation,
+		Collection: commit.Collection,
+		Rkey:       commit.Rkey,
+		Record:     b,
+		Cid:        commit.Cid,
+	}
+
+	// jetstream doesn't carry the signed bytes, so only the typed record can be filled in
+	if b != nil {
+		if err := records.SetFromJSON(kafkaCommit, b); err != nil {
+			logger.Warn("failed to build typed record", "err", err)
+		}
+	}
+
+	return &vyletkafka.FirehoseEvent{
+		Did:       evt.Did,
+		Timestamp: timestamppb.New(parsedTime),
+		Commit:    kafkaCommit,
+	}, ""
+}
+
+func (kf *KafkaFirehose) deadLetterJetstreamCommit(ctx context.Context, evt *jetstreamEvent, reason error) {
+	kf.deadLetters.Send(ctx, &vyletkafka.DeadLetter{
+		Stage:       deadletter.StageFirehose,
+		Reason:      reason.Error(),
+		Collection:  evt.Commit.Collection,
+		Did:         evt.Did,
+		Rkey:        evt.Commit.Rkey,
+		Rev:         evt.Commit.Rev,
+		PayloadType: vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_RECORD_JSON,
+		Payload:     evt.Commit.Record,
+	})
+}
@@ -0,0 +1,52 @@
+package kafkafirehose
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/bluesky-social/indigo/atproto/lexicon"
+	"github.com/vylet-app/go/bus/deadletter"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+)
+
+// newLexiconCatalog loads every schema in the lexicons directory, the same tree consumed by lexgen. It should include
+// the com.atproto schemas, since vylet records reference types like com.atproto.repo.strongRef.
+func newLexiconCatalog(dir string) (*lexicon.BaseCatalog, error) {
+	cat := lexicon.NewBaseCatalog()
+	if err := cat.LoadDirectory(dir); err != nil {
+		return nil, fmt.Errorf("failed to load lexicons from %s: %w", dir, err)
+	}
+	return &cat, nil
+}
+
+// validateRecord checks a decoded record against its collection's schema. Collections without a schema in the
+// catalog are passed through unchecked, as are all records when validation is disabled.
+func (kf *KafkaFirehose) validateRecord(collection string, rec map[string]any) error {
+	if kf.lexicons == nil {
+		return nil
+	}
+
+	if _, err := kf.lexicons.Resolve(collection); err != nil {
+		return nil
+	}
+
+	return lexicon.ValidateRecord(kf.lexicons, rec, collection, 0)
+}
+
+// rejectInvalidRecord sends a record that failed lexicon validation to the dead-letter topic along with the
+// validation error, so that it can be re-driven once the record or the schema is fixed
+func (kf *KafkaFirehose) rejectInvalidRecord(ctx context.Context, logger *slog.Logger, did, rev, collection, rkey string, validationErr error, payloadType vyletkafka.DeadLetterPayload, payload []byte) {
+	logger.Info("record failed lexicon validation", "did", did, "rkey", rkey, "err", validationErr)
+
+	kf.deadLetters.Send(ctx, &vyletkafka.DeadLetter{
+		Stage:       deadletter.StageFirehose,
+		Reason:      fmt.Sprintf("record failed lexicon validation: %s", validationErr),
+		Collection:  collection,
+		Did:         did,
+		Rkey:        rkey,
+		Rev:         rev,
+		PayloadType: payloadType,
+		Payload:     payload,
+	})
+}
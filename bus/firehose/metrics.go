@@ -24,4 +24,39 @@ var (
 		Namespace: namespace,
 		Name:      "messages_produced",
 	}, []string{"status"})
+
+	reconnectAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconnect_attempts",
+	}, []string{"upstream"})
+
+	streamStalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stream_stalls",
+	}, []string{"upstream"})
+
+	duplicatesDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "duplicates_dropped",
+	}, []string{"kind"})
+
+	commitsRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "commits_rejected",
+	}, []string{"reason"})
+
+	unackedEvents = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "unacked_events",
+	}, []string{"upstream"})
+
+	transactionsCommitted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transactions_committed",
+	}, []string{"status"})
+
+	eventLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "event_lag_seconds",
+	}, []string{"upstream"})
 )
@@ -0,0 +1,78 @@
+package kafkafirehose
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bluesky-social/go-util/pkg/bus/kafka"
+	"github.com/twmb/franz-go/pkg/kgo"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+)
+
+// Route sends commits whose collection matches Prefix to Topic, in addition to the main output topic. Prefix is
+// either a full collection NSID or an NSID prefix like app.vylet.feed.*
+type Route struct {
+	Prefix string
+	Topic  string
+}
+
+// ParseRoute parses a route in the form <prefix>=<topic>
+func ParseRoute(s string) (Route, error) {
+	prefix, topic, ok := strings.Cut(s, "=")
+	if !ok || prefix == "" || topic == "" {
+		return Route{}, fmt.Errorf("invalid route %q, expected <collection or prefix>=<topic>", s)
+	}
+
+	return Route{
+		Prefix: strings.TrimSuffix(strings.TrimSuffix(prefix, ".*"), "."),
+		Topic:  topic,
+	}, nil
+}
+
+func (r Route) matches(collection string) bool {
+	return collection == r.Prefix || strings.HasPrefix(collection, r.Prefix+".")
+}
+
+// ensureOutputTopic creates an output topic with the settings the main topic has always been created with
+func ensureOutputTopic(ctx context.Context, client *kgo.Client, bootstrapServers []string, topic string) error {
+	return kafka.EnsureTopic(ctx, client, kafka.Config{
+		BootstrapServers:  bootstrapServers,
+		Topic:             topic,
+		TopicPartitions:   24,
+		ReplicationFactor: 1,
+		TopicConfig: []string{
+			fmt.Sprintf("retention.ms=%d", (24 * time.Hour).Milliseconds()),
+		},
+	})
+}
+
+// topicsFor returns every topic an event should be produced to, starting with the main output topic
+func (kf *KafkaFirehose) topicsFor(evt *vyletkafka.FirehoseEvent, hasBlobs bool) []string {
+	topics := []string{kf.outputTopic}
+	if evt.Commit == nil {
+		return topics
+	}
+
+	add := func(topic string) {
+		for _, t := range topics {
+			if t == topic {
+				return
+			}
+		}
+		topics = append(topics, topic)
+	}
+
+	for _, route := range kf.routes {
+		if route.matches(evt.Commit.Collection) {
+			add(route.Topic)
+		}
+	}
+
+	if hasBlobs && kf.blobTopic != "" {
+		add(kf.blobTopic)
+	}
+
+	return topics
+}
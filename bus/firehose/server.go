@@ -4,45 +4,144 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/bluesky-social/go-util/pkg/bus/cursor"
+	"github.com/bluesky-social/go-util/pkg/bus/kafka"
 	"github.com/bluesky-social/go-util/pkg/bus/producer"
+	"github.com/bluesky-social/indigo/atproto/identity"
+	"github.com/bluesky-social/indigo/atproto/lexicon"
 	"github.com/bluesky-social/indigo/events"
-	"github.com/bluesky-social/indigo/events/schedulers/parallel"
 	"github.com/gorilla/websocket"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/vylet-app/go/bus/capture"
+	"github.com/vylet-app/go/bus/deadletter"
 	vyletkafka "github.com/vylet-app/go/bus/proto"
 )
 
+// InputMode selects the upstream event stream the firehose reads from
+type InputMode string
+
+const (
+	// InputModeRelay reads the CBOR com.atproto.sync.subscribeRepos stream from a relay
+	InputModeRelay InputMode = "relay"
+	// InputModeJetstream reads the JSON stream served by a Jetstream instance
+	InputModeJetstream InputMode = "jetstream"
+)
+
 type KafkaFirehose struct {
 	logger *slog.Logger
 
-	producer *producer.Producer[*vyletkafka.FirehoseEvent]
+	client      *kgo.Client
+	outputTopic string
+	routes      []Route
+	blobTopic   string
+	deadLetters *deadletter.Producer
+
+	// set only when commit verification is enabled
+	directory        identity.Directory
+	rejectedProducer *producer.Producer[*vyletkafka.RejectedCommit]
+
+	// set only when lexicon validation is enabled
+	lexicons *lexicon.BaseCatalog
+
+	// set only when capturing produced events
+	capture *capture.Writer
+
+	// set only when did lists or per-did rate limits are configured
+	didFilter             *didFilter
+	didListReloadInterval time.Duration
+	didLimiter            *didLimiter
+
+	// in transactional mode records and the cursor are committed together every transactionInterval, and producers
+	// hold txnLk for reading so that a commit never lands midway through an event
+	transactional       bool
+	transactionInterval time.Duration
+	txnLk               sync.RWMutex
+	lastCommittedCursor *vyletkafka.SequenceCursor
 
 	cursor          *cursor.Cursor[*vyletkafka.SequenceCursor]
-	lastCursor      *int64
-	cursorLk        sync.Mutex
 	saveLastCursor  chan struct{}
 	lastCursorSaved chan struct{}
 
+	inputMode          InputMode
 	desiredCollections []string
-	websocketHost      string
+	wantedCollections  []string
+	upstreams          []*upstream
+
+	// events already produced, so that the same event arriving from several upstreams is only produced once
+	dedup *dedupWindow
+
+	stallTimeout time.Duration
+	backoffMin   time.Duration
+	backoffMax   time.Duration
 }
 
 type Args struct {
 	Logger *slog.Logger
 
+	// InputMode defaults to InputModeRelay
+	InputMode          InputMode
 	DesiredCollections []string
-	WebsocketHost      string
-	BootstrapServers   []string
-	OutputTopic        string
+	// WebsocketHosts are the relays to subscribe to in relay mode. Each keeps its own cursor and events are
+	// deduplicated across all of them.
+	WebsocketHosts   []string
+	JetstreamHost    string
+	BootstrapServers []string
+	OutputTopic      string
+
+	// DedupWindow is the number of recently produced events remembered for deduplication
+	DedupWindow int
+
+	// Routes additionally send matching commits to their own topics
+	Routes []Route
+	// BlobTopic additionally receives every commit whose record references a blob
+	BlobTopic string
+
+	// Transactional commits produced events and the cursor atomically every TransactionInterval, using
+	// TransactionalID, which must be unique to this firehose instance
+	Transactional       bool
+	TransactionalID     string
+	TransactionInterval time.Duration
+
+	// VerifyCommits checks commit signatures and MST proofs before producing, sending failures to <OutputTopic>-rejected
+	VerifyCommits bool
+	PLCHost       string
+
+	// LexiconsPath, when set, is loaded as a lexicon catalog and every record is validated against its collection's
+	// schema before producing. Invalid records are sent to the dead-letter topic instead.
+	LexiconsPath string
+
+	// CaptureDir, when set, receives a copy of every produced event in compressed segments of CaptureSegmentEvents
+	// events each, which can be replayed with kafka-replay
+	CaptureDir           string
+	CaptureSegmentEvents int
+
+	// AllowDidsFile and DenyDidsFile list one DID per line. When an allow list is set only the DIDs on it are
+	// forwarded, and DIDs on the deny list never are. Both are reloaded every DidListReloadInterval.
+	AllowDidsFile         string
+	DenyDidsFile          string
+	DidListReloadInterval time.Duration
+
+	// DidRateLimit is the number of creates and updates per minute forwarded for any single repo, with bursts of up
+	// to DidRateBurst. Ops over the limit are dropped. Disabled when zero.
+	DidRateLimit float64
+	DidRateBurst int
+
+	// StallTimeout forces a reconnect when no event has been received for this long
+	StallTimeout time.Duration
+	// ReconnectBackoffMin and ReconnectBackoffMax bound the jittered exponential backoff between reconnects
+	ReconnectBackoffMin time.Duration
+	ReconnectBackoffMax time.Duration
 }
 
 func New(ctx context.Context, args *Args) (*KafkaFirehose, error) {
@@ -52,25 +151,170 @@ func New(ctx context.Context, args *Args) (*KafkaFirehose, error) {
 
 	logger := args.Logger
 
-	busProducer, err := producer.New(
-		ctx,
-		logger.With("component", "producer"),
-		args.BootstrapServers,
-		args.OutputTopic,
-		producer.WithEnsureTopic[*vyletkafka.FirehoseEvent](true),
-		producer.WithTopicPartitions[*vyletkafka.FirehoseEvent](24),
-		producer.WithRetentionTime[*vyletkafka.FirehoseEvent](24*time.Hour),
-		producer.WithReplicationFactor[*vyletkafka.FirehoseEvent](1),
-	)
+	if args.InputMode == "" {
+		args.InputMode = InputModeRelay
+	}
+
+	var upstreamHosts []string
+	switch args.InputMode {
+	case InputModeRelay:
+		upstreamHosts = args.WebsocketHosts
+	case InputModeJetstream:
+		upstreamHosts = []string{args.JetstreamHost}
+	default:
+		return nil, fmt.Errorf("unknown input mode %q", args.InputMode)
+	}
+
+	if len(upstreamHosts) == 0 {
+		return nil, fmt.Errorf("at least one %s host is required", args.InputMode)
+	}
+
+	var upstreams []*upstream
+	for _, host := range upstreamHosts {
+		if _, err := url.Parse(host); err != nil {
+			return nil, fmt.Errorf("failed to parse %s host: %w", args.InputMode, err)
+		}
+		upstreams = append(upstreams, newUpstream(host))
+	}
+
+	if args.DedupWindow <= 0 {
+		args.DedupWindow = 100_000
+	}
+
+	if args.VerifyCommits && args.InputMode != InputModeRelay {
+		return nil, fmt.Errorf("commit verification requires the relay input mode")
+	}
+
+	var lexicons *lexicon.BaseCatalog
+	if args.LexiconsPath != "" {
+		cat, err := newLexiconCatalog(args.LexiconsPath)
+		if err != nil {
+			return nil, err
+		}
+		lexicons = cat
+	}
+
+	var captureWriter *capture.Writer
+	if args.CaptureDir != "" {
+		w, err := capture.NewWriter(&capture.Args{
+			Logger:        logger,
+			Dir:           args.CaptureDir,
+			SegmentEvents: args.CaptureSegmentEvents,
+		})
+		if err != nil {
+			return nil, err
+		}
+		captureWriter = w
+	}
+
+	var filter *didFilter
+	if args.AllowDidsFile != "" || args.DenyDidsFile != "" {
+		f, err := newDidFilter(logger, args.AllowDidsFile, args.DenyDidsFile)
+		if err != nil {
+			return nil, err
+		}
+		filter = f
+
+		if args.DidListReloadInterval <= 0 {
+			args.DidListReloadInterval = 30 * time.Second
+		}
+	}
+
+	var limiter *didLimiter
+	if args.DidRateLimit > 0 {
+		if args.DidRateBurst <= 0 {
+			args.DidRateBurst = max(1, int(args.DidRateLimit))
+		}
+		limiter = newDidLimiter(args.DidRateLimit, args.DidRateBurst)
+	}
+
+	if args.StallTimeout <= 0 {
+		args.StallTimeout = time.Minute
+	}
+	if args.ReconnectBackoffMin <= 0 {
+		args.ReconnectBackoffMin = time.Second
+	}
+	if args.ReconnectBackoffMax < args.ReconnectBackoffMin {
+		args.ReconnectBackoffMax = max(time.Minute, args.ReconnectBackoffMin)
+	}
+
+	// records carry headers and may fan out to routed topics, so produce through the client directly
+	var client *kgo.Client
+	var err error
+	if args.Transactional {
+		if args.TransactionalID == "" {
+			args.TransactionalID = "vylet-kafka-firehose-" + args.OutputTopic
+		}
+		if args.TransactionInterval <= 0 {
+			args.TransactionInterval = time.Second
+		}
+
+		client, err = kgo.NewClient(
+			kgo.SeedBrokers(args.BootstrapServers...),
+			kgo.ClientID("vylet-kafka-firehose"),
+			kgo.ProducerBatchMaxBytes(20<<20),
+			kgo.TransactionalID(args.TransactionalID),
+		)
+	} else {
+		client, err = kafka.NewKafkaClient(kafka.Config{
+			BootstrapServers: args.BootstrapServers,
+			ClientID:         "vylet-kafka-firehose",
+		}, nil)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
 	}
 
-	cursorProducer, err := cursor.New[*vyletkafka.SequenceCursor](ctx, args.BootstrapServers, args.OutputTopic+"-cursor")
+	outputTopics := []string{args.OutputTopic}
+	for _, route := range args.Routes {
+		outputTopics = append(outputTopics, route.Topic)
+	}
+	if args.BlobTopic != "" {
+		outputTopics = append(outputTopics, args.BlobTopic)
+	}
+	for _, topic := range outputTopics {
+		if err := ensureOutputTopic(ctx, client, args.BootstrapServers, topic); err != nil {
+			return nil, fmt.Errorf("failed to ensure output topic %s: %w", topic, err)
+		}
+	}
+
+	cursorProducer, err := cursor.New[*vyletkafka.SequenceCursor](ctx, args.BootstrapServers, CursorTopic(args.OutputTopic))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cursor producer: %w", err)
 	}
 
+	deadLetters, err := deadletter.New(ctx, &deadletter.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.OutputTopic,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var directory identity.Directory
+	var rejectedProducer *producer.Producer[*vyletkafka.RejectedCommit]
+	if args.VerifyCommits {
+		if args.PLCHost == "" {
+			args.PLCHost = "https://plc.directory"
+		}
+		directory = newVerificationDirectory(args.PLCHost)
+
+		rejectedProducer, err = producer.New(
+			ctx,
+			logger.With("component", "rejected-producer"),
+			args.BootstrapServers,
+			args.OutputTopic+"-rejected",
+			producer.WithEnsureTopic[*vyletkafka.RejectedCommit](true),
+			producer.WithTopicPartitions[*vyletkafka.RejectedCommit](1),
+			producer.WithRetentionTime[*vyletkafka.RejectedCommit](7*24*time.Hour),
+			producer.WithReplicationFactor[*vyletkafka.RejectedCommit](1),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rejected commit producer: %w", err)
+		}
+	}
+
 	desiredCollections := make([]string, len(args.DesiredCollections))
 	for idx, coll := range args.DesiredCollections {
 		desiredCollections[idx] = strings.TrimSuffix(strings.TrimSuffix(coll, ".*"), ".")
@@ -79,14 +323,38 @@ func New(ctx context.Context, args *Args) (*KafkaFirehose, error) {
 	kf := KafkaFirehose{
 		logger: args.Logger,
 
-		producer: busProducer,
+		client:      client,
+		outputTopic: args.OutputTopic,
+		routes:      args.Routes,
+		blobTopic:   args.BlobTopic,
+		deadLetters: deadLetters,
+
+		directory:        directory,
+		rejectedProducer: rejectedProducer,
+		lexicons:         lexicons,
+		capture:          captureWriter,
+
+		didFilter:             filter,
+		didListReloadInterval: args.DidListReloadInterval,
+		didLimiter:            limiter,
+
+		transactional:       args.Transactional,
+		transactionInterval: args.TransactionInterval,
 
 		cursor:          cursorProducer,
 		saveLastCursor:  make(chan struct{}, 1),
 		lastCursorSaved: make(chan struct{}, 1),
 
+		inputMode:          args.InputMode,
 		desiredCollections: desiredCollections,
-		websocketHost:      args.WebsocketHost,
+		wantedCollections:  args.DesiredCollections,
+		upstreams:          upstreams,
+
+		dedup: newDedupWindow(args.DedupWindow),
+
+		stallTimeout: args.StallTimeout,
+		backoffMin:   args.ReconnectBackoffMin,
+		backoffMax:   args.ReconnectBackoffMax,
 	}
 
 	logger.Info("attempting to fetch last cursor from bus")
@@ -100,70 +368,48 @@ func New(ctx context.Context, args *Args) (*KafkaFirehose, error) {
 func (kf *KafkaFirehose) Run(ctx context.Context) error {
 	logger := kf.logger.With("name", "Run")
 
-	wsDialer := websocket.DefaultDialer
-	u, err := url.Parse(kf.websocketHost)
-	if err != nil {
-		return fmt.Errorf("failed to parse websocket host: %w", err)
+	var runErr error
+
+	if kf.transactional {
+		if err := kf.client.BeginTransaction(); err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
 	}
 
-	u.Path = "/xrpc/com.atproto.sync.subscribeRepos"
+	consumerCtx, cancelConsumer := context.WithCancel(ctx)
+	defer cancelConsumer()
 
-	cursor := kf.getCursor()
-	if cursor != nil {
-		u.RawQuery = fmt.Sprintf("cursor=%d", *cursor)
+	// each upstream runs independently, so one falling behind or disconnecting doesn't hold up the others
+	var consumers sync.WaitGroup
+	for _, up := range kf.upstreams {
+		consumers.Go(func() {
+			kf.runConsumer(consumerCtx, up)
+		})
 	}
 
-	shutdownConsumer := make(chan struct{}, 1)
 	consumerShutdown := make(chan struct{}, 1)
-	consumerErr := make(chan error, 1)
 	go func() {
-		logger := kf.logger.With("component", "consumer")
-
-		logger.Info("subscribing to repo event stream", "url", u.String())
-
-		// dial the websocket
-		conn, _, err := wsDialer.Dial(u.String(), http.Header{
-			"User-Agent": []string{"vylet-kafka/0.0.0"},
-		})
-		if err != nil {
-			logger.Error("error dialing websocket", "err", err)
-			close(shutdownConsumer)
-			return
-		}
-
-		// setup a new event scheduler
-		parallelism := 400
+		defer close(consumerShutdown)
+		consumers.Wait()
+	}()
 
-		scheduler := parallel.NewScheduler(parallelism, 1000, kf.websocketHost, kf.handleEvent)
+	if kf.didFilter != nil {
+		go kf.didFilter.watch(consumerCtx, kf.didListReloadInterval)
+	}
+	if kf.didLimiter != nil {
+		go kf.didLimiter.sweep(consumerCtx)
+	}
 
-		// run the consumer and wait for it to be shut down
+	txnErr := make(chan error, 1)
+	if kf.transactional {
 		go func() {
-			if err := events.HandleRepoStream(ctx, conn, scheduler, logger); err != nil {
-				logger.Error("error handling repo stream", "err", err)
-				consumerErr <- err
-				return
+			if err := kf.runTransactions(consumerCtx); err != nil {
+				txnErr <- err
 			}
-			consumerErr <- nil
 		}()
-
-		select {
-		case <-shutdownConsumer:
-		case err := <-consumerErr:
-			if err != nil {
-				logger.Error("consumer encountered an error", "err", err)
-			}
-		}
-
-		if err := conn.Close(); err != nil {
-			logger.Error("error closing websocket", "err", err)
-		} else {
-			logger.Info("websocket closed")
-		}
-
-		close(consumerShutdown)
-	}()
-
-	go kf.periodicallySaveCursor(ctx)
+	} else {
+		go kf.periodicallySaveCursor(ctx)
+	}
 
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
@@ -172,12 +418,15 @@ func (kf *KafkaFirehose) Run(ctx context.Context) error {
 	select {
 	case sig := <-signals:
 		logger.Info("received exit signal", "signal", sig)
-		close(shutdownConsumer)
+		cancelConsumer()
 	case <-ctx.Done():
 		logger.Info("main context cancelled")
-		close(shutdownConsumer)
+		cancelConsumer()
 	case <-consumerShutdown:
 		logger.Warn("consumer shutdown unexpectedly, forcing exit")
+	case runErr = <-txnErr:
+		logger.Error("transaction failed, forcing exit", "err", runErr)
+		cancelConsumer()
 	}
 
 	select {
@@ -186,48 +435,268 @@ func (kf *KafkaFirehose) Run(ctx context.Context) error {
 		logger.Warn("websocket did not shut down within five seconds, forcefully shutting down")
 	}
 
-	// close the producer
-	kf.producer.Close()
+	if kf.transactional {
+		if runErr != nil {
+			kf.abortTransaction()
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := kf.commitTransaction(ctx, true); err != nil {
+				logger.Error("failed to commit final transaction", "err", err)
+				kf.abortTransaction()
+			}
+			cancel()
+		}
+		kf.closeClient()
+	} else {
+		// flushing fires the remaining ack callbacks, so the final cursor covers everything that was produced
+		kf.closeClient()
+
+		kf.saveLastCursor <- struct{}{}
+		select {
+		case <-kf.lastCursorSaved:
+		case <-time.After(5 * time.Second):
+			logger.Warn("final cursor was not saved within five seconds")
+		}
+	}
+
+	kf.deadLetters.Close()
+	if kf.rejectedProducer != nil {
+		kf.rejectedProducer.Close()
+	}
 	if err := kf.cursor.Close(); err != nil {
 		logger.Error("error closing cursor", "err", err)
 	}
+	if kf.capture != nil {
+		if err := kf.capture.Close(); err != nil {
+			logger.Error("error closing capture", "err", err)
+		}
+	}
+
+	if runErr != nil {
+		return runErr
+	}
 
 	logger.Info("kafka firehose shutdown successfully")
 
 	return nil
 }
 
+// closeClient flushes outstanding async writes for up to five seconds before closing the kafka client
+func (kf *KafkaFirehose) closeClient() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := kf.client.Flush(ctx); err != nil {
+		kf.logger.Warn("failed to flush producer before closing", "err", err)
+	}
+	kf.client.Close()
+}
+
+// runConsumer keeps a subscription to the upstream stream open until the context is cancelled, reconnecting with
+// jittered exponential backoff and resuming from the last seen cursor whenever the stream ends.
+func (kf *KafkaFirehose) runConsumer(ctx context.Context, up *upstream) {
+	logger := kf.logger.With("component", "consumer", "mode", kf.inputMode, "upstream", up.host)
+
+	subscribe := kf.subscribeRelay
+	if kf.inputMode == InputModeJetstream {
+		subscribe = kf.subscribeJetstream
+	}
+
+	attempt := 0
+	for {
+		connectedAt := time.Now()
+
+		err := subscribe(ctx, logger, up)
+
+		// anything still pending belonged to the old connection and will be replayed from the watermark
+		up.acks.reset()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// only back off further if the last connection never delivered anything
+		if up.getLastEventAt().After(connectedAt) {
+			attempt = 0
+		}
+
+		delay := kf.reconnectDelay(attempt)
+		attempt++
+
+		reconnectAttempts.WithLabelValues(up.host).Inc()
+		logger.Warn("event stream ended, reconnecting", "err", err, "attempt", attempt, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// subscribeRelay dials the relay and handles the repo stream until it ends, the context is cancelled, or the watchdog
+// observes that no events have arrived within the stall timeout.
+func (kf *KafkaFirehose) subscribeRelay(ctx context.Context, logger *slog.Logger, up *upstream) error {
+	u, err := url.Parse(up.host)
+	if err != nil {
+		return fmt.Errorf("failed to parse websocket host: %w", err)
+	}
+
+	u.Path = "/xrpc/com.atproto.sync.subscribeRepos"
+
+	if cursor := up.getCursor(); cursor != nil {
+		u.RawQuery = fmt.Sprintf("cursor=%d", *cursor)
+	}
+
+	logger.Info("subscribing to repo event stream", "url", u.String())
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u.String(), http.Header{
+		"User-Agent": []string{"vylet-kafka/0.0.0"},
+	})
+	if err != nil {
+		return fmt.Errorf("error dialing websocket: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logger.Debug("error closing websocket", "err", err)
+		} else {
+			logger.Info("websocket closed")
+		}
+	}()
+
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stalled atomic.Bool
+	go kf.watchForStall(connCtx, cancel, logger, up, &stalled)
+
+	// setup a new event scheduler
+	parallelism := 400
+
+	scheduler := newTrackingScheduler(up.acks, parallelism, up.host, func(ctx context.Context, evt *events.XRPCStreamEvent, entry *ackEntry) error {
+		return kf.handleEvent(ctx, up, evt, entry)
+	})
+
+	err = events.HandleRepoStream(connCtx, conn, scheduler, logger)
+	if stalled.Load() {
+		return fmt.Errorf("repo stream stalled")
+	}
+	if err != nil {
+		return fmt.Errorf("error handling repo stream: %w", err)
+	}
+
+	return nil
+}
+
+// watchForStall cancels the connection context once no event has been received within the stall timeout
+func (kf *KafkaFirehose) watchForStall(ctx context.Context, cancel context.CancelFunc, logger *slog.Logger, up *upstream, stalled *atomic.Bool) {
+	// give the new connection a full stall window before the watchdog can fire
+	up.setLastEventAt(time.Now())
+
+	ticker := time.NewTicker(kf.stallTimeout / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if since := time.Since(up.getLastEventAt()); since > kf.stallTimeout {
+				logger.Warn("no events received within stall timeout, forcing reconnect", "since", since)
+				streamStalls.WithLabelValues(up.host).Inc()
+				stalled.Store(true)
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// reconnectDelay returns a full-jitter exponential backoff for the given attempt
+func (kf *KafkaFirehose) reconnectDelay(attempt int) time.Duration {
+	ceiling := kf.backoffMax
+	if attempt < 32 {
+		ceiling = min(kf.backoffMin<<attempt, kf.backoffMax)
+	}
+	return kf.backoffMin + rand.N(ceiling-kf.backoffMin+1)
+}
+
 func isFinalCursor(c *vyletkafka.SequenceCursor) bool {
 	return c != nil && c.SavedOnExit
 }
 
-func (kf *KafkaFirehose) loadCursor(ctx context.Context) error {
-	kf.cursorLk.Lock()
-	defer kf.cursorLk.Unlock()
+// cursorSource is the source recorded alongside every cursor this firehose saves
+func (kf *KafkaFirehose) cursorSource() vyletkafka.CursorSource {
+	if kf.inputMode == InputModeJetstream {
+		return vyletkafka.CursorSource_CURSOR_SOURCE_JETSTREAM
+	}
+	return vyletkafka.CursorSource_CURSOR_SOURCE_RELAY
+}
+
+// SourceOf returns the source of a saved cursor, treating cursors written before sources were tracked as relay cursors
+func SourceOf(c *vyletkafka.SequenceCursor) vyletkafka.CursorSource {
+	if c.Source == vyletkafka.CursorSource_CURSOR_SOURCE_UNSPECIFIED {
+		return vyletkafka.CursorSource_CURSOR_SOURCE_RELAY
+	}
+	return c.Source
+}
 
-	if c, err := kf.cursor.Load(ctx, isFinalCursor); err != nil {
+func (kf *KafkaFirehose) loadCursor(ctx context.Context) error {
+	c, err := kf.cursor.Load(ctx, isFinalCursor)
+	if err != nil {
 		return fmt.Errorf("failed to load cursor: %w", err)
-	} else if c != nil {
-		kf.lastCursor = &c.Sequence
-		kf.logger.Info("loaded last cursor", "cursor", kf.lastCursor)
-	} else {
+	}
+
+	if c == nil {
 		kf.logger.Info("no previous cursor found, starting fresh")
+		return nil
+	}
+
+	if SourceOf(c) != kf.cursorSource() {
+		kf.logger.Warn("last cursor was saved by a different input source, starting fresh", "cursor", c.Sequence, "source", SourceOf(c))
+		return nil
+	}
+
+	for idx, up := range kf.upstreams {
+		if seq, ok := c.UpstreamSequences[up.host]; ok {
+			up.setCursor(seq)
+			kf.logger.Info("loaded last cursor", "upstream", up.host, "cursor", seq)
+		} else if idx == 0 && len(c.UpstreamSequences) == 0 {
+			// cursors saved before upstreams were tracked separately belong to the single upstream of the time
+			up.setCursor(c.Sequence)
+			kf.logger.Info("loaded last cursor", "upstream", up.host, "cursor", c.Sequence)
+		} else {
+			kf.logger.Info("no previous cursor found for upstream, starting fresh", "upstream", up.host)
+		}
 	}
 
 	return nil
 }
 
-func (kf *KafkaFirehose) getCursor() *int64 {
-	kf.cursorLk.Lock()
-	defer kf.cursorLk.Unlock()
+// snapshotCursor collects the current cursor of every upstream, returning nil if none of them has one yet
+func (kf *KafkaFirehose) snapshotCursor(final bool) *vyletkafka.SequenceCursor {
+	c := &vyletkafka.SequenceCursor{
+		SavedOnExit:       final,
+		Source:            kf.cursorSource(),
+		UpstreamSequences: make(map[string]int64, len(kf.upstreams)),
+	}
 
-	return kf.lastCursor
-}
+	for idx, up := range kf.upstreams {
+		seq := up.getCursor()
+		if seq == nil {
+			continue
+		}
+		if idx == 0 {
+			c.Sequence = *seq
+		}
+		c.UpstreamSequences[up.host] = *seq
+	}
 
-func (kf *KafkaFirehose) setCursor(c int64) {
-	kf.cursorLk.Lock()
-	defer kf.cursorLk.Unlock()
-	kf.lastCursor = &c
+	if len(c.UpstreamSequences) == 0 {
+		return nil
+	}
+
+	return c
 }
 
 func (kf *KafkaFirehose) periodicallySaveCursor(ctx context.Context) {
@@ -235,14 +704,11 @@ func (kf *KafkaFirehose) periodicallySaveCursor(ctx context.Context) {
 	defer ticker.Stop()
 
 	defer func() {
-		kf.cursorLk.Lock()
-		defer kf.cursorLk.Unlock()
-		if kf.lastCursor != nil {
-			finalCursor := vyletkafka.SequenceCursor{Sequence: *kf.lastCursor, SavedOnExit: true}
-			if err := kf.cursor.Save(context.Background(), &finalCursor); err != nil {
+		if finalCursor := kf.snapshotCursor(true); finalCursor != nil {
+			if err := kf.cursor.Save(context.Background(), finalCursor); err != nil {
 				kf.logger.Error("failed to save final cursor", "err", err)
 			} else {
-				kf.logger.Info("saved final cursor on exit", "cursor", *kf.lastCursor)
+				kf.logger.Info("saved final cursor on exit", "cursors", finalCursor.UpstreamSequences)
 			}
 		}
 		close(kf.lastCursorSaved)
@@ -254,15 +720,11 @@ func (kf *KafkaFirehose) periodicallySaveCursor(ctx context.Context) {
 			kf.logger.Info("saving last cursor...")
 			return
 		case <-ticker.C:
-			kf.cursorLk.Lock()
-			last := kf.lastCursor
-			kf.cursorLk.Unlock()
-
-			if last != nil {
-				if err := kf.cursor.Save(ctx, &vyletkafka.SequenceCursor{Sequence: *last}); err != nil {
+			if last := kf.snapshotCursor(false); last != nil {
+				if err := kf.cursor.Save(ctx, last); err != nil {
 					kf.logger.Info("failed to save cursor", "err", err)
 				} else {
-					kf.logger.Info("saved cursor", "sequence", *last)
+					kf.logger.Info("saved cursor", "sequences", last.UpstreamSequences)
 				}
 			}
 		}
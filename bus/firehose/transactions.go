@@ -0,0 +1,95 @@
+package kafkafirehose
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+)
+
+// runTransactions periodically commits everything produced since the last commit together with the cursor covering
+// it, so that the output topics and the cursor topic never disagree. Returns an error if a commit fails, at which
+// point the process must restart from the last committed cursor.
+func (kf *KafkaFirehose) runTransactions(ctx context.Context) error {
+	ticker := time.NewTicker(kf.transactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := kf.commitTransaction(ctx, false); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// commitTransaction flushes the open transaction, adds the cursor to it and commits. Unless final, a new transaction
+// is opened for subsequent records.
+func (kf *KafkaFirehose) commitTransaction(ctx context.Context, final bool) error {
+	// producers hold the read lock, so once we have the write lock no handler is midway through producing an event
+	kf.txnLk.Lock()
+	defer kf.txnLk.Unlock()
+
+	status := "error"
+	defer func() {
+		transactionsCommitted.WithLabelValues(status).Inc()
+	}()
+
+	// every record produced so far is acknowledged after the flush, so the watermark covers all of them
+	if err := kf.client.Flush(ctx); err != nil {
+		return fmt.Errorf("failed to flush transaction: %w", err)
+	}
+
+	if last := kf.snapshotCursor(final); last != nil && (final || !proto.Equal(last, kf.lastCommittedCursor)) {
+		payload, err := proto.Marshal(last)
+		if err != nil {
+			return fmt.Errorf("failed to marshal cursor: %w", err)
+		}
+
+		if err := kf.client.ProduceSync(ctx, &kgo.Record{
+			Topic: CursorTopic(kf.outputTopic),
+			Value: payload,
+		}).FirstErr(); err != nil {
+			return fmt.Errorf("failed to produce cursor in transaction: %w", err)
+		}
+
+		kf.lastCommittedCursor = last
+	}
+
+	if err := kf.client.EndTransaction(ctx, kgo.TryCommit); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	status = "ok"
+
+	if final {
+		return nil
+	}
+
+	if err := kf.client.BeginTransaction(); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	return nil
+}
+
+// abortTransaction discards the open transaction after a failure
+func (kf *KafkaFirehose) abortTransaction() {
+	kf.txnLk.Lock()
+	defer kf.txnLk.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := kf.client.AbortBufferedRecords(ctx); err != nil {
+		kf.logger.Error("failed to abort buffered records", "err", err)
+	}
+	if err := kf.client.EndTransaction(ctx, kgo.TryAbort); err != nil {
+		kf.logger.Error("failed to abort transaction", "err", err)
+	}
+}
@@ -0,0 +1,48 @@
+package kafkafirehose
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// upstream is a single relay or jetstream connection, along with the cursor and ack state that belong to it
+type upstream struct {
+	host string
+
+	// the cursor only ever advances to the highest sequence whose events, and all earlier ones, have been acked
+	acks *ackTracker
+
+	cursorLk   sync.Mutex
+	lastCursor *int64
+
+	// unix nanos of the last event received, used by the stall watchdog
+	lastEventAt atomic.Int64
+}
+
+func newUpstream(host string) *upstream {
+	up := &upstream{host: host}
+	up.acks = newAckTracker(host, up.setCursor)
+	return up
+}
+
+func (up *upstream) getCursor() *int64 {
+	up.cursorLk.Lock()
+	defer up.cursorLk.Unlock()
+
+	return up.lastCursor
+}
+
+func (up *upstream) setCursor(c int64) {
+	up.cursorLk.Lock()
+	defer up.cursorLk.Unlock()
+	up.lastCursor = &c
+}
+
+func (up *upstream) getLastEventAt() time.Time {
+	return time.Unix(0, up.lastEventAt.Load())
+}
+
+func (up *upstream) setLastEventAt(t time.Time) {
+	up.lastEventAt.Store(t.UnixNano())
+}
@@ -0,0 +1,158 @@
+package kafkafirehose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	"github.com/bluesky-social/indigo/atproto/identity"
+	atrepo "github.com/bluesky-social/indigo/atproto/repo"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/twmb/franz-go/pkg/kgo"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	rejectReasonIdentity  = "identity"
+	rejectReasonSignature = "signature"
+	rejectReasonProof     = "proof"
+)
+
+// commitRejection describes why a commit failed verification
+type commitRejection struct {
+	reason string
+	err    error
+}
+
+// commitHasWantedOps reports whether any op in the commit touches a desired collection, so that we only pay for
+// identity lookups and proof checks on commits we would actually produce
+func (kf *KafkaFirehose) commitHasWantedOps(commit *comatproto.SyncSubscribeRepos_Commit) bool {
+	for _, op := range commit.Ops {
+		collection, _, _ := strings.Cut(op.Path, "/")
+		if kf.wantsCollection(collection) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyCommit checks the commit signature against the repo's current signing key and verifies the MST proofs for
+// every op in the commit
+func (kf *KafkaFirehose) verifyCommit(ctx context.Context, evt *comatproto.SyncSubscribeRepos_Commit) *commitRejection {
+	if rejection := kf.verifyCommitSignature(ctx, evt); rejection != nil {
+		return rejection
+	}
+
+	// checks record inclusion for creates and updates, and inverts the ops to check the tree against prevData
+	if _, err := atrepo.VerifyCommitMessage(ctx, evt); err != nil {
+		return &commitRejection{reason: rejectReasonProof, err: err}
+	}
+
+	return nil
+}
+
+func (kf *KafkaFirehose) verifyCommitSignature(ctx context.Context, evt *comatproto.SyncSubscribeRepos_Commit) *commitRejection {
+	commit, _, err := atrepo.LoadCommitFromCAR(ctx, bytes.NewReader(evt.Blocks))
+	if err != nil {
+		return &commitRejection{reason: rejectReasonProof, err: fmt.Errorf("failed to load commit from car: %w", err)}
+	}
+
+	if err := commit.VerifyStructure(); err != nil {
+		return &commitRejection{reason: rejectReasonProof, err: err}
+	}
+
+	if commit.DID != evt.Repo {
+		return &commitRejection{reason: rejectReasonProof, err: fmt.Errorf("commit did %s does not match event repo %s", commit.DID, evt.Repo)}
+	}
+
+	did, err := syntax.ParseDID(commit.DID)
+	if err != nil {
+		return &commitRejection{reason: rejectReasonIdentity, err: err}
+	}
+
+	verify := func() *commitRejection {
+		ident, err := kf.directory.LookupDID(ctx, did)
+		if err != nil {
+			return &commitRejection{reason: rejectReasonIdentity, err: fmt.Errorf("failed to resolve did: %w", err)}
+		}
+
+		pubkey, err := ident.PublicKey()
+		if err != nil {
+			return &commitRejection{reason: rejectReasonIdentity, err: fmt.Errorf("failed to get signing key: %w", err)}
+		}
+
+		if err := commit.VerifySignature(pubkey); err != nil {
+			return &commitRejection{reason: rejectReasonSignature, err: err}
+		}
+
+		return nil
+	}
+
+	rejection := verify()
+	if rejection == nil || rejection.reason != rejectReasonSignature {
+		return rejection
+	}
+
+	// the cached signing key may be stale after a key rotation, so purge it and try once more before rejecting
+	if err := kf.directory.Purge(ctx, did.AtIdentifier()); err != nil {
+		return rejection
+	}
+
+	return verify()
+}
+
+// rejectCommit counts the rejection and produces a record of it to the rejected topic
+func (kf *KafkaFirehose) rejectCommit(ctx context.Context, logger *slog.Logger, evt *comatproto.SyncSubscribeRepos_Commit, rejection *commitRejection) {
+	logger.Warn("rejecting commit that failed verification", "did", evt.Repo, "rev", evt.Rev, "reason", rejection.reason, "err", rejection.err)
+
+	commitsRejected.WithLabelValues(rejection.reason).Inc()
+
+	paths := make([]string, 0, len(evt.Ops))
+	for _, op := range evt.Ops {
+		paths = append(paths, op.Path)
+	}
+
+	timestamp := timestamppb.Now()
+	if parsedTime, err := time.Parse(time.RFC3339Nano, evt.Time); err == nil {
+		timestamp = timestamppb.New(parsedTime)
+	}
+
+	rejected := &vyletkafka.RejectedCommit{
+		Did:       evt.Repo,
+		Timestamp: timestamp,
+		Sequence:  evt.Seq,
+		Rev:       evt.Rev,
+		Reason:    rejection.reason,
+		Error:     rejection.err.Error(),
+		Paths:     paths,
+		Blocks:    evt.Blocks,
+	}
+
+	if err := kf.rejectedProducer.ProduceAsync(ctx, evt.Repo, rejected, func(r *kgo.Record, err error) {
+		if err != nil {
+			logger.Error("error after producing rejected commit async", "err", err)
+		}
+	}); err != nil {
+		logger.Error("failed to produce rejected commit async", "err", err)
+	}
+}
+
+// newVerificationDirectory builds the cached identity directory used to resolve repo signing keys
+func newVerificationDirectory(plcHost string) identity.Directory {
+	baseDirectory := identity.BaseDirectory{
+		PLCURL: plcHost,
+		HTTPClient: http.Client{
+			Timeout: time.Second * 5,
+		},
+		TryAuthoritativeDNS:   false,
+		SkipDNSDomainSuffixes: []string{".bsky.social", ".staging.bsky.dev"},
+	}
+	directory := identity.NewCacheDirectory(&baseDirectory, 250_000, time.Hour*48, time.Minute*15, time.Minute*15)
+	return &directory
+}
@@ -0,0 +1,144 @@
+// Package headers names the Kafka record headers the firehose stamps on every event, so that consumers can route or
+// filter records without unmarshalling the protobuf payload.
+package headers
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+)
+
+const (
+	// Kind is one of commit, identity, account, sync or info
+	Kind = "kind"
+	// Did is the repo the event belongs to, empty on info events
+	Did = "did"
+	// Sequence is the upstream cursor of the event, a relay sequence or a jetstream time_us, and -1 on events that
+	// were not read from an upstream
+	Sequence = "seq"
+	// Collection is set on commits only
+	Collection = "collection"
+	// Operation is set on commits only, one of create, update or delete
+	Operation = "operation"
+	// HasBlobs is set to true on commits whose record references at least one blob
+	HasBlobs = "has-blobs"
+	// Backfill is set to true on the creates emitted for records read from a whole repo by the backfill stage
+	Backfill = "backfill"
+)
+
+const (
+	KindCommit   = "commit"
+	KindIdentity = "identity"
+	KindAccount  = "account"
+	KindSync     = "sync"
+	KindInfo     = "info"
+)
+
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Get returns the value of the first header with the given key
+func Get(r *kgo.Record, key string) (string, bool) {
+	for _, h := range r.Headers {
+		if h.Key == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+// ForEvent builds the headers stamped on every record produced for an event
+func ForEvent(evt *vyletkafka.FirehoseEvent, seq int64, hasBlobs bool) []kgo.RecordHeader {
+	hdrs := []kgo.RecordHeader{
+		{Key: Did, Value: []byte(evt.Did)},
+		{Key: Sequence, Value: []byte(strconv.FormatInt(seq, 10))},
+	}
+
+	switch {
+	case evt.Commit != nil:
+		var operation string
+		switch evt.Commit.Operation {
+		case vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE:
+			operation = OperationCreate
+		case vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE:
+			operation = OperationUpdate
+		case vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE:
+			operation = OperationDelete
+		}
+
+		hdrs = append(hdrs,
+			kgo.RecordHeader{Key: Kind, Value: []byte(KindCommit)},
+			kgo.RecordHeader{Key: Collection, Value: []byte(evt.Commit.Collection)},
+			kgo.RecordHeader{Key: Operation, Value: []byte(operation)},
+			kgo.RecordHeader{Key: HasBlobs, Value: []byte(strconv.FormatBool(hasBlobs))},
+		)
+	case evt.Identity != nil:
+		hdrs = append(hdrs, kgo.RecordHeader{Key: Kind, Value: []byte(KindIdentity)})
+	case evt.Account != nil:
+		hdrs = append(hdrs, kgo.RecordHeader{Key: Kind, Value: []byte(KindAccount)})
+	case evt.Sync != nil:
+		hdrs = append(hdrs, kgo.RecordHeader{Key: Kind, Value: []byte(KindSync)})
+	case evt.Info != nil:
+		hdrs = append(hdrs, kgo.RecordHeader{Key: Kind, Value: []byte(KindInfo)})
+	}
+
+	return hdrs
+}
+
+// Filter describes the records a consumer handles, so that it can skip the rest by their headers without decoding
+// them. Records missing a header the filter looks at, such as those produced before headers were stamped, are never
+// skipped.
+type Filter struct {
+	// Kinds are the kinds of events handled, all of them if empty
+	Kinds []string
+	// Collections are the collections of the commits handled, all of them if empty
+	Collections []string
+	// Operations are the operations of the commits handled, all of them if empty
+	Operations []string
+	// BlobsOnly skips creates and updates whose record references no blob
+	BlobsOnly bool
+	// SkipBackfill skips the creates emitted by the backfill stage
+	SkipBackfill bool
+}
+
+// Skip reports whether the consumer has no use for the record
+func (f *Filter) Skip(r *kgo.Record) bool {
+	if f.SkipBackfill {
+		if v, ok := Get(r, Backfill); ok && v == "true" {
+			return true
+		}
+	}
+
+	kind, ok := Get(r, Kind)
+	if !ok {
+		return false
+	}
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, kind) {
+		return true
+	}
+	if kind != KindCommit {
+		return false
+	}
+
+	if v, ok := Get(r, Collection); ok && len(f.Collections) > 0 && !slices.Contains(f.Collections, v) {
+		return true
+	}
+
+	operation, ok := Get(r, Operation)
+	if ok && len(f.Operations) > 0 && !slices.Contains(f.Operations, operation) {
+		return true
+	}
+
+	if f.BlobsOnly && ok && operation != OperationDelete {
+		if v, ok := Get(r, HasBlobs); ok && v == "false" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,153 @@
+package lag
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+)
+
+// Exporter reports how far a consumer group is behind on a topic: the offset lag of every partition, read from the
+// group's committed offsets, and the age of the last event the consumer processed
+type Exporter struct {
+	logger *slog.Logger
+
+	client   *kgo.Client
+	admin    *kadm.Client
+	topic    string
+	group    string
+	interval time.Duration
+
+	lk        sync.Mutex
+	lastEvent time.Time
+
+	// partitions are those with an offset_lag series, deleted once the partition is no longer reported
+	partitions map[int32]struct{}
+}
+
+type Args struct {
+	Logger *slog.Logger
+
+	BootstrapServers []string
+	Topic            string
+	ConsumerGroup    string
+	// Interval is how often the offset lag is read, defaulting to 15 seconds
+	Interval time.Duration
+}
+
+func New(args *Args) (*Exporter, error) {
+	if args.Logger == nil {
+		args.Logger = slog.Default()
+	}
+
+	if args.Interval <= 0 {
+		args.Interval = 15 * time.Second
+	}
+
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(args.BootstrapServers...),
+		kgo.ClientID("vylet-lag-exporter"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+
+	return &Exporter{
+		logger: args.Logger.With("component", "lag-exporter", "topic", args.Topic, "group", args.ConsumerGroup),
+
+		client:   client,
+		admin:    kadm.NewClient(client),
+		topic:    args.Topic,
+		group:    args.ConsumerGroup,
+		interval: args.Interval,
+
+		partitions: make(map[int32]struct{}),
+	}, nil
+}
+
+// Observe records evt as the last event processed, unless a newer one was already observed, since events from several
+// partitions are processed concurrently. The reported age keeps growing between events, so a stalled consumer shows
+// up as well as a slow one.
+func (e *Exporter) Observe(evt *vyletkafka.FirehoseEvent) {
+	if evt.Timestamp == nil {
+		return
+	}
+
+	e.lk.Lock()
+	defer e.lk.Unlock()
+
+	if ts := evt.Timestamp.AsTime(); ts.After(e.lastEvent) {
+		e.lastEvent = ts
+	}
+	eventAge.WithLabelValues(e.topic, e.group).Set(time.Since(e.lastEvent).Seconds())
+}
+
+// Run reports the lag every interval until ctx is cancelled
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := e.report(ctx); err != nil {
+			e.logger.Error("failed to report consumer lag", "err", err)
+			lagErrors.WithLabelValues(e.topic, e.group).Inc()
+		}
+	}
+}
+
+func (e *Exporter) report(ctx context.Context) error {
+	e.lk.Lock()
+	if !e.lastEvent.IsZero() {
+		eventAge.WithLabelValues(e.topic, e.group).Set(time.Since(e.lastEvent).Seconds())
+	}
+	e.lk.Unlock()
+
+	lags, err := e.admin.Lag(ctx, e.group)
+	if err != nil {
+		return fmt.Errorf("failed to fetch group lag: %w", err)
+	}
+
+	groupLag, ok := lags[e.group]
+	if !ok {
+		return fmt.Errorf("group %s not found", e.group)
+	}
+	if err := groupLag.Error(); err != nil {
+		return fmt.Errorf("failed to describe group: %w", err)
+	}
+
+	reported := make(map[int32]struct{})
+	for partition, l := range groupLag.Lag[e.topic] {
+		// -1 when the partition has no commit yet or its end offset could not be listed
+		if l.Lag < 0 {
+			continue
+		}
+		offsetLag.WithLabelValues(e.topic, e.group, strconv.Itoa(int(partition))).Set(float64(l.Lag))
+		reported[partition] = struct{}{}
+	}
+
+	// partitions that were revoked from the group or lost their commit would otherwise keep their last lag forever
+	for partition := range e.partitions {
+		if _, ok := reported[partition]; !ok {
+			offsetLag.DeleteLabelValues(e.topic, e.group, strconv.Itoa(int(partition)))
+		}
+	}
+	e.partitions = reported
+
+	return nil
+}
+
+func (e *Exporter) Close() {
+	e.client.Close()
+}
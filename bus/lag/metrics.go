@@ -0,0 +1,27 @@
+package lag
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace = "consumer"
+)
+
+var (
+	offsetLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "offset_lag",
+	}, []string{"topic", "group", "partition"})
+
+	eventAge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "event_age_seconds",
+	}, []string{"topic", "group"})
+
+	lagErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lag_errors",
+	}, []string{"topic", "group"})
+)
@@ -75,13 +75,176 @@ func (CommitOperation) EnumDescriptor() ([]byte, []int) {
 	return file_vylet_kafka_proto_rawDescGZIP(), []int{0}
 }
 
+type DeadLetterPayload int32
+
+const (
+	DeadLetterPayload_DEAD_LETTER_PAYLOAD_UNSPECIFIED DeadLetterPayload = 0
+	// a serialized FirehoseEvent, which can be re-driven into the main topic
+	DeadLetterPayload_DEAD_LETTER_PAYLOAD_FIREHOSE_EVENT DeadLetterPayload = 1
+	// the raw DAG-CBOR bytes of a record
+	DeadLetterPayload_DEAD_LETTER_PAYLOAD_RECORD_CBOR DeadLetterPayload = 2
+	// the CAR slice of a commit as received from the relay
+	DeadLetterPayload_DEAD_LETTER_PAYLOAD_COMMIT_CAR DeadLetterPayload = 3
+	// the JSON of a record as received from jetstream
+	DeadLetterPayload_DEAD_LETTER_PAYLOAD_RECORD_JSON DeadLetterPayload = 4
+)
+
+// Enum value maps for DeadLetterPayload.
+var (
+	DeadLetterPayload_name = map[int32]string{
+		0: "DEAD_LETTER_PAYLOAD_UNSPECIFIED",
+		1: "DEAD_LETTER_PAYLOAD_FIREHOSE_EVENT",
+		2: "DEAD_LETTER_PAYLOAD_RECORD_CBOR",
+		3: "DEAD_LETTER_PAYLOAD_COMMIT_CAR",
+		4: "DEAD_LETTER_PAYLOAD_RECORD_JSON",
+	}
+	DeadLetterPayload_value = map[string]int32{
+		"DEAD_LETTER_PAYLOAD_UNSPECIFIED":    0,
+		"DEAD_LETTER_PAYLOAD_FIREHOSE_EVENT": 1,
+		"DEAD_LETTER_PAYLOAD_RECORD_CBOR":    2,
+		"DEAD_LETTER_PAYLOAD_COMMIT_CAR":     3,
+		"DEAD_LETTER_PAYLOAD_RECORD_JSON":    4,
+	}
+)
+
+func (x DeadLetterPayload) Enum() *DeadLetterPayload {
+	p := new(DeadLetterPayload)
+	*p = x
+	return p
+}
+
+func (x DeadLetterPayload) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeadLetterPayload) Descriptor() protoreflect.EnumDescriptor {
+	return file_vylet_kafka_proto_enumTypes[1].Descriptor()
+}
+
+func (DeadLetterPayload) Type() protoreflect.EnumType {
+	return &file_vylet_kafka_proto_enumTypes[1]
+}
+
+func (x DeadLetterPayload) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeadLetterPayload.Descriptor instead.
+func (DeadLetterPayload) EnumDescriptor() ([]byte, []int) {
+	return file_vylet_kafka_proto_rawDescGZIP(), []int{1}
+}
+
+type CursorSource int32
+
+const (
+	// cursors saved before sources were tracked, treated as relay sequences
+	CursorSource_CURSOR_SOURCE_UNSPECIFIED CursorSource = 0
+	CursorSource_CURSOR_SOURCE_RELAY       CursorSource = 1
+	CursorSource_CURSOR_SOURCE_JETSTREAM   CursorSource = 2
+)
+
+// Enum value maps for CursorSource.
+var (
+	CursorSource_name = map[int32]string{
+		0: "CURSOR_SOURCE_UNSPECIFIED",
+		1: "CURSOR_SOURCE_RELAY",
+		2: "CURSOR_SOURCE_JETSTREAM",
+	}
+	CursorSource_value = map[string]int32{
+		"CURSOR_SOURCE_UNSPECIFIED": 0,
+		"CURSOR_SOURCE_RELAY":       1,
+		"CURSOR_SOURCE_JETSTREAM":   2,
+	}
+)
+
+func (x CursorSource) Enum() *CursorSource {
+	p := new(CursorSource)
+	*p = x
+	return p
+}
+
+func (x CursorSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CursorSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_vylet_kafka_proto_enumTypes[2].Descriptor()
+}
+
+func (CursorSource) Type() protoreflect.EnumType {
+	return &file_vylet_kafka_proto_enumTypes[2]
+}
+
+func (x CursorSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CursorSource.Descriptor instead.
+func (CursorSource) EnumDescriptor() ([]byte, []int) {
+	return file_vylet_kafka_proto_rawDescGZIP(), []int{2}
+}
+
+type BackfillState int32
+
+const (
+	BackfillState_BACKFILL_STATE_UNSPECIFIED BackfillState = 0
+	BackfillState_BACKFILL_STATE_COMPLETE    BackfillState = 1
+	BackfillState_BACKFILL_STATE_FAILED      BackfillState = 2
+)
+
+// Enum value maps for BackfillState.
+var (
+	BackfillState_name = map[int32]string{
+		0: "BACKFILL_STATE_UNSPECIFIED",
+		1: "BACKFILL_STATE_COMPLETE",
+		2: "BACKFILL_STATE_FAILED",
+	}
+	BackfillState_value = map[string]int32{
+		"BACKFILL_STATE_UNSPECIFIED": 0,
+		"BACKFILL_STATE_COMPLETE":    1,
+		"BACKFILL_STATE_FAILED":      2,
+	}
+)
+
+func (x BackfillState) Enum() *BackfillState {
+	p := new(BackfillState)
+	*p = x
+	return p
+}
+
+func (x BackfillState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BackfillState) Descriptor() protoreflect.EnumDescriptor {
+	return file_vylet_kafka_proto_enumTypes[3].Descriptor()
+}
+
+func (BackfillState) Type() protoreflect.EnumType {
+	return &file_vylet_kafka_proto_enumTypes[3]
+}
+
+func (x BackfillState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BackfillState.Descriptor instead.
+func (BackfillState) EnumDescriptor() ([]byte, []int) {
+	return file_vylet_kafka_proto_rawDescGZIP(), []int{3}
+}
+
 type FirehoseEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Did           string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Commit        *Commit                `protobuf:"bytes,3,opt,name=commit,proto3,oneof" json:"commit,omitempty"`
-	Account       []byte                 `protobuf:"bytes,4,opt,name=account,proto3,oneof" json:"account,omitempty"`
-	Identity      []byte                 `protobuf:"bytes,5,opt,name=identity,proto3,oneof" json:"identity,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty for info events, which are about the upstream connection rather than any single repo
+	Did       string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Commit    *Commit                `protobuf:"bytes,3,opt,name=commit,proto3,oneof" json:"commit,omitempty"`
+	Account   []byte                 `protobuf:"bytes,4,opt,name=account,proto3,oneof" json:"account,omitempty"`
+	Identity  []byte                 `protobuf:"bytes,5,opt,name=identity,proto3,oneof" json:"identity,omitempty"`
+	// the upstream's #sync event as JSON, telling consumers that their derived state for the repo may be stale
+	Sync []byte `protobuf:"bytes,6,opt,name=sync,proto3,oneof" json:"sync,omitempty"`
+	// the upstream's #info event as JSON
+	Info          []byte `protobuf:"bytes,7,opt,name=info,proto3,oneof" json:"info,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -151,14 +314,42 @@ func (x *FirehoseEvent) GetIdentity() []byte {
 	return nil
 }
 
+func (x *FirehoseEvent) GetSync() []byte {
+	if x != nil {
+		return x.Sync
+	}
+	return nil
+}
+
+func (x *FirehoseEvent) GetInfo() []byte {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
 type Commit struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Rev           string                 `protobuf:"bytes,1,opt,name=rev,proto3" json:"rev,omitempty"`
-	Operation     CommitOperation        `protobuf:"varint,2,opt,name=operation,proto3,enum=vyletkafka.CommitOperation" json:"operation,omitempty"`
-	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
-	Rkey          string                 `protobuf:"bytes,4,opt,name=rkey,proto3" json:"rkey,omitempty"`
-	Record        []byte                 `protobuf:"bytes,5,opt,name=record,proto3" json:"record,omitempty"` // json.RawMessage as opaque bytes
-	Cid           string                 `protobuf:"bytes,6,opt,name=cid,proto3" json:"cid,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Rev        string                 `protobuf:"bytes,1,opt,name=rev,proto3" json:"rev,omitempty"`
+	Operation  CommitOperation        `protobuf:"varint,2,opt,name=operation,proto3,enum=vyletkafka.CommitOperation" json:"operation,omitempty"`
+	Collection string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	Rkey       string                 `protobuf:"bytes,4,opt,name=rkey,proto3" json:"rkey,omitempty"`
+	Record     []byte                 `protobuf:"bytes,5,opt,name=record,proto3" json:"record,omitempty"` // json.RawMessage as opaque bytes, superseded by typed_record and record_cbor
+	Cid        string                 `protobuf:"bytes,6,opt,name=cid,proto3" json:"cid,omitempty"`
+	// the record's DAG-CBOR bytes exactly as signed, set for creates and updates read from a relay
+	RecordCbor []byte `protobuf:"bytes,7,opt,name=record_cbor,json=recordCbor,proto3" json:"record_cbor,omitempty"`
+	// the record decoded into its lexicon type, set for known app.vylet.* collections. Consumers should prefer this
+	// over record, which is kept while consumers are rolled over.
+	//
+	// Types that are valid to be assigned to TypedRecord:
+	//
+	//	*Commit_FeedPost
+	//	*Commit_FeedLike
+	//	*Commit_FeedComment
+	//	*Commit_GraphFollow
+	//	*Commit_ActorProfile
+	//	*Commit_GraphBlock
+	TypedRecord   isCommit_TypedRecord `protobuf_oneof:"typed_record"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -235,17 +426,357 @@ func (x *Commit) GetCid() string {
 	return ""
 }
 
-type SequenceCursor struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
-	SavedOnExit   bool                   `protobuf:"varint,2,opt,name=saved_on_exit,json=savedOnExit,proto3" json:"saved_on_exit,omitempty"`
+func (x *Commit) GetRecordCbor() []byte {
+	if x != nil {
+		return x.RecordCbor
+	}
+	return nil
+}
+
+func (x *Commit) GetTypedRecord() isCommit_TypedRecord {
+	if x != nil {
+		return x.TypedRecord
+	}
+	return nil
+}
+
+func (x *Commit) GetFeedPost() *FeedPost {
+	if x != nil {
+		if x, ok := x.TypedRecord.(*Commit_FeedPost); ok {
+			return x.FeedPost
+		}
+	}
+	return nil
+}
+
+func (x *Commit) GetFeedLike() *FeedLike {
+	if x != nil {
+		if x, ok := x.TypedRecord.(*Commit_FeedLike); ok {
+			return x.FeedLike
+		}
+	}
+	return nil
+}
+
+func (x *Commit) GetFeedComment() *FeedComment {
+	if x != nil {
+		if x, ok := x.TypedRecord.(*Commit_FeedComment); ok {
+			return x.FeedComment
+		}
+	}
+	return nil
+}
+
+func (x *Commit) GetGraphFollow() *GraphFollow {
+	if x != nil {
+		if x, ok := x.TypedRecord.(*Commit_GraphFollow); ok {
+			return x.GraphFollow
+		}
+	}
+	return nil
+}
+
+func (x *Commit) GetActorProfile() *ActorProfile {
+	if x != nil {
+		if x, ok := x.TypedRecord.(*Commit_ActorProfile); ok {
+			return x.ActorProfile
+		}
+	}
+	return nil
+}
+
+func (x *Commit) GetGraphBlock() *GraphBlock {
+	if x != nil {
+		if x, ok := x.TypedRecord.(*Commit_GraphBlock); ok {
+			return x.GraphBlock
+		}
+	}
+	return nil
+}
+
+type isCommit_TypedRecord interface {
+	isCommit_TypedRecord()
+}
+
+type Commit_FeedPost struct {
+	FeedPost *FeedPost `protobuf:"bytes,8,opt,name=feed_post,json=feedPost,proto3,oneof"`
+}
+
+type Commit_FeedLike struct {
+	FeedLike *FeedLike `protobuf:"bytes,9,opt,name=feed_like,json=feedLike,proto3,oneof"`
+}
+
+type Commit_FeedComment struct {
+	FeedComment *FeedComment `protobuf:"bytes,10,opt,name=feed_comment,json=feedComment,proto3,oneof"`
+}
+
+type Commit_GraphFollow struct {
+	GraphFollow *GraphFollow `protobuf:"bytes,11,opt,name=graph_follow,json=graphFollow,proto3,oneof"`
+}
+
+type Commit_ActorProfile struct {
+	ActorProfile *ActorProfile `protobuf:"bytes,12,opt,name=actor_profile,json=actorProfile,proto3,oneof"`
+}
+
+type Commit_GraphBlock struct {
+	GraphBlock *GraphBlock `protobuf:"bytes,13,opt,name=graph_block,json=graphBlock,proto3,oneof"`
+}
+
+func (*Commit_FeedPost) isCommit_TypedRecord() {}
+
+func (*Commit_FeedLike) isCommit_TypedRecord() {}
+
+func (*Commit_FeedComment) isCommit_TypedRecord() {}
+
+func (*Commit_GraphFollow) isCommit_TypedRecord() {}
+
+func (*Commit_ActorProfile) isCommit_TypedRecord() {}
+
+func (*Commit_GraphBlock) isCommit_TypedRecord() {}
+
+type RejectedCommit struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Did       string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Sequence  int64                  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Rev       string                 `protobuf:"bytes,4,opt,name=rev,proto3" json:"rev,omitempty"`
+	// one of identity, signature or proof
+	Reason        string   `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Error         string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Paths         []string `protobuf:"bytes,7,rep,name=paths,proto3" json:"paths,omitempty"`
+	Blocks        []byte   `protobuf:"bytes,8,opt,name=blocks,proto3" json:"blocks,omitempty"` // the commit's CAR slice as received from the relay
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectedCommit) Reset() {
+	*x = RejectedCommit{}
+	mi := &file_vylet_kafka_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectedCommit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedCommit) ProtoMessage() {}
+
+func (x *RejectedCommit) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_kafka_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedCommit.ProtoReflect.Descriptor instead.
+func (*RejectedCommit) Descriptor() ([]byte, []int) {
+	return file_vylet_kafka_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RejectedCommit) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *RejectedCommit) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *RejectedCommit) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *RejectedCommit) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+func (x *RejectedCommit) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RejectedCommit) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RejectedCommit) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *RejectedCommit) GetBlocks() []byte {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type DeadLetter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the main topic the event was bound for or read from, entries live in <topic>-dlq
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// firehose or indexer
+	Stage      string                 `protobuf:"bytes,2,opt,name=stage,proto3" json:"stage,omitempty"`
+	Reason     string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Collection string                 `protobuf:"bytes,4,opt,name=collection,proto3" json:"collection,omitempty"`
+	Did        string                 `protobuf:"bytes,5,opt,name=did,proto3" json:"did,omitempty"`
+	Rkey       string                 `protobuf:"bytes,6,opt,name=rkey,proto3" json:"rkey,omitempty"`
+	Rev        string                 `protobuf:"bytes,7,opt,name=rev,proto3" json:"rev,omitempty"`
+	FailedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	// empty when the failure happened before a consumer group was involved
+	ConsumerGroup string            `protobuf:"bytes,9,opt,name=consumer_group,json=consumerGroup,proto3" json:"consumer_group,omitempty"`
+	PayloadType   DeadLetterPayload `protobuf:"varint,10,opt,name=payload_type,json=payloadType,proto3,enum=vyletkafka.DeadLetterPayload" json:"payload_type,omitempty"`
+	Payload       []byte            `protobuf:"bytes,11,opt,name=payload,proto3" json:"payload,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_vylet_kafka_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_kafka_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_vylet_kafka_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeadLetter) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetRkey() string {
+	if x != nil {
+		return x.Rkey
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetConsumerGroup() string {
+	if x != nil {
+		return x.ConsumerGroup
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetPayloadType() DeadLetterPayload {
+	if x != nil {
+		return x.PayloadType
+	}
+	return DeadLetterPayload_DEAD_LETTER_PAYLOAD_UNSPECIFIED
+}
+
+func (x *DeadLetter) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type SequenceCursor struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Sequence    int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	SavedOnExit bool                   `protobuf:"varint,2,opt,name=saved_on_exit,json=savedOnExit,proto3" json:"saved_on_exit,omitempty"`
+	// the input the sequence belongs to. relay sequences and jetstream microsecond timestamps are not interchangeable
+	Source CursorSource `protobuf:"varint,3,opt,name=source,proto3,enum=vyletkafka.CursorSource" json:"source,omitempty"`
+	// the sequence of every upstream connection keyed by host, sequence above mirrors the first upstream
+	UpstreamSequences map[string]int64 `protobuf:"bytes,4,rep,name=upstream_sequences,json=upstreamSequences,proto3" json:"upstream_sequences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
 func (x *SequenceCursor) Reset() {
 	*x = SequenceCursor{}
-	mi := &file_vylet_kafka_proto_msgTypes[2]
+	mi := &file_vylet_kafka_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -257,7 +788,7 @@ func (x *SequenceCursor) String() string {
 func (*SequenceCursor) ProtoMessage() {}
 
 func (x *SequenceCursor) ProtoReflect() protoreflect.Message {
-	mi := &file_vylet_kafka_proto_msgTypes[2]
+	mi := &file_vylet_kafka_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -270,7 +801,7 @@ func (x *SequenceCursor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SequenceCursor.ProtoReflect.Descriptor instead.
 func (*SequenceCursor) Descriptor() ([]byte, []int) {
-	return file_vylet_kafka_proto_rawDescGZIP(), []int{2}
+	return file_vylet_kafka_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SequenceCursor) GetSequence() int64 {
@@ -287,22 +818,317 @@ func (x *SequenceCursor) GetSavedOnExit() bool {
 	return false
 }
 
+func (x *SequenceCursor) GetSource() CursorSource {
+	if x != nil {
+		return x.Source
+	}
+	return CursorSource_CURSOR_SOURCE_UNSPECIFIED
+}
+
+func (x *SequenceCursor) GetUpstreamSequences() map[string]int64 {
+	if x != nil {
+		return x.UpstreamSequences
+	}
+	return nil
+}
+
+// BlobEvent is emitted by the cdn bus stage for every blob referenced by a created or updated record, and once for
+// every deleted record, so that blob consumers don't need to parse records themselves
+type BlobEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Did   string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	// empty for deletes, as the deleted record's blobs are no longer known
+	Cid      string `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	MimeType string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Size     int64  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// at:// uri of the record referencing the blob
+	Uri           string                 `protobuf:"bytes,5,opt,name=uri,proto3" json:"uri,omitempty"`
+	Operation     CommitOperation        `protobuf:"varint,6,opt,name=operation,proto3,enum=vyletkafka.CommitOperation" json:"operation,omitempty"`
+	Collection    string                 `protobuf:"bytes,7,opt,name=collection,proto3" json:"collection,omitempty"`
+	Rev           string                 `protobuf:"bytes,8,opt,name=rev,proto3" json:"rev,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlobEvent) Reset() {
+	*x = BlobEvent{}
+	mi := &file_vylet_kafka_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlobEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobEvent) ProtoMessage() {}
+
+func (x *BlobEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_kafka_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobEvent.ProtoReflect.Descriptor instead.
+func (*BlobEvent) Descriptor() ([]byte, []int) {
+	return file_vylet_kafka_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BlobEvent) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *BlobEvent) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *BlobEvent) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *BlobEvent) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BlobEvent) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *BlobEvent) GetOperation() CommitOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return CommitOperation_COMMIT_OPERATION_UNSPECIFIED
+}
+
+func (x *BlobEvent) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *BlobEvent) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+func (x *BlobEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// CapturedEvent is a produced firehose event as written to a capture segment
+type CapturedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the upstream cursor of the event, a relay sequence or a jetstream time_us
+	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Upstream      string                 `protobuf:"bytes,2,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	CapturedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+	HasBlobs      bool                   `protobuf:"varint,4,opt,name=has_blobs,json=hasBlobs,proto3" json:"has_blobs,omitempty"`
+	Event         *FirehoseEvent         `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturedEvent) Reset() {
+	*x = CapturedEvent{}
+	mi := &file_vylet_kafka_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturedEvent) ProtoMessage() {}
+
+func (x *CapturedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_kafka_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturedEvent.ProtoReflect.Descriptor instead.
+func (*CapturedEvent) Descriptor() ([]byte, []int) {
+	return file_vylet_kafka_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CapturedEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *CapturedEvent) GetUpstream() string {
+	if x != nil {
+		return x.Upstream
+	}
+	return ""
+}
+
+func (x *CapturedEvent) GetCapturedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return nil
+}
+
+func (x *CapturedEvent) GetHasBlobs() bool {
+	if x != nil {
+		return x.HasBlobs
+	}
+	return false
+}
+
+func (x *CapturedEvent) GetEvent() *FirehoseEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// BackfillProgress is the latest backfill state of a repo, keyed by did on the backfill progress topic
+type BackfillProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Did   string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	State BackfillState          `protobuf:"varint,2,opt,name=state,proto3,enum=vyletkafka.BackfillState" json:"state,omitempty"`
+	// rev of the repo the emitted records were read from
+	Rev     string `protobuf:"bytes,3,opt,name=rev,proto3" json:"rev,omitempty"`
+	Records int64  `protobuf:"varint,4,opt,name=records,proto3" json:"records,omitempty"`
+	// set when the backfill failed
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackfillProgress) Reset() {
+	*x = BackfillProgress{}
+	mi := &file_vylet_kafka_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackfillProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillProgress) ProtoMessage() {}
+
+func (x *BackfillProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_kafka_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillProgress.ProtoReflect.Descriptor instead.
+func (*BackfillProgress) Descriptor() ([]byte, []int) {
+	return file_vylet_kafka_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BackfillProgress) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *BackfillProgress) GetState() BackfillState {
+	if x != nil {
+		return x.State
+	}
+	return BackfillState_BACKFILL_STATE_UNSPECIFIED
+}
+
+func (x *BackfillProgress) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+func (x *BackfillProgress) GetRecords() int64 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+func (x *BackfillProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BackfillProgress) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
 var File_vylet_kafka_proto protoreflect.FileDescriptor
 
 const file_vylet_kafka_proto_rawDesc = "" +
 	"\n" +
 	"\x11vylet_kafka.proto\x12\n" +
-	"vyletkafka\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x80\x02\n" +
-	"\rFirehoseEvent\x12\x18\n" +
-	"\x03did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03did\x12@\n" +
+	"vyletkafka\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13vylet_records.proto\"\xbc\x02\n" +
+	"\rFirehoseEvent\x12\x10\n" +
+	"\x03did\x18\x01 \x01(\tR\x03did\x12@\n" +
 	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\ttimestamp\x12/\n" +
 	"\x06commit\x18\x03 \x01(\v2\x12.vyletkafka.CommitH\x00R\x06commit\x88\x01\x01\x12\x1d\n" +
 	"\aaccount\x18\x04 \x01(\fH\x01R\aaccount\x88\x01\x01\x12\x1f\n" +
-	"\bidentity\x18\x05 \x01(\fH\x02R\bidentity\x88\x01\x01B\t\n" +
+	"\bidentity\x18\x05 \x01(\fH\x02R\bidentity\x88\x01\x01\x12\x17\n" +
+	"\x04sync\x18\x06 \x01(\fH\x03R\x04sync\x88\x01\x01\x12\x17\n" +
+	"\x04info\x18\a \x01(\fH\x04R\x04info\x88\x01\x01B\t\n" +
 	"\a_commitB\n" +
 	"\n" +
 	"\b_accountB\v\n" +
-	"\t_identity\"\xb3\x01\n" +
+	"\t_identityB\a\n" +
+	"\x05_syncB\a\n" +
+	"\x05_info\"\xc6\x04\n" +
 	"\x06Commit\x12\x10\n" +
 	"\x03rev\x18\x01 \x01(\tR\x03rev\x129\n" +
 	"\toperation\x18\x02 \x01(\x0e2\x1b.vyletkafka.CommitOperationR\toperation\x12\x1e\n" +
@@ -311,15 +1137,97 @@ const file_vylet_kafka_proto_rawDesc = "" +
 	"collection\x12\x12\n" +
 	"\x04rkey\x18\x04 \x01(\tR\x04rkey\x12\x16\n" +
 	"\x06record\x18\x05 \x01(\fR\x06record\x12\x10\n" +
-	"\x03cid\x18\x06 \x01(\tR\x03cid\"P\n" +
+	"\x03cid\x18\x06 \x01(\tR\x03cid\x12\x1f\n" +
+	"\vrecord_cbor\x18\a \x01(\fR\n" +
+	"recordCbor\x123\n" +
+	"\tfeed_post\x18\b \x01(\v2\x14.vyletkafka.FeedPostH\x00R\bfeedPost\x123\n" +
+	"\tfeed_like\x18\t \x01(\v2\x14.vyletkafka.FeedLikeH\x00R\bfeedLike\x12<\n" +
+	"\ffeed_comment\x18\n" +
+	" \x01(\v2\x17.vyletkafka.FeedCommentH\x00R\vfeedComment\x12<\n" +
+	"\fgraph_follow\x18\v \x01(\v2\x17.vyletkafka.GraphFollowH\x00R\vgraphFollow\x12?\n" +
+	"\ractor_profile\x18\f \x01(\v2\x18.vyletkafka.ActorProfileH\x00R\factorProfile\x129\n" +
+	"\vgraph_block\x18\r \x01(\v2\x16.vyletkafka.GraphBlockH\x00R\n" +
+	"graphBlockB\x0e\n" +
+	"\ftyped_record\"\xe6\x01\n" +
+	"\x0eRejectedCommit\x12\x10\n" +
+	"\x03did\x18\x01 \x01(\tR\x03did\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x03R\bsequence\x12\x10\n" +
+	"\x03rev\x18\x04 \x01(\tR\x03rev\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x14\n" +
+	"\x05paths\x18\a \x03(\tR\x05paths\x12\x16\n" +
+	"\x06blocks\x18\b \x01(\fR\x06blocks\"\xe4\x02\n" +
+	"\n" +
+	"DeadLetter\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x14\n" +
+	"\x05stage\x18\x02 \x01(\tR\x05stage\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x04 \x01(\tR\n" +
+	"collection\x12\x10\n" +
+	"\x03did\x18\x05 \x01(\tR\x03did\x12\x12\n" +
+	"\x04rkey\x18\x06 \x01(\tR\x04rkey\x12\x10\n" +
+	"\x03rev\x18\a \x01(\tR\x03rev\x127\n" +
+	"\tfailed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\x12%\n" +
+	"\x0econsumer_group\x18\t \x01(\tR\rconsumerGroup\x12@\n" +
+	"\fpayload_type\x18\n" +
+	" \x01(\x0e2\x1d.vyletkafka.DeadLetterPayloadR\vpayloadType\x12\x18\n" +
+	"\apayload\x18\v \x01(\fR\apayload\"\xaa\x02\n" +
 	"\x0eSequenceCursor\x12\x1a\n" +
 	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12\"\n" +
-	"\rsaved_on_exit\x18\x02 \x01(\bR\vsavedOnExit*\x8a\x01\n" +
+	"\rsaved_on_exit\x18\x02 \x01(\bR\vsavedOnExit\x120\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x18.vyletkafka.CursorSourceR\x06source\x12`\n" +
+	"\x12upstream_sequences\x18\x04 \x03(\v21.vyletkafka.SequenceCursor.UpstreamSequencesEntryR\x11upstreamSequences\x1aD\n" +
+	"\x16UpstreamSequencesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x99\x02\n" +
+	"\tBlobEvent\x12\x10\n" +
+	"\x03did\x18\x01 \x01(\tR\x03did\x12\x10\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x10\n" +
+	"\x03uri\x18\x05 \x01(\tR\x03uri\x129\n" +
+	"\toperation\x18\x06 \x01(\x0e2\x1b.vyletkafka.CommitOperationR\toperation\x12\x1e\n" +
+	"\n" +
+	"collection\x18\a \x01(\tR\n" +
+	"collection\x12\x10\n" +
+	"\x03rev\x18\b \x01(\tR\x03rev\x128\n" +
+	"\ttimestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xd2\x01\n" +
+	"\rCapturedEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12\x1a\n" +
+	"\bupstream\x18\x02 \x01(\tR\bupstream\x12;\n" +
+	"\vcaptured_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"capturedAt\x12\x1b\n" +
+	"\thas_blobs\x18\x04 \x01(\bR\bhasBlobs\x12/\n" +
+	"\x05event\x18\x05 \x01(\v2\x19.vyletkafka.FirehoseEventR\x05event\"\xd2\x01\n" +
+	"\x10BackfillProgress\x12\x10\n" +
+	"\x03did\x18\x01 \x01(\tR\x03did\x12/\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x19.vyletkafka.BackfillStateR\x05state\x12\x10\n" +
+	"\x03rev\x18\x03 \x01(\tR\x03rev\x12\x18\n" +
+	"\arecords\x18\x04 \x01(\x03R\arecords\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt*\x8a\x01\n" +
 	"\x0fCommitOperation\x12 \n" +
 	"\x1cCOMMIT_OPERATION_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17COMMIT_OPERATION_CREATE\x10\x01\x12\x1b\n" +
 	"\x17COMMIT_OPERATION_UPDATE\x10\x02\x12\x1b\n" +
-	"\x17COMMIT_OPERATION_DELETE\x10\x03Bx\n" +
+	"\x17COMMIT_OPERATION_DELETE\x10\x03*\xce\x01\n" +
+	"\x11DeadLetterPayload\x12#\n" +
+	"\x1fDEAD_LETTER_PAYLOAD_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"DEAD_LETTER_PAYLOAD_FIREHOSE_EVENT\x10\x01\x12#\n" +
+	"\x1fDEAD_LETTER_PAYLOAD_RECORD_CBOR\x10\x02\x12\"\n" +
+	"\x1eDEAD_LETTER_PAYLOAD_COMMIT_CAR\x10\x03\x12#\n" +
+	"\x1fDEAD_LETTER_PAYLOAD_RECORD_JSON\x10\x04*c\n" +
+	"\fCursorSource\x12\x1d\n" +
+	"\x19CURSOR_SOURCE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CURSOR_SOURCE_RELAY\x10\x01\x12\x1b\n" +
+	"\x17CURSOR_SOURCE_JETSTREAM\x10\x02*g\n" +
+	"\rBackfillState\x12\x1e\n" +
+	"\x1aBACKFILL_STATE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17BACKFILL_STATE_COMPLETE\x10\x01\x12\x19\n" +
+	"\x15BACKFILL_STATE_FAILED\x10\x02Bx\n" +
 	"\x0ecom.vyletkafkaB\x0fVyletKafkaProtoP\x01Z\r./;vyletkafka\xa2\x02\x03VXX\xaa\x02\n" +
 	"Vyletkafka\xca\x02\n" +
 	"Vyletkafka\xe2\x02\x16Vyletkafka\\GPBMetadata\xea\x02\n" +
@@ -337,24 +1245,56 @@ func file_vylet_kafka_proto_rawDescGZIP() []byte {
 	return file_vylet_kafka_proto_rawDescData
 }
 
-var file_vylet_kafka_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_vylet_kafka_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_vylet_kafka_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_vylet_kafka_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_vylet_kafka_proto_goTypes = []any{
 	(CommitOperation)(0),          // 0: vyletkafka.CommitOperation
-	(*FirehoseEvent)(nil),         // 1: vyletkafka.FirehoseEvent
-	(*Commit)(nil),                // 2: vyletkafka.Commit
-	(*SequenceCursor)(nil),        // 3: vyletkafka.SequenceCursor
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(DeadLetterPayload)(0),        // 1: vyletkafka.DeadLetterPayload
+	(CursorSource)(0),             // 2: vyletkafka.CursorSource
+	(BackfillState)(0),            // 3: vyletkafka.BackfillState
+	(*FirehoseEvent)(nil),         // 4: vyletkafka.FirehoseEvent
+	(*Commit)(nil),                // 5: vyletkafka.Commit
+	(*RejectedCommit)(nil),        // 6: vyletkafka.RejectedCommit
+	(*DeadLetter)(nil),            // 7: vyletkafka.DeadLetter
+	(*SequenceCursor)(nil),        // 8: vyletkafka.SequenceCursor
+	(*BlobEvent)(nil),             // 9: vyletkafka.BlobEvent
+	(*CapturedEvent)(nil),         // 10: vyletkafka.CapturedEvent
+	(*BackfillProgress)(nil),      // 11: vyletkafka.BackfillProgress
+	nil,                           // 12: vyletkafka.SequenceCursor.UpstreamSequencesEntry
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+	(*FeedPost)(nil),              // 14: vyletkafka.FeedPost
+	(*FeedLike)(nil),              // 15: vyletkafka.FeedLike
+	(*FeedComment)(nil),           // 16: vyletkafka.FeedComment
+	(*GraphFollow)(nil),           // 17: vyletkafka.GraphFollow
+	(*ActorProfile)(nil),          // 18: vyletkafka.ActorProfile
+	(*GraphBlock)(nil),            // 19: vyletkafka.GraphBlock
 }
 var file_vylet_kafka_proto_depIdxs = []int32{
-	4, // 0: vyletkafka.FirehoseEvent.timestamp:type_name -> google.protobuf.Timestamp
-	2, // 1: vyletkafka.FirehoseEvent.commit:type_name -> vyletkafka.Commit
-	0, // 2: vyletkafka.Commit.operation:type_name -> vyletkafka.CommitOperation
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	13, // 0: vyletkafka.FirehoseEvent.timestamp:type_name -> google.protobuf.Timestamp
+	5,  // 1: vyletkafka.FirehoseEvent.commit:type_name -> vyletkafka.Commit
+	0,  // 2: vyletkafka.Commit.operation:type_name -> vyletkafka.CommitOperation
+	14, // 3: vyletkafka.Commit.feed_post:type_name -> vyletkafka.FeedPost
+	15, // 4: vyletkafka.Commit.feed_like:type_name -> vyletkafka.FeedLike
+	16, // 5: vyletkafka.Commit.feed_comment:type_name -> vyletkafka.FeedComment
+	17, // 6: vyletkafka.Commit.graph_follow:type_name -> vyletkafka.GraphFollow
+	18, // 7: vyletkafka.Commit.actor_profile:type_name -> vyletkafka.ActorProfile
+	19, // 8: vyletkafka.Commit.graph_block:type_name -> vyletkafka.GraphBlock
+	13, // 9: vyletkafka.RejectedCommit.timestamp:type_name -> google.protobuf.Timestamp
+	13, // 10: vyletkafka.DeadLetter.failed_at:type_name -> google.protobuf.Timestamp
+	1,  // 11: vyletkafka.DeadLetter.payload_type:type_name -> vyletkafka.DeadLetterPayload
+	2,  // 12: vyletkafka.SequenceCursor.source:type_name -> vyletkafka.CursorSource
+	12, // 13: vyletkafka.SequenceCursor.upstream_sequences:type_name -> vyletkafka.SequenceCursor.UpstreamSequencesEntry
+	0,  // 14: vyletkafka.BlobEvent.operation:type_name -> vyletkafka.CommitOperation
+	13, // 15: vyletkafka.BlobEvent.timestamp:type_name -> google.protobuf.Timestamp
+	13, // 16: vyletkafka.CapturedEvent.captured_at:type_name -> google.protobuf.Timestamp
+	4,  // 17: vyletkafka.CapturedEvent.event:type_name -> vyletkafka.FirehoseEvent
+	3,  // 18: vyletkafka.BackfillProgress.state:type_name -> vyletkafka.BackfillState
+	13, // 19: vyletkafka.BackfillProgress.updated_at:type_name -> google.protobuf.Timestamp
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_vylet_kafka_proto_init() }
@@ -362,14 +1302,23 @@ func file_vylet_kafka_proto_init() {
 	if File_vylet_kafka_proto != nil {
 		return
 	}
+	file_vylet_records_proto_init()
 	file_vylet_kafka_proto_msgTypes[0].OneofWrappers = []any{}
+	file_vylet_kafka_proto_msgTypes[1].OneofWrappers = []any{
+		(*Commit_FeedPost)(nil),
+		(*Commit_FeedLike)(nil),
+		(*Commit_FeedComment)(nil),
+		(*Commit_GraphFollow)(nil),
+		(*Commit_ActorProfile)(nil),
+		(*Commit_GraphBlock)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vylet_kafka_proto_rawDesc), len(file_vylet_kafka_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      4,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,937 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: vylet_records.proto
+
+package vyletkafka
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StrongRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StrongRef) Reset() {
+	*x = StrongRef{}
+	mi := &file_vylet_records_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StrongRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StrongRef) ProtoMessage() {}
+
+func (x *StrongRef) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StrongRef.ProtoReflect.Descriptor instead.
+func (*StrongRef) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StrongRef) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *StrongRef) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+type Blob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cid           string                 `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	MimeType      string                 `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Blob) Reset() {
+	*x = Blob{}
+	mi := &file_vylet_records_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Blob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Blob) ProtoMessage() {}
+
+func (x *Blob) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Blob.ProtoReflect.Descriptor instead.
+func (*Blob) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Blob) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *Blob) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Blob) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type AspectRatio struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Width         int64                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int64                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AspectRatio) Reset() {
+	*x = AspectRatio{}
+	mi := &file_vylet_records_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AspectRatio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AspectRatio) ProtoMessage() {}
+
+func (x *AspectRatio) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AspectRatio.ProtoReflect.Descriptor instead.
+func (*AspectRatio) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AspectRatio) GetWidth() int64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *AspectRatio) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type Image struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alt           string                 `protobuf:"bytes,1,opt,name=alt,proto3" json:"alt,omitempty"`
+	AspectRatio   *AspectRatio           `protobuf:"bytes,2,opt,name=aspect_ratio,json=aspectRatio,proto3,oneof" json:"aspect_ratio,omitempty"`
+	Image         *Blob                  `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Image) Reset() {
+	*x = Image{}
+	mi := &file_vylet_records_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Image) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Image) ProtoMessage() {}
+
+func (x *Image) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Image.ProtoReflect.Descriptor instead.
+func (*Image) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Image) GetAlt() string {
+	if x != nil {
+		return x.Alt
+	}
+	return ""
+}
+
+func (x *Image) GetAspectRatio() *AspectRatio {
+	if x != nil {
+		return x.AspectRatio
+	}
+	return nil
+}
+
+func (x *Image) GetImage() *Blob {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+type Facet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ByteStart     int64                  `protobuf:"varint,1,opt,name=byte_start,json=byteStart,proto3" json:"byte_start,omitempty"`
+	ByteEnd       int64                  `protobuf:"varint,2,opt,name=byte_end,json=byteEnd,proto3" json:"byte_end,omitempty"`
+	Features      []*FacetFeature        `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Facet) Reset() {
+	*x = Facet{}
+	mi := &file_vylet_records_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Facet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Facet) ProtoMessage() {}
+
+func (x *Facet) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Facet.ProtoReflect.Descriptor instead.
+func (*Facet) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Facet) GetByteStart() int64 {
+	if x != nil {
+		return x.ByteStart
+	}
+	return 0
+}
+
+func (x *Facet) GetByteEnd() int64 {
+	if x != nil {
+		return x.ByteEnd
+	}
+	return 0
+}
+
+func (x *Facet) GetFeatures() []*FacetFeature {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type FacetFeature struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Feature:
+	//
+	//	*FacetFeature_MentionDid
+	//	*FacetFeature_LinkUri
+	Feature       isFacetFeature_Feature `protobuf_oneof:"feature"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetFeature) Reset() {
+	*x = FacetFeature{}
+	mi := &file_vylet_records_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetFeature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetFeature) ProtoMessage() {}
+
+func (x *FacetFeature) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetFeature.ProtoReflect.Descriptor instead.
+func (*FacetFeature) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FacetFeature) GetFeature() isFacetFeature_Feature {
+	if x != nil {
+		return x.Feature
+	}
+	return nil
+}
+
+func (x *FacetFeature) GetMentionDid() string {
+	if x != nil {
+		if x, ok := x.Feature.(*FacetFeature_MentionDid); ok {
+			return x.MentionDid
+		}
+	}
+	return ""
+}
+
+func (x *FacetFeature) GetLinkUri() string {
+	if x != nil {
+		if x, ok := x.Feature.(*FacetFeature_LinkUri); ok {
+			return x.LinkUri
+		}
+	}
+	return ""
+}
+
+type isFacetFeature_Feature interface {
+	isFacetFeature_Feature()
+}
+
+type FacetFeature_MentionDid struct {
+	// app.vylet.richtext.facet#mention
+	MentionDid string `protobuf:"bytes,1,opt,name=mention_did,json=mentionDid,proto3,oneof"`
+}
+
+type FacetFeature_LinkUri struct {
+	// app.vylet.richtext.facet#link
+	LinkUri string `protobuf:"bytes,2,opt,name=link_uri,json=linkUri,proto3,oneof"`
+}
+
+func (*FacetFeature_MentionDid) isFacetFeature_Feature() {}
+
+func (*FacetFeature_LinkUri) isFacetFeature_Feature() {}
+
+// app.vylet.feed.post
+type FeedPost struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Caption   *string                `protobuf:"bytes,1,opt,name=caption,proto3,oneof" json:"caption,omitempty"`
+	CreatedAt string                 `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Facets    []*Facet               `protobuf:"bytes,3,rep,name=facets,proto3" json:"facets,omitempty"`
+	// values of com.atproto.label.defs#selfLabels
+	SelfLabels []string `protobuf:"bytes,4,rep,name=self_labels,json=selfLabels,proto3" json:"self_labels,omitempty"`
+	// app.vylet.media.images, the only supported media type
+	Images        []*Image `protobuf:"bytes,5,rep,name=images,proto3" json:"images,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedPost) Reset() {
+	*x = FeedPost{}
+	mi := &file_vylet_records_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedPost) ProtoMessage() {}
+
+func (x *FeedPost) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedPost.ProtoReflect.Descriptor instead.
+func (*FeedPost) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FeedPost) GetCaption() string {
+	if x != nil && x.Caption != nil {
+		return *x.Caption
+	}
+	return ""
+}
+
+func (x *FeedPost) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *FeedPost) GetFacets() []*Facet {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *FeedPost) GetSelfLabels() []string {
+	if x != nil {
+		return x.SelfLabels
+	}
+	return nil
+}
+
+func (x *FeedPost) GetImages() []*Image {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+// app.vylet.feed.like
+type FeedLike struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     string                 `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Subject       *StrongRef             `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedLike) Reset() {
+	*x = FeedLike{}
+	mi := &file_vylet_records_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedLike) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedLike) ProtoMessage() {}
+
+func (x *FeedLike) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedLike.ProtoReflect.Descriptor instead.
+func (*FeedLike) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FeedLike) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *FeedLike) GetSubject() *StrongRef {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+// app.vylet.feed.comment
+type FeedComment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     string                 `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Facets        []*Facet               `protobuf:"bytes,3,rep,name=facets,proto3" json:"facets,omitempty"`
+	Root          *StrongRef             `protobuf:"bytes,4,opt,name=root,proto3" json:"root,omitempty"`
+	Parent        *StrongRef             `protobuf:"bytes,5,opt,name=parent,proto3,oneof" json:"parent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedComment) Reset() {
+	*x = FeedComment{}
+	mi := &file_vylet_records_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedComment) ProtoMessage() {}
+
+func (x *FeedComment) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedComment.ProtoReflect.Descriptor instead.
+func (*FeedComment) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FeedComment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *FeedComment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *FeedComment) GetFacets() []*Facet {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *FeedComment) GetRoot() *StrongRef {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *FeedComment) GetParent() *StrongRef {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+// app.vylet.graph.follow
+type GraphFollow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     string                 `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphFollow) Reset() {
+	*x = GraphFollow{}
+	mi := &file_vylet_records_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphFollow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphFollow) ProtoMessage() {}
+
+func (x *GraphFollow) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphFollow.ProtoReflect.Descriptor instead.
+func (*GraphFollow) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GraphFollow) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GraphFollow) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+// app.vylet.graph.block
+type GraphBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     string                 `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphBlock) Reset() {
+	*x = GraphBlock{}
+	mi := &file_vylet_records_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphBlock) ProtoMessage() {}
+
+func (x *GraphBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphBlock.ProtoReflect.Descriptor instead.
+func (*GraphBlock) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GraphBlock) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GraphBlock) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+// app.vylet.actor.profile
+type ActorProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     string                 `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DisplayName   *string                `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3,oneof" json:"display_name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Pronouns      *string                `protobuf:"bytes,4,opt,name=pronouns,proto3,oneof" json:"pronouns,omitempty"`
+	Avatar        *Blob                  `protobuf:"bytes,5,opt,name=avatar,proto3,oneof" json:"avatar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActorProfile) Reset() {
+	*x = ActorProfile{}
+	mi := &file_vylet_records_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorProfile) ProtoMessage() {}
+
+func (x *ActorProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_vylet_records_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorProfile.ProtoReflect.Descriptor instead.
+func (*ActorProfile) Descriptor() ([]byte, []int) {
+	return file_vylet_records_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ActorProfile) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ActorProfile) GetDisplayName() string {
+	if x != nil && x.DisplayName != nil {
+		return *x.DisplayName
+	}
+	return ""
+}
+
+func (x *ActorProfile) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ActorProfile) GetPronouns() string {
+	if x != nil && x.Pronouns != nil {
+		return *x.Pronouns
+	}
+	return ""
+}
+
+func (x *ActorProfile) GetAvatar() *Blob {
+	if x != nil {
+		return x.Avatar
+	}
+	return nil
+}
+
+var File_vylet_records_proto protoreflect.FileDescriptor
+
+const file_vylet_records_proto_rawDesc = "" +
+	"\n" +
+	"\x13vylet_records.proto\x12\n" +
+	"vyletkafka\"/\n" +
+	"\tStrongRef\x12\x10\n" +
+	"\x03uri\x18\x01 \x01(\tR\x03uri\x12\x10\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\"I\n" +
+	"\x04Blob\x12\x10\n" +
+	"\x03cid\x18\x01 \x01(\tR\x03cid\x12\x1b\n" +
+	"\tmime_type\x18\x02 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\";\n" +
+	"\vAspectRatio\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x03R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x03R\x06height\"\x93\x01\n" +
+	"\x05Image\x12\x10\n" +
+	"\x03alt\x18\x01 \x01(\tR\x03alt\x12?\n" +
+	"\faspect_ratio\x18\x02 \x01(\v2\x17.vyletkafka.AspectRatioH\x00R\vaspectRatio\x88\x01\x01\x12&\n" +
+	"\x05image\x18\x03 \x01(\v2\x10.vyletkafka.BlobR\x05imageB\x0f\n" +
+	"\r_aspect_ratio\"w\n" +
+	"\x05Facet\x12\x1d\n" +
+	"\n" +
+	"byte_start\x18\x01 \x01(\x03R\tbyteStart\x12\x19\n" +
+	"\bbyte_end\x18\x02 \x01(\x03R\abyteEnd\x124\n" +
+	"\bfeatures\x18\x03 \x03(\v2\x18.vyletkafka.FacetFeatureR\bfeatures\"Y\n" +
+	"\fFacetFeature\x12!\n" +
+	"\vmention_did\x18\x01 \x01(\tH\x00R\n" +
+	"mentionDid\x12\x1b\n" +
+	"\blink_uri\x18\x02 \x01(\tH\x00R\alinkUriB\t\n" +
+	"\afeature\"\xcb\x01\n" +
+	"\bFeedPost\x12\x1d\n" +
+	"\acaption\x18\x01 \x01(\tH\x00R\acaption\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\tR\tcreatedAt\x12)\n" +
+	"\x06facets\x18\x03 \x03(\v2\x11.vyletkafka.FacetR\x06facets\x12\x1f\n" +
+	"\vself_labels\x18\x04 \x03(\tR\n" +
+	"selfLabels\x12)\n" +
+	"\x06images\x18\x05 \x03(\v2\x11.vyletkafka.ImageR\x06imagesB\n" +
+	"\n" +
+	"\b_caption\"Z\n" +
+	"\bFeedLike\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x01 \x01(\tR\tcreatedAt\x12/\n" +
+	"\asubject\x18\x02 \x01(\v2\x15.vyletkafka.StrongRefR\asubject\"\xd5\x01\n" +
+	"\vFeedComment\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x01 \x01(\tR\tcreatedAt\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12)\n" +
+	"\x06facets\x18\x03 \x03(\v2\x11.vyletkafka.FacetR\x06facets\x12)\n" +
+	"\x04root\x18\x04 \x01(\v2\x15.vyletkafka.StrongRefR\x04root\x122\n" +
+	"\x06parent\x18\x05 \x01(\v2\x15.vyletkafka.StrongRefH\x00R\x06parent\x88\x01\x01B\t\n" +
+	"\a_parent\"F\n" +
+	"\vGraphFollow\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x01 \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\"E\n" +
+	"\n" +
+	"GraphBlock\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x01 \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\"\x85\x02\n" +
+	"\fActorProfile\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x01 \x01(\tR\tcreatedAt\x12&\n" +
+	"\fdisplay_name\x18\x02 \x01(\tH\x00R\vdisplayName\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1f\n" +
+	"\bpronouns\x18\x04 \x01(\tH\x02R\bpronouns\x88\x01\x01\x12-\n" +
+	"\x06avatar\x18\x05 \x01(\v2\x10.vyletkafka.BlobH\x03R\x06avatar\x88\x01\x01B\x0f\n" +
+	"\r_display_nameB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_pronounsB\t\n" +
+	"\a_avatarBz\n" +
+	"\x0ecom.vyletkafkaB\x11VyletRecordsProtoP\x01Z\r./;vyletkafka\xa2\x02\x03VXX\xaa\x02\n" +
+	"Vyletkafka\xca\x02\n" +
+	"Vyletkafka\xe2\x02\x16Vyletkafka\\GPBMetadata\xea\x02\n" +
+	"Vyletkafkab\x06proto3"
+
+var (
+	file_vylet_records_proto_rawDescOnce sync.Once
+	file_vylet_records_proto_rawDescData []byte
+)
+
+func file_vylet_records_proto_rawDescGZIP() []byte {
+	file_vylet_records_proto_rawDescOnce.Do(func() {
+		file_vylet_records_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vylet_records_proto_rawDesc), len(file_vylet_records_proto_rawDesc)))
+	})
+	return file_vylet_records_proto_rawDescData
+}
+
+var file_vylet_records_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_vylet_records_proto_goTypes = []any{
+	(*StrongRef)(nil),    // 0: vyletkafka.StrongRef
+	(*Blob)(nil),         // 1: vyletkafka.Blob
+	(*AspectRatio)(nil),  // 2: vyletkafka.AspectRatio
+	(*Image)(nil),        // 3: vyletkafka.Image
+	(*Facet)(nil),        // 4: vyletkafka.Facet
+	(*FacetFeature)(nil), // 5: vyletkafka.FacetFeature
+	(*FeedPost)(nil),     // 6: vyletkafka.FeedPost
+	(*FeedLike)(nil),     // 7: vyletkafka.FeedLike
+	(*FeedComment)(nil),  // 8: vyletkafka.FeedComment
+	(*GraphFollow)(nil),  // 9: vyletkafka.GraphFollow
+	(*GraphBlock)(nil),   // 10: vyletkafka.GraphBlock
+	(*ActorProfile)(nil), // 11: vyletkafka.ActorProfile
+}
+var file_vylet_records_proto_depIdxs = []int32{
+	2,  // 0: vyletkafka.Image.aspect_ratio:type_name -> vyletkafka.AspectRatio
+	1,  // 1: vyletkafka.Image.image:type_name -> vyletkafka.Blob
+	5,  // 2: vyletkafka.Facet.features:type_name -> vyletkafka.FacetFeature
+	4,  // 3: vyletkafka.FeedPost.facets:type_name -> vyletkafka.Facet
+	3,  // 4: vyletkafka.FeedPost.images:type_name -> vyletkafka.Image
+	0,  // 5: vyletkafka.FeedLike.subject:type_name -> vyletkafka.StrongRef
+	4,  // 6: vyletkafka.FeedComment.facets:type_name -> vyletkafka.Facet
+	0,  // 7: vyletkafka.FeedComment.root:type_name -> vyletkafka.StrongRef
+	0,  // 8: vyletkafka.FeedComment.parent:type_name -> vyletkafka.StrongRef
+	1,  // 9: vyletkafka.ActorProfile.avatar:type_name -> vyletkafka.Blob
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_vylet_records_proto_init() }
+func file_vylet_records_proto_init() {
+	if File_vylet_records_proto != nil {
+		return
+	}
+	file_vylet_records_proto_msgTypes[3].OneofWrappers = []any{}
+	file_vylet_records_proto_msgTypes[5].OneofWrappers = []any{
+		(*FacetFeature_MentionDid)(nil),
+		(*FacetFeature_LinkUri)(nil),
+	}
+	file_vylet_records_proto_msgTypes[6].OneofWrappers = []any{}
+	file_vylet_records_proto_msgTypes[8].OneofWrappers = []any{}
+	file_vylet_records_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vylet_records_proto_rawDesc), len(file_vylet_records_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_vylet_records_proto_goTypes,
+		DependencyIndexes: file_vylet_records_proto_depIdxs,
+		MessageInfos:      file_vylet_records_proto_msgTypes,
+	}.Build()
+	File_vylet_records_proto = out.File
+	file_vylet_records_proto_goTypes = nil
+	file_vylet_records_proto_depIdxs = nil
+}
@@ -0,0 +1,295 @@
+package records
+
+import (
+	"fmt"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	lexutil "github.com/bluesky-social/indigo/lex/util"
+	"github.com/ipfs/go-cid"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/generated/vylet"
+)
+
+// feedPostToProto leaves images empty for posts without supported media, which consumers then reject as they would
+// the record itself
+func feedPostToProto(rec *vylet.FeedPost) *vyletkafka.FeedPost {
+	post := vyletkafka.FeedPost{
+		Caption:   rec.Caption,
+		CreatedAt: rec.CreatedAt,
+		Facets:    facetsToProto(rec.Facets),
+	}
+
+	if rec.Labels != nil && rec.Labels.LabelDefs_SelfLabels != nil {
+		for _, label := range rec.Labels.LabelDefs_SelfLabels.Values {
+			post.SelfLabels = append(post.SelfLabels, label.Val)
+		}
+	}
+
+	if rec.Media == nil || rec.Media.MediaImages == nil {
+		return &post
+	}
+
+	for _, img := range rec.Media.MediaImages.Images {
+		pimg := vyletkafka.Image{
+			Alt:   img.Alt,
+			Image: blobToProto(img.Image),
+		}
+		if img.AspectRatio != nil {
+			pimg.AspectRatio = &vyletkafka.AspectRatio{
+				Width:  img.AspectRatio.Width,
+				Height: img.AspectRatio.Height,
+			}
+		}
+		post.Images = append(post.Images, &pimg)
+	}
+
+	return &post
+}
+
+func feedPostFromProto(post *vyletkafka.FeedPost) (*vylet.FeedPost, error) {
+	rec := vylet.FeedPost{
+		LexiconTypeID: CollectionFeedPost,
+		Caption:       post.Caption,
+		CreatedAt:     post.CreatedAt,
+		Facets:        facetsFromProto(post.Facets),
+	}
+
+	if len(post.SelfLabels) > 0 {
+		labels := comatproto.LabelDefs_SelfLabels{
+			LexiconTypeID: "com.atproto.label.defs#selfLabels",
+		}
+		for _, val := range post.SelfLabels {
+			labels.Values = append(labels.Values, &comatproto.LabelDefs_SelfLabel{Val: val})
+		}
+		rec.Labels = &vylet.FeedPost_Labels{LabelDefs_SelfLabels: &labels}
+	}
+
+	images := vylet.MediaImages{
+		LexiconTypeID: "app.vylet.media.images",
+	}
+	for _, pimg := range post.Images {
+		blob, err := blobFromProto(pimg.Image)
+		if err != nil {
+			return nil, err
+		}
+
+		img := vylet.MediaImages_Image{
+			Alt:   pimg.Alt,
+			Image: blob,
+		}
+		if pimg.AspectRatio != nil {
+			img.AspectRatio = &vylet.MediaDefs_AspectRatio{
+				Width:  pimg.AspectRatio.Width,
+				Height: pimg.AspectRatio.Height,
+			}
+		}
+		images.Images = append(images.Images, &img)
+	}
+	rec.Media = &vylet.FeedPost_Media{MediaImages: &images}
+
+	return &rec, nil
+}
+
+func feedLikeToProto(rec *vylet.FeedLike) *vyletkafka.FeedLike {
+	return &vyletkafka.FeedLike{
+		CreatedAt: rec.CreatedAt,
+		Subject:   strongRefToProto(rec.Subject),
+	}
+}
+
+func feedLikeFromProto(like *vyletkafka.FeedLike) *vylet.FeedLike {
+	return &vylet.FeedLike{
+		LexiconTypeID: CollectionFeedLike,
+		CreatedAt:     like.CreatedAt,
+		Subject:       strongRefFromProto(like.Subject),
+	}
+}
+
+func feedCommentToProto(rec *vylet.FeedComment) *vyletkafka.FeedComment {
+	return &vyletkafka.FeedComment{
+		CreatedAt: rec.CreatedAt,
+		Text:      rec.Text,
+		Facets:    facetsToProto(rec.Facets),
+		Root:      strongRefToProto(rec.Root),
+		Parent:    strongRefToProto(rec.Parent),
+	}
+}
+
+func feedCommentFromProto(comment *vyletkafka.FeedComment) *vylet.FeedComment {
+	return &vylet.FeedComment{
+		LexiconTypeID: CollectionFeedComment,
+		CreatedAt:     comment.CreatedAt,
+		Text:          comment.Text,
+		Facets:        facetsFromProto(comment.Facets),
+		Root:          strongRefFromProto(comment.Root),
+		Parent:        strongRefFromProto(comment.Parent),
+	}
+}
+
+func graphFollowToProto(rec *vylet.GraphFollow) *vyletkafka.GraphFollow {
+	return &vyletkafka.GraphFollow{
+		CreatedAt: rec.CreatedAt,
+		Subject:   rec.Subject,
+	}
+}
+
+func graphFollowFromProto(follow *vyletkafka.GraphFollow) *vylet.GraphFollow {
+	return &vylet.GraphFollow{
+		LexiconTypeID: CollectionGraphFollow,
+		CreatedAt:     follow.CreatedAt,
+		Subject:       follow.Subject,
+	}
+}
+
+func graphBlockToProto(rec *vylet.GraphBlock) *vyletkafka.GraphBlock {
+	return &vyletkafka.GraphBlock{
+		CreatedAt: rec.CreatedAt,
+		Subject:   rec.Subject,
+	}
+}
+
+func graphBlockFromProto(block *vyletkafka.GraphBlock) *vylet.GraphBlock {
+	return &vylet.GraphBlock{
+		LexiconTypeID: CollectionGraphBlock,
+		CreatedAt:     block.CreatedAt,
+		Subject:       block.Subject,
+	}
+}
+
+func actorProfileToProto(rec *vylet.ActorProfile) *vyletkafka.ActorProfile {
+	return &vyletkafka.ActorProfile{
+		CreatedAt:   rec.CreatedAt,
+		DisplayName: rec.DisplayName,
+		Description: rec.Description,
+		Pronouns:    rec.Pronouns,
+		Avatar:      blobToProto(rec.Avatar),
+	}
+}
+
+func actorProfileFromProto(profile *vyletkafka.ActorProfile) (*vylet.ActorProfile, error) {
+	avatar, err := blobFromProto(profile.Avatar)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vylet.ActorProfile{
+		LexiconTypeID: CollectionActorProfile,
+		CreatedAt:     profile.CreatedAt,
+		DisplayName:   profile.DisplayName,
+		Description:   profile.Description,
+		Pronouns:      profile.Pronouns,
+		Avatar:        avatar,
+	}, nil
+}
+
+func blobToProto(blob *lexutil.LexBlob) *vyletkafka.Blob {
+	if blob == nil {
+		return nil
+	}
+
+	return &vyletkafka.Blob{
+		Cid:      blob.Ref.String(),
+		MimeType: blob.MimeType,
+		Size:     blob.Size,
+	}
+}
+
+func blobFromProto(blob *vyletkafka.Blob) (*lexutil.LexBlob, error) {
+	if blob == nil {
+		return nil, nil
+	}
+
+	c, err := cid.Decode(blob.Cid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse blob cid: %w", err)
+	}
+
+	return &lexutil.LexBlob{
+		Ref:      lexutil.LexLink(c),
+		MimeType: blob.MimeType,
+		Size:     blob.Size,
+	}, nil
+}
+
+func strongRefToProto(ref *comatproto.RepoStrongRef) *vyletkafka.StrongRef {
+	if ref == nil {
+		return nil
+	}
+
+	return &vyletkafka.StrongRef{
+		Uri: ref.Uri,
+		Cid: ref.Cid,
+	}
+}
+
+func strongRefFromProto(ref *vyletkafka.StrongRef) *comatproto.RepoStrongRef {
+	if ref == nil {
+		return nil
+	}
+
+	return &comatproto.RepoStrongRef{
+		Uri: ref.Uri,
+		Cid: ref.Cid,
+	}
+}
+
+// facetsToProto drops any feature type that the proto does not know about
+func facetsToProto(facets []*vylet.RichtextFacet) []*vyletkafka.Facet {
+	var out []*vyletkafka.Facet
+	for _, facet := range facets {
+		if facet == nil || facet.Index == nil {
+			continue
+		}
+
+		pfacet := vyletkafka.Facet{
+			ByteStart: facet.Index.ByteStart,
+			ByteEnd:   facet.Index.ByteEnd,
+		}
+		for _, feature := range facet.Features {
+			switch {
+			case feature.RichtextFacet_Mention != nil:
+				pfacet.Features = append(pfacet.Features, &vyletkafka.FacetFeature{
+					Feature: &vyletkafka.FacetFeature_MentionDid{MentionDid: feature.RichtextFacet_Mention.Did},
+				})
+			case feature.RichtextFacet_Link != nil:
+				pfacet.Features = append(pfacet.Features, &vyletkafka.FacetFeature{
+					Feature: &vyletkafka.FacetFeature_LinkUri{LinkUri: feature.RichtextFacet_Link.Uri},
+				})
+			}
+		}
+		out = append(out, &pfacet)
+	}
+	return out
+}
+
+func facetsFromProto(facets []*vyletkafka.Facet) []*vylet.RichtextFacet {
+	var out []*vylet.RichtextFacet
+	for _, pfacet := range facets {
+		facet := vylet.RichtextFacet{
+			Index: &vylet.RichtextFacet_ByteSlice{
+				ByteStart: pfacet.ByteStart,
+				ByteEnd:   pfacet.ByteEnd,
+			},
+		}
+		for _, feature := range pfacet.Features {
+			switch f := feature.Feature.(type) {
+			case *vyletkafka.FacetFeature_MentionDid:
+				facet.Features = append(facet.Features, &vylet.RichtextFacet_Features_Elem{
+					RichtextFacet_Mention: &vylet.RichtextFacet_Mention{
+						LexiconTypeID: "app.vylet.richtext.facet#mention",
+						Did:           f.MentionDid,
+					},
+				})
+			case *vyletkafka.FacetFeature_LinkUri:
+				facet.Features = append(facet.Features, &vylet.RichtextFacet_Features_Elem{
+					RichtextFacet_Link: &vylet.RichtextFacet_Link{
+						LexiconTypeID: "app.vylet.richtext.facet#link",
+						Uri:           f.LinkUri,
+					},
+				})
+			}
+		}
+		out = append(out, &facet)
+	}
+	return out
+}
@@ -0,0 +1,192 @@
+// Package records converts app.vylet.* records between their lexicon types and the typed payloads carried on the bus
+package records
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bluesky-social/indigo/atproto/atdata"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/generated/vylet"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+const (
+	CollectionFeedPost     = "app.vylet.feed.post"
+	CollectionFeedLike     = "app.vylet.feed.like"
+	CollectionFeedComment  = "app.vylet.feed.comment"
+	CollectionGraphFollow  = "app.vylet.graph.follow"
+	CollectionGraphBlock   = "app.vylet.graph.block"
+	CollectionActorProfile = "app.vylet.actor.profile"
+)
+
+// newRecord returns an empty lexicon record for the collection, or nil if the collection has no typed payload
+func newRecord(collection string) cbg.CBORUnmarshaler {
+	switch collection {
+	case CollectionFeedPost:
+		return &vylet.FeedPost{}
+	case CollectionFeedLike:
+		return &vylet.FeedLike{}
+	case CollectionFeedComment:
+		return &vylet.FeedComment{}
+	case CollectionGraphFollow:
+		return &vylet.GraphFollow{}
+	case CollectionGraphBlock:
+		return &vylet.GraphBlock{}
+	case CollectionActorProfile:
+		return &vylet.ActorProfile{}
+	}
+	return nil
+}
+
+// SetFromCBOR decodes the record's DAG-CBOR bytes into the typed payload for the commit's collection. Commits for
+// collections without a typed payload are left untouched.
+func SetFromCBOR(commit *vyletkafka.Commit, b []byte) error {
+	rec := newRecord(commit.Collection)
+	if rec == nil {
+		return nil
+	}
+
+	if err := rec.UnmarshalCBOR(bytes.NewReader(b)); err != nil {
+		return fmt.Errorf("failed to unmarshal %s record: %w", commit.Collection, err)
+	}
+
+	setTyped(commit, rec)
+	return nil
+}
+
+// SetFromJSON decodes the record's JSON into the typed payload for the commit's collection. Commits for collections
+// without a typed payload are left untouched.
+func SetFromJSON(commit *vyletkafka.Commit, b []byte) error {
+	rec := newRecord(commit.Collection)
+	if rec == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(b, rec); err != nil {
+		return fmt.Errorf("failed to unmarshal %s record: %w", commit.Collection, err)
+	}
+
+	setTyped(commit, rec)
+	return nil
+}
+
+func setTyped(commit *vyletkafka.Commit, rec any) {
+	switch rec := rec.(type) {
+	case *vylet.FeedPost:
+		commit.TypedRecord = &vyletkafka.Commit_FeedPost{FeedPost: feedPostToProto(rec)}
+	case *vylet.FeedLike:
+		commit.TypedRecord = &vyletkafka.Commit_FeedLike{FeedLike: feedLikeToProto(rec)}
+	case *vylet.FeedComment:
+		commit.TypedRecord = &vyletkafka.Commit_FeedComment{FeedComment: feedCommentToProto(rec)}
+	case *vylet.GraphFollow:
+		commit.TypedRecord = &vyletkafka.Commit_GraphFollow{GraphFollow: graphFollowToProto(rec)}
+	case *vylet.GraphBlock:
+		commit.TypedRecord = &vyletkafka.Commit_GraphBlock{GraphBlock: graphBlockToProto(rec)}
+	case *vylet.ActorProfile:
+		commit.TypedRecord = &vyletkafka.Commit_ActorProfile{ActorProfile: actorProfileToProto(rec)}
+	}
+}
+
+// FeedPost returns the commit's post record, preferring the typed payload and falling back to the JSON record for
+// events produced before typed payloads were introduced
+func FeedPost(commit *vyletkafka.Commit) (*vylet.FeedPost, error) {
+	if typed := commit.GetFeedPost(); typed != nil {
+		return feedPostFromProto(typed)
+	}
+
+	var rec vylet.FeedPost
+	if err := json.Unmarshal(commit.Record, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// FeedLike returns the commit's like record, preferring the typed payload over the JSON record
+func FeedLike(commit *vyletkafka.Commit) (*vylet.FeedLike, error) {
+	if typed := commit.GetFeedLike(); typed != nil {
+		return feedLikeFromProto(typed), nil
+	}
+
+	var rec vylet.FeedLike
+	if err := json.Unmarshal(commit.Record, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// FeedComment returns the commit's comment record, preferring the typed payload over the JSON record
+func FeedComment(commit *vyletkafka.Commit) (*vylet.FeedComment, error) {
+	if typed := commit.GetFeedComment(); typed != nil {
+		return feedCommentFromProto(typed), nil
+	}
+
+	var rec vylet.FeedComment
+	if err := json.Unmarshal(commit.Record, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// GraphFollow returns the commit's follow record, preferring the typed payload over the JSON record
+func GraphFollow(commit *vyletkafka.Commit) (*vylet.GraphFollow, error) {
+	if typed := commit.GetGraphFollow(); typed != nil {
+		return graphFollowFromProto(typed), nil
+	}
+
+	var rec vylet.GraphFollow
+	if err := json.Unmarshal(commit.Record, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// GraphBlock returns the commit's block record, preferring the typed payload over the JSON record
+func GraphBlock(commit *vyletkafka.Commit) (*vylet.GraphBlock, error) {
+	if typed := commit.GetGraphBlock(); typed != nil {
+		return graphBlockFromProto(typed), nil
+	}
+
+	var rec vylet.GraphBlock
+	if err := json.Unmarshal(commit.Record, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// ActorProfile returns the commit's profile record, preferring the typed payload over the JSON record
+func ActorProfile(commit *vyletkafka.Commit) (*vylet.ActorProfile, error) {
+	if typed := commit.GetActorProfile(); typed != nil {
+		return actorProfileFromProto(typed)
+	}
+
+	var rec vylet.ActorProfile
+	if err := json.Unmarshal(commit.Record, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// Data returns the commit's record in the generic data model, preferring the signed DAG-CBOR bytes over the JSON
+// record
+func Data(commit *vyletkafka.Commit) (map[string]any, error) {
+	if len(commit.RecordCbor) > 0 {
+		return atdata.UnmarshalCBOR(commit.RecordCbor)
+	}
+	return atdata.UnmarshalJSON(commit.Record)
+}
+
+// HasBlobs reports whether a commit's record references any blobs
+func HasBlobs(commit *vyletkafka.Commit) bool {
+	if commit == nil || (len(commit.Record) == 0 && len(commit.RecordCbor) == 0) {
+		return false
+	}
+
+	rec, err := Data(commit)
+	if err != nil {
+		return false
+	}
+
+	return len(atdata.ExtractBlobs(rec)) > 0
+}
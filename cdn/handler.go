@@ -6,11 +6,14 @@ import (
 
 	"github.com/bluesky-social/indigo/atproto/atdata"
 	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
 	vyletdatabase "github.com/vylet-app/go/database/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) handleEvent(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
+	defer s.lag.Observe(evt)
+
 	if evt.Commit != nil {
 		return s.handleCommit(ctx, evt)
 	}
@@ -27,9 +30,9 @@ func (s *Server) handleCommit(ctx context.Context, evt *vyletkafka.FirehoseEvent
 		// Track record processing
 		recordsProcessed.WithLabelValues(op.Operation.String()).Inc()
 
-		rec, err := atdata.UnmarshalJSON(op.Record)
+		rec, err := records.Data(op)
 		if err != nil {
-			logger.Error("failed to unmarshal record JSON", "err", err)
+			logger.Error("failed to unmarshal record", "err", err)
 			return nil // Don't fail the event processing, just skip blob extraction
 		}
 
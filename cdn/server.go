@@ -9,15 +9,17 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/bluesky-social/go-util/pkg/bus/consumer"
-	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/consume"
+	"github.com/vylet-app/go/bus/headers"
+	"github.com/vylet-app/go/bus/lag"
 	"github.com/vylet-app/go/database/client"
 )
 
 type Server struct {
 	logger *slog.Logger
 
-	consumer *consumer.Consumer[*vyletkafka.FirehoseEvent]
+	consumer *consume.Consumer
+	lag      *lag.Exporter
 	db       *client.Client
 }
 
@@ -45,20 +47,37 @@ func New(args *Args) (*Server, error) {
 		return nil, fmt.Errorf("failed to create a new database client: %w", err)
 	}
 
+	lagExporter, err := lag.New(&lag.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lag exporter: %w", err)
+	}
+
 	server := Server{
 		logger: logger,
 
-		db: db,
+		lag: lagExporter,
+		db:  db,
 	}
 
-	busConsumer, err := consumer.New(
-		logger.With("component", "consumer"),
-		args.BootstrapServers,
-		args.InputTopic,
-		args.ConsumerGroup,
-		consumer.WithOffset[*vyletkafka.FirehoseEvent](consumer.OffsetStart),
-		consumer.WithMessageHandler(server.handleEvent),
-	)
+	busConsumer, err := consume.New(&consume.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+		ClientID:         "vylet-cdn",
+		// only the blobs of created and updated records are tracked
+		Filter: &headers.Filter{
+			Kinds:      []string{headers.KindCommit},
+			Operations: []string{headers.OperationCreate, headers.OperationUpdate},
+			BlobsOnly:  true,
+		},
+		Handler: server.handleEvent,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new consumer: %w", err)
 	}
@@ -70,6 +89,11 @@ func New(args *Args) (*Server, error) {
 func (s *Server) Run(ctx context.Context) error {
 	logger := s.logger.With("name", "Run")
 
+	ctx, cancelLag := context.WithCancel(ctx)
+	defer cancelLag()
+
+	go s.lag.Run(ctx)
+
 	shutdownConsumer := make(chan struct{}, 1)
 	consumerShutdown := make(chan struct{}, 1)
 	consumerErr := make(chan error, 1)
@@ -109,6 +133,7 @@ func (s *Server) Run(ctx context.Context) error {
 	defer cancel()
 
 	s.consumer.Close()
+	s.lag.Close()
 
 	if err := s.db.Close(); err != nil {
 		logger.Error("failed to close database client", "err", err)
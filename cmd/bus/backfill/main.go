@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/bluesky-social/go-util/pkg/telemetry"
+	"github.com/urfave/cli/v2"
+	kafkabackfill "github.com/vylet-app/go/bus/backfill"
+)
+
+func main() {
+	app := cli.App{
+		Name:  "kafka-backfill",
+		Usage: "emit create events for the records of repos that existed before the firehose cursor",
+		Flags: []cli.Flag{
+			telemetry.CLIFlagDebug,
+			telemetry.CLIFlagMetricsListenAddress,
+			&cli.StringSliceFlag{
+				Name:    "bootstrap-servers",
+				EnvVars: []string{"VYLET_BACKFILL_BOOTSTRAP_SERVERS", "VYLET_BOOTSTRAP_SERVERS", "BOOTSTRAP_SERVERS"},
+				Value:   cli.NewStringSlice("localhost:9092"),
+			},
+			&cli.StringFlag{
+				Name:    "input-topic",
+				Usage:   "live firehose topic to forward to the output topic, holding back repos until they are backfilled",
+				EnvVars: []string{"VYLET_BACKFILL_INPUT_TOPIC"},
+			},
+			&cli.StringFlag{
+				Name:    "consumer-group",
+				Usage:   "consumer group for the input topic, required with --input-topic",
+				EnvVars: []string{"VYLET_BACKFILL_CONSUMER_GROUP"},
+			},
+			&cli.StringFlag{
+				Name:    "output-topic",
+				EnvVars: []string{"VYLET_BACKFILL_OUTPUT_TOPIC"},
+				Value:   "firehose-events-backfill",
+			},
+			&cli.StringFlag{
+				Name:    "relay-host",
+				Usage:   "relay to enumerate repos from with com.atproto.sync.listReposByCollection",
+				EnvVars: []string{"VYLET_BACKFILL_RELAY_HOST"},
+				Value:   "https://bsky.network",
+			},
+			&cli.StringFlag{
+				Name:    "plc-host",
+				EnvVars: []string{"VYLET_BACKFILL_PLC_HOST", "PLC_HOST"},
+				Value:   "https://plc.directory",
+			},
+			&cli.StringFlag{
+				Name:    "pds-host",
+				Usage:   "fetch every repo from this pds instead of resolving it from the did, for local setups",
+				EnvVars: []string{"VYLET_BACKFILL_PDS_HOST"},
+			},
+			&cli.StringSliceFlag{
+				Name:    "collection",
+				Usage:   "collection to backfill, may be given multiple times, defaults to every app.vylet collection",
+				EnvVars: []string{"VYLET_BACKFILL_COLLECTIONS"},
+			},
+			&cli.IntFlag{
+				Name:    "workers",
+				Usage:   "number of repos fetched concurrently",
+				EnvVars: []string{"VYLET_BACKFILL_WORKERS"},
+				Value:   8,
+			},
+			&cli.IntFlag{
+				Name:    "max-buffered-events",
+				Usage:   "live events held back per queued repo before its backfill fails until the next run",
+				EnvVars: []string{"VYLET_BACKFILL_MAX_BUFFERED_EVENTS"},
+				Value:   10_000,
+			},
+		},
+		Action: run,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(cmd *cli.Context) error {
+	ctx := context.Background()
+
+	logger := telemetry.StartLogger(cmd)
+	telemetry.StartMetrics(cmd)
+
+	kb, err := kafkabackfill.New(ctx, &kafkabackfill.Args{
+		Logger:            logger,
+		BootstrapServers:  cmd.StringSlice("bootstrap-servers"),
+		InputTopic:        cmd.String("input-topic"),
+		ConsumerGroup:     cmd.String("consumer-group"),
+		OutputTopic:       cmd.String("output-topic"),
+		RelayHost:         cmd.String("relay-host"),
+		PLCHost:           cmd.String("plc-host"),
+		PDSHost:           cmd.String("pds-host"),
+		Collections:       cmd.StringSlice("collection"),
+		Workers:           cmd.Int("workers"),
+		MaxBufferedEvents: cmd.Int("max-buffered-events"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create new kafka backfill: %w", err)
+	}
+
+	if err := kb.Run(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,15 +1,43 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
 
+	"github.com/bluesky-social/go-util/pkg/telemetry"
 	"github.com/urfave/cli/v2"
+	kafkacdn "github.com/vylet-app/go/bus/cdn"
 )
 
 func main() {
 	app := cli.App{
-		Name:   "kafka-cdn",
+		Name: "kafka-cdn",
+		Flags: []cli.Flag{
+			telemetry.CLIFlagDebug,
+			telemetry.CLIFlagMetricsListenAddress,
+			&cli.StringSliceFlag{
+				Name:    "bootstrap-servers",
+				EnvVars: []string{"VYLET_KAFKA_CDN_BOOTSTRAP_SERVERS", "VYLET_BOOTSTRAP_SERVERS", "BOOTSTRAP_SERVERS"},
+				Value:   cli.NewStringSlice("localhost:9092"),
+			},
+			&cli.StringFlag{
+				Name:    "input-topic",
+				EnvVars: []string{"VYLET_KAFKA_CDN_INPUT_TOPIC"},
+				Value:   "firehose-events-prod",
+			},
+			&cli.StringFlag{
+				Name:     "consumer-group",
+				Required: true,
+				EnvVars:  []string{"VYLET_KAFKA_CDN_CONSUMER_GROUP"},
+			},
+			&cli.StringFlag{
+				Name:    "output-topic",
+				EnvVars: []string{"VYLET_KAFKA_CDN_OUTPUT_TOPIC"},
+				Value:   "blob-events-prod",
+			},
+		},
 		Action: run,
 	}
 
@@ -19,5 +47,25 @@ func main() {
 }
 
 func run(cmd *cli.Context) error {
+	ctx := context.Background()
+
+	logger := telemetry.StartLogger(cmd)
+	telemetry.StartMetrics(cmd)
+
+	kc, err := kafkacdn.New(ctx, &kafkacdn.Args{
+		Logger:           logger,
+		BootstrapServers: cmd.StringSlice("bootstrap-servers"),
+		InputTopic:       cmd.String("input-topic"),
+		ConsumerGroup:    cmd.String("consumer-group"),
+		OutputTopic:      cmd.String("output-topic"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create new kafka cdn: %w", err)
+	}
+
+	if err := kc.Run(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bluesky-social/go-util/pkg/bus/producer"
+	"github.com/bluesky-social/go-util/pkg/telemetry"
+	"github.com/urfave/cli/v2"
+	"github.com/vylet-app/go/bus/deadletter"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+func main() {
+	filterFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  "stage",
+			Usage: "only include entries from this stage (firehose, indexer, cdn or notifier)",
+		},
+		&cli.StringFlag{
+			Name:  "collection",
+			Usage: "only include entries for this collection",
+		},
+		&cli.StringFlag{
+			Name:  "did",
+			Usage: "only include entries for this repo",
+		},
+	}
+
+	app := cli.App{
+		Name:  "kafka-dlq",
+		Usage: "inspect and re-drive dead-lettered firehose events",
+		Flags: []cli.Flag{
+			telemetry.CLIFlagDebug,
+			&cli.StringSliceFlag{
+				Name:    "bootstrap-servers",
+				EnvVars: []string{"VYLET_DLQ_BOOTSTRAP_SERVERS", "BOOTSTRAP_SERVERS"},
+				Value:   cli.NewStringSlice("localhost:9092"),
+			},
+			&cli.StringFlag{
+				Name:    "topic",
+				Usage:   "the main topic, entries are read from <topic>-dlq",
+				EnvVars: []string{"VYLET_DLQ_TOPIC"},
+				Value:   "firehose-events-prod",
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "list entries in the dead-letter topic",
+				Flags:  filterFlags,
+				Action: list,
+			},
+			{
+				Name:  "redrive",
+				Usage: "produce selected entries back into the main topic",
+				Flags: append([]cli.Flag{
+					&cli.Int64SliceFlag{
+						Name:  "offset",
+						Usage: "dead-letter offset to re-drive, may be given multiple times",
+					},
+					&cli.BoolFlag{
+						Name:  "all",
+						Usage: "re-drive every entry that matches the filters",
+					},
+				}, filterFlags...),
+				Action: redrive,
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func listEntries(cmd *cli.Context) ([]*deadletter.Entry, error) {
+	entries, err := deadletter.List(cmd.Context, cmd.StringSlice("bootstrap-servers"), cmd.String("topic"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	stage := cmd.String("stage")
+	collection := cmd.String("collection")
+	did := cmd.String("did")
+
+	return slices.DeleteFunc(entries, func(e *deadletter.Entry) bool {
+		return (stage != "" && e.Letter.Stage != stage) ||
+			(collection != "" && e.Letter.Collection != collection) ||
+			(did != "" && e.Letter.Did != did)
+	}), nil
+}
+
+func list(cmd *cli.Context) error {
+	entries, err := listEntries(cmd)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OFFSET\tFAILED AT\tSTAGE\tGROUP\tDID\tCOLLECTION\tRKEY\tPAYLOAD\tREASON")
+	for _, e := range entries {
+		l := e.Letter
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Offset,
+			l.FailedAt.AsTime().Format(time.RFC3339),
+			l.Stage,
+			l.ConsumerGroup,
+			l.Did,
+			l.Collection,
+			l.Rkey,
+			l.PayloadType,
+			l.Reason,
+		)
+	}
+
+	return w.Flush()
+}
+
+func redrive(cmd *cli.Context) error {
+	ctx := context.Background()
+
+	logger := telemetry.StartLogger(cmd)
+
+	offsets := cmd.Int64Slice("offset")
+	if len(offsets) == 0 && !cmd.Bool("all") {
+		return fmt.Errorf("either --offset or --all must be set")
+	}
+
+	entries, err := listEntries(cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(offsets) > 0 {
+		entries = slices.DeleteFunc(entries, func(e *deadletter.Entry) bool {
+			return !slices.Contains(offsets, e.Offset)
+		})
+	}
+
+	busProducer, err := producer.New[*vyletkafka.FirehoseEvent](
+		ctx,
+		logger.With("component", "producer"),
+		cmd.StringSlice("bootstrap-servers"),
+		cmd.String("topic"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+	defer busProducer.Close()
+
+	redriven := 0
+	for _, e := range entries {
+		logger := logger.With("offset", e.Offset, "did", e.Letter.Did, "collection", e.Letter.Collection)
+
+		// only consumer failures carry the original event, firehose failures need to be recovered upstream
+		if e.Letter.PayloadType != vyletkafka.DeadLetterPayload_DEAD_LETTER_PAYLOAD_FIREHOSE_EVENT {
+			logger.Warn("entry does not carry a firehose event, skipping", "payload", e.Letter.PayloadType)
+			continue
+		}
+
+		var evt vyletkafka.FirehoseEvent
+		if err := proto.Unmarshal(e.Letter.Payload, &evt); err != nil {
+			logger.Error("failed to unmarshal firehose event, skipping", "err", err)
+			continue
+		}
+
+		if err := busProducer.ProduceSync(ctx, evt.Did, &evt); err != nil {
+			return fmt.Errorf("failed to re-drive entry at offset %d: %w", e.Offset, err)
+		}
+
+		logger.Info("re-drove entry")
+		redriven++
+	}
+
+	fmt.Printf("re-drove %d of %d selected entries into %s\n", redriven, len(entries), cmd.String("topic"))
+
+	return nil
+}
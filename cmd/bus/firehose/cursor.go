@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	kafkafirehose "github.com/vylet-app/go/bus/firehose"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+)
+
+var cursorCommand = &cli.Command{
+	Name:  "cursor",
+	Usage: "inspect or rewind the cursor saved in <output-topic>-cursor",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "show",
+			Usage: "show the most recently saved cursors",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:  "limit",
+					Usage: "number of saved cursors to show",
+					Value: 10,
+				},
+			},
+			Action: showCursor,
+		},
+		{
+			Name:  "set",
+			Usage: "save a cursor for the next firehose to start from, refusing while a firehose is running",
+			Flags: []cli.Flag{
+				&cli.Int64Flag{
+					Name:  "sequence",
+					Usage: "relay sequence, or jetstream time_us, to start from",
+				},
+				&cli.TimestampFlag{
+					Name:   "time",
+					Usage:  "approximate time to start from, as an RFC 3339 timestamp",
+					Layout: time.RFC3339,
+				},
+				&cli.StringSliceFlag{
+					Name:  "upstream",
+					Usage: "relay to set the cursor for, required when fanning in from several relays",
+				},
+			},
+			Action: setCursor,
+		},
+	},
+}
+
+func showCursor(cmd *cli.Context) error {
+	ctx := cmd.Context
+	bootstrapServers := cmd.StringSlice("bootstrap-servers")
+	outputTopic := cmd.String("output-topic")
+
+	cursors, err := kafkafirehose.ListCursors(ctx, bootstrapServers, outputTopic, cmd.Int("limit"))
+	if err != nil {
+		return err
+	}
+
+	holders, err := kafkafirehose.ActiveCursorHolders(ctx, bootstrapServers, outputTopic)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OFFSET\tSAVED AT\tFINAL\tSOURCE\tSEQUENCE\tUPSTREAMS")
+	for _, c := range cursors {
+		fmt.Fprintf(w, "%d\t%s\t%t\t%s\t%d\t%s\n",
+			c.Offset,
+			c.SavedAt.Format(time.RFC3339),
+			c.Cursor.SavedOnExit,
+			c.Cursor.Source,
+			c.Cursor.Sequence,
+			formatUpstreams(c.Cursor.UpstreamSequences),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if latest := latestCursor(cursors, false); latest != nil {
+		fmt.Printf("latest saved cursor: offset %d at %s\n", latest.Offset, latest.SavedAt.Format(time.RFC3339))
+	} else {
+		fmt.Println("no saved cursor")
+	}
+	if latest := latestCursor(cursors, true); latest != nil {
+		fmt.Printf("latest final cursor: offset %d at %s\n", latest.Offset, latest.SavedAt.Format(time.RFC3339))
+	} else {
+		fmt.Println("no final cursor among those shown")
+	}
+	if len(holders) > 0 {
+		fmt.Printf("a firehose is running, held by %s\n", strings.Join(holders, ", "))
+	} else {
+		fmt.Println("no firehose is running")
+	}
+
+	return nil
+}
+
+func setCursor(cmd *cli.Context) error {
+	ctx := cmd.Context
+	bootstrapServers := cmd.StringSlice("bootstrap-servers")
+	outputTopic := cmd.String("output-topic")
+	inputMode := kafkafirehose.InputMode(cmd.String("input-mode"))
+
+	if cmd.IsSet("sequence") == cmd.IsSet("time") {
+		return fmt.Errorf("exactly one of --sequence or --time must be set")
+	}
+
+	// a running firehose would overwrite the cursor within seconds, or worse, keep producing past it
+	holders, err := kafkafirehose.ActiveCursorHolders(ctx, bootstrapServers, outputTopic)
+	if err != nil {
+		return err
+	}
+	if len(holders) > 0 {
+		return fmt.Errorf("refusing to set the cursor while a firehose is running, held by %s", strings.Join(holders, ", "))
+	}
+
+	var source vyletkafka.CursorSource
+	var hosts []string
+	switch inputMode {
+	case kafkafirehose.InputModeRelay:
+		source = vyletkafka.CursorSource_CURSOR_SOURCE_RELAY
+		hosts = cmd.StringSlice("websocket-host")
+	case kafkafirehose.InputModeJetstream:
+		source = vyletkafka.CursorSource_CURSOR_SOURCE_JETSTREAM
+		hosts = []string{cmd.String("jetstream-host")}
+	default:
+		return fmt.Errorf("unknown input mode %q", inputMode)
+	}
+
+	targets := cmd.StringSlice("upstream")
+	if len(targets) == 0 {
+		if len(hosts) > 1 {
+			return fmt.Errorf("sequences differ between relays, pass --upstream for each relay to set")
+		}
+		targets = hosts
+	}
+	for _, target := range targets {
+		if !slices.Contains(hosts, target) {
+			return fmt.Errorf("%s is not one of the configured upstreams %s", target, strings.Join(hosts, ", "))
+		}
+	}
+
+	var seq int64
+	switch {
+	case cmd.IsSet("sequence"):
+		seq = cmd.Int64("sequence")
+	case inputMode == kafkafirehose.InputModeJetstream:
+		seq = cmd.Timestamp("time").UnixMicro()
+	default:
+		seq, err = kafkafirehose.SequenceAt(ctx, bootstrapServers, outputTopic, *cmd.Timestamp("time"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// keep the saved position of every upstream we aren't moving
+	c := &vyletkafka.SequenceCursor{
+		Source:            source,
+		UpstreamSequences: make(map[string]int64),
+	}
+	cursors, err := kafkafirehose.ListCursors(ctx, bootstrapServers, outputTopic, 1)
+	if err != nil {
+		return err
+	}
+	if len(cursors) > 0 && kafkafirehose.SourceOf(cursors[0].Cursor) == source {
+		last := cursors[0].Cursor
+		maps.Copy(c.UpstreamSequences, last.UpstreamSequences)
+		if len(last.UpstreamSequences) == 0 {
+			// cursors saved before upstreams were tracked separately belong to the first upstream
+			c.UpstreamSequences[hosts[0]] = last.Sequence
+		}
+		c.Sequence = last.Sequence
+	}
+
+	for _, target := range targets {
+		c.UpstreamSequences[target] = seq
+	}
+	if slices.Contains(targets, hosts[0]) {
+		c.Sequence = seq
+	}
+
+	if err := kafkafirehose.SaveCursor(ctx, bootstrapServers, outputTopic, c); err != nil {
+		return err
+	}
+
+	fmt.Printf("saved cursor %s\n", formatUpstreams(c.UpstreamSequences))
+
+	return nil
+}
+
+// latestCursor returns the most recent cursor, or the most recent final one
+func latestCursor(cursors []*kafkafirehose.SavedCursor, final bool) *kafkafirehose.SavedCursor {
+	for i := len(cursors) - 1; i >= 0; i-- {
+		if !final || cursors[i].Cursor.SavedOnExit {
+			return cursors[i]
+		}
+	}
+	return nil
+}
+
+func formatUpstreams(seqs map[string]int64) string {
+	var parts []string
+	for _, host := range slices.Sorted(maps.Keys(seqs)) {
+		parts = append(parts, fmt.Sprintf("%s=%d", host, seqs[host]))
+	}
+	return strings.Join(parts, " ")
+}
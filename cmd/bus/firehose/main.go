@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/bluesky-social/go-util/pkg/telemetry"
 	"github.com/urfave/cli/v2"
@@ -17,14 +18,26 @@ func main() {
 		Flags: []cli.Flag{
 			telemetry.CLIFlagDebug,
 			telemetry.CLIFlagMetricsListenAddress,
+			&cli.StringFlag{
+				Name:    "input-mode",
+				Usage:   "upstream to read events from, either relay or jetstream",
+				EnvVars: []string{"VYLET_FIREHOSE_INPUT_MODE"},
+				Value:   string(kafkafirehose.InputModeRelay),
+			},
 			&cli.StringSliceFlag{
 				Name:    "desired-collections",
 				EnvVars: []string{"VYLET_FIREHOSE_DESIRED_COLLECTIONS"},
 			},
-			&cli.StringFlag{
+			&cli.StringSliceFlag{
 				Name:    "websocket-host",
+				Usage:   "relay to subscribe to, may be given multiple times to fan in from several relays",
 				EnvVars: []string{"VYLET_FIREHOSE_WEBSOCKET_HOST", "BSKY_RELAY_HOST", "RELAY_HOST"},
-				Value:   "wss://bsky.network",
+				Value:   cli.NewStringSlice("wss://bsky.network"),
+			},
+			&cli.StringFlag{
+				Name:    "jetstream-host",
+				EnvVars: []string{"VYLET_FIREHOSE_JETSTREAM_HOST", "JETSTREAM_HOST"},
+				Value:   "wss://jetstream2.us-east.bsky.network",
 			},
 			&cli.StringSliceFlag{
 				Name:    "bootstrap-servers",
@@ -36,8 +49,109 @@ func main() {
 				EnvVars: []string{"VYLET_FIREHOSE_OUTPUT_TOPIC"},
 				Value:   "firehose-events-prod",
 			},
+			&cli.IntFlag{
+				Name:    "dedup-window",
+				Usage:   "number of recently produced events remembered to drop duplicates delivered by several relays",
+				EnvVars: []string{"VYLET_FIREHOSE_DEDUP_WINDOW"},
+				Value:   100_000,
+			},
+			&cli.StringSliceFlag{
+				Name:    "route",
+				Usage:   "additionally send commits for a collection or NSID prefix to a topic, as <prefix>=<topic>",
+				EnvVars: []string{"VYLET_FIREHOSE_ROUTES"},
+			},
+			&cli.StringFlag{
+				Name:    "blob-topic",
+				Usage:   "additionally send commits whose record references a blob to this topic",
+				EnvVars: []string{"VYLET_FIREHOSE_BLOB_TOPIC"},
+			},
+			&cli.BoolFlag{
+				Name:    "transactional",
+				Usage:   "commit produced events and the cursor atomically in kafka transactions",
+				EnvVars: []string{"VYLET_FIREHOSE_TRANSACTIONAL"},
+			},
+			&cli.StringFlag{
+				Name:    "transactional-id",
+				Usage:   "transactional id unique to this firehose instance, defaults to one derived from the output topic",
+				EnvVars: []string{"VYLET_FIREHOSE_TRANSACTIONAL_ID"},
+			},
+			&cli.DurationFlag{
+				Name:    "transaction-interval",
+				EnvVars: []string{"VYLET_FIREHOSE_TRANSACTION_INTERVAL"},
+				Value:   time.Second,
+			},
+			&cli.BoolFlag{
+				Name:    "verify-commits",
+				Usage:   "verify commit signatures and MST proofs before producing, sending failures to the rejected topic",
+				EnvVars: []string{"VYLET_FIREHOSE_VERIFY_COMMITS"},
+			},
+			&cli.StringFlag{
+				Name:    "plc-host",
+				EnvVars: []string{"VYLET_FIREHOSE_PLC_HOST", "PLC_HOST"},
+				Value:   "https://plc.directory",
+			},
+			&cli.StringFlag{
+				Name:    "lexicons-path",
+				Usage:   "directory of lexicon schemas to validate records against before producing, validation is disabled when empty",
+				EnvVars: []string{"VYLET_FIREHOSE_LEXICONS_PATH"},
+			},
+			&cli.StringFlag{
+				Name:    "capture-dir",
+				Usage:   "directory to write a copy of every produced event to, for replaying with kafka-replay",
+				EnvVars: []string{"VYLET_FIREHOSE_CAPTURE_DIR"},
+			},
+			&cli.IntFlag{
+				Name:    "capture-segment-events",
+				Usage:   "number of events written to each capture segment",
+				EnvVars: []string{"VYLET_FIREHOSE_CAPTURE_SEGMENT_EVENTS"},
+				Value:   100_000,
+			},
+			&cli.StringFlag{
+				Name:    "allow-dids-file",
+				Usage:   "file listing the only DIDs whose records are forwarded, one per line",
+				EnvVars: []string{"VYLET_FIREHOSE_ALLOW_DIDS_FILE"},
+			},
+			&cli.StringFlag{
+				Name:    "deny-dids-file",
+				Usage:   "file listing DIDs whose records are never forwarded, one per line",
+				EnvVars: []string{"VYLET_FIREHOSE_DENY_DIDS_FILE"},
+			},
+			&cli.DurationFlag{
+				Name:    "did-list-reload-interval",
+				EnvVars: []string{"VYLET_FIREHOSE_DID_LIST_RELOAD_INTERVAL"},
+				Value:   30 * time.Second,
+			},
+			&cli.Float64Flag{
+				Name:    "did-rate-limit",
+				Usage:   "creates and updates per minute forwarded for any single DID, disabled when zero",
+				EnvVars: []string{"VYLET_FIREHOSE_DID_RATE_LIMIT"},
+			},
+			&cli.IntFlag{
+				Name:    "did-rate-burst",
+				Usage:   "burst allowed above the per-DID rate limit, defaults to one minute's worth",
+				EnvVars: []string{"VYLET_FIREHOSE_DID_RATE_BURST"},
+			},
+			&cli.DurationFlag{
+				Name:    "stall-timeout",
+				Usage:   "force a reconnect upstream when no events have been received for this long",
+				EnvVars: []string{"VYLET_FIREHOSE_STALL_TIMEOUT"},
+				Value:   time.Minute,
+			},
+			&cli.DurationFlag{
+				Name:    "reconnect-backoff-min",
+				EnvVars: []string{"VYLET_FIREHOSE_RECONNECT_BACKOFF_MIN"},
+				Value:   time.Second,
+			},
+			&cli.DurationFlag{
+				Name:    "reconnect-backoff-max",
+				EnvVars: []string{"VYLET_FIREHOSE_RECONNECT_BACKOFF_MAX"},
+				Value:   time.Minute,
+			},
 		},
 		Action: run,
+		Commands: []*cli.Command{
+			cursorCommand,
+		},
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -51,13 +165,49 @@ func run(cmd *cli.Context) error {
 	logger := telemetry.StartLogger(cmd)
 	telemetry.StartMetrics(cmd)
 
+	var routes []kafkafirehose.Route
+	for _, r := range cmd.StringSlice("route") {
+		route, err := kafkafirehose.ParseRoute(r)
+		if err != nil {
+			return err
+		}
+		routes = append(routes, route)
+	}
+
 	kf, err := kafkafirehose.New(ctx, &kafkafirehose.Args{
 		Logger: logger,
 
+		InputMode:          kafkafirehose.InputMode(cmd.String("input-mode")),
 		DesiredCollections: cmd.StringSlice("desired-collections"),
-		WebsocketHost:      cmd.String("websocket-host"),
+		WebsocketHosts:     cmd.StringSlice("websocket-host"),
+		JetstreamHost:      cmd.String("jetstream-host"),
 		BootstrapServers:   cmd.StringSlice("bootstrap-servers"),
 		OutputTopic:        cmd.String("output-topic"),
+		DedupWindow:        cmd.Int("dedup-window"),
+
+		Routes:    routes,
+		BlobTopic: cmd.String("blob-topic"),
+
+		Transactional:       cmd.Bool("transactional"),
+		TransactionalID:     cmd.String("transactional-id"),
+		TransactionInterval: cmd.Duration("transaction-interval"),
+
+		VerifyCommits: cmd.Bool("verify-commits"),
+		PLCHost:       cmd.String("plc-host"),
+		LexiconsPath:  cmd.String("lexicons-path"),
+
+		CaptureDir:           cmd.String("capture-dir"),
+		CaptureSegmentEvents: cmd.Int("capture-segment-events"),
+
+		AllowDidsFile:         cmd.String("allow-dids-file"),
+		DenyDidsFile:          cmd.String("deny-dids-file"),
+		DidListReloadInterval: cmd.Duration("did-list-reload-interval"),
+		DidRateLimit:          cmd.Float64("did-rate-limit"),
+		DidRateBurst:          cmd.Int("did-rate-burst"),
+
+		StallTimeout:        cmd.Duration("stall-timeout"),
+		ReconnectBackoffMin: cmd.Duration("reconnect-backoff-min"),
+		ReconnectBackoffMax: cmd.Duration("reconnect-backoff-max"),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create new kafka firehose: %w", err)
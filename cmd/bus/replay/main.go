@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/bluesky-social/go-util/pkg/bus/kafka"
+	"github.com/bluesky-social/go-util/pkg/telemetry"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/urfave/cli/v2"
+	"github.com/vylet-app/go/bus/capture"
+	"github.com/vylet-app/go/bus/headers"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
+)
+
+func main() {
+	app := cli.App{
+		Name:  "kafka-replay",
+		Usage: "produce events captured by kafka-firehose into a topic",
+		Flags: []cli.Flag{
+			telemetry.CLIFlagDebug,
+			&cli.StringSliceFlag{
+				Name:    "bootstrap-servers",
+				EnvVars: []string{"VYLET_REPLAY_BOOTSTRAP_SERVERS", "BOOTSTRAP_SERVERS"},
+				Value:   cli.NewStringSlice("localhost:9092"),
+			},
+			&cli.StringFlag{
+				Name:     "capture",
+				Usage:    "capture segment, or directory of segments, written by kafka-firehose --capture-dir",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "topic",
+				Usage:    "topic to produce the captured events into",
+				Required: true,
+				EnvVars:  []string{"VYLET_REPLAY_TOPIC"},
+			},
+			&cli.Float64Flag{
+				Name:  "rate",
+				Usage: "maximum events produced per second, unlimited when zero",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "stop after producing this many events, unlimited when zero",
+			},
+		},
+		Action: run,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(cmd *cli.Context) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	logger := telemetry.StartLogger(cmd)
+
+	reader, err := capture.Open(cmd.String("capture"))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	bootstrapServers := cmd.StringSlice("bootstrap-servers")
+	topic := cmd.String("topic")
+
+	client, err := kafka.NewKafkaClient(kafka.Config{
+		BootstrapServers: bootstrapServers,
+		ClientID:         "vylet-kafka-replay",
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	defer client.Close()
+
+	if err := kafka.EnsureTopic(ctx, client, kafka.Config{
+		BootstrapServers:  bootstrapServers,
+		Topic:             topic,
+		TopicPartitions:   24,
+		ReplicationFactor: 1,
+	}); err != nil {
+		return fmt.Errorf("failed to ensure topic %s: %w", topic, err)
+	}
+
+	limiter := rate.NewLimiter(rate.Inf, 1)
+	if r := cmd.Float64("rate"); r > 0 {
+		limiter = rate.NewLimiter(rate.Limit(r), 1)
+	}
+
+	limit := cmd.Int("limit")
+
+	// the first error from an async produce stops the replay
+	var produceErr atomic.Pointer[error]
+
+	produced := 0
+	for limit <= 0 || produced < limit {
+		captured, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := limiter.Wait(ctx); err != nil {
+			logger.Info("replay interrupted", "produced", produced)
+			break
+		}
+
+		payload, err := proto.Marshal(captured.Event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+
+		if err := produceErr.Load(); err != nil {
+			return *err
+		}
+
+		// produced in capture order and keyed by did, as the firehose does, so per-repo ordering is preserved
+		seq := captured.Sequence
+		client.Produce(ctx, &kgo.Record{
+			Topic:   topic,
+			Key:     []byte(captured.Event.Did),
+			Value:   payload,
+			Headers: headers.ForEvent(captured.Event, captured.Sequence, captured.HasBlobs),
+		}, func(r *kgo.Record, err error) {
+			if err != nil {
+				err = fmt.Errorf("failed to produce event with sequence %d: %w", seq, err)
+				produceErr.CompareAndSwap(nil, &err)
+			}
+		})
+
+		produced++
+		if produced%10_000 == 0 {
+			logger.Info("replay progress", "produced", produced, "sequence", captured.Sequence)
+		}
+	}
+
+	if err := client.Flush(context.Background()); err != nil {
+		return fmt.Errorf("failed to flush produced events: %w", err)
+	}
+	if err := produceErr.Load(); err != nil {
+		return *err
+	}
+
+	fmt.Printf("replayed %d events into %s\n", produced, topic)
+
+	return nil
+}
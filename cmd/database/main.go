@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/bluesky-social/go-util/pkg/telemetry"
 	"github.com/urfave/cli/v2"
@@ -32,6 +33,12 @@ func main() {
 				Value:   "vylet",
 				EnvVars: []string{"VYLET_DATABASE_CASSANDRA_KEYSPACE"},
 			},
+			&cli.DurationFlag{
+				Name:    "tombstone-ttl",
+				Usage:   "how long the rev of a deleted record is kept to reject older creates, which must outlast the retention of the firehose topic",
+				Value:   30 * 24 * time.Hour,
+				EnvVars: []string{"VYLET_DATABASE_TOMBSTONE_TTL"},
+			},
 		},
 		Action: run,
 	}
@@ -53,6 +60,7 @@ func run(cmd *cli.Context) error {
 		ListenAddr:        cmd.String("listen-addr"),
 		CassandraAddrs:    cmd.StringSlice("cassandra-addrs"),
 		CassandraKeyspace: cmd.String("cassandra-keyspace"),
+		TombstoneTTL:      cmd.Duration("tombstone-ttl"),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create new server: %w", err)
@@ -208,8 +208,14 @@ func getTypePartsFromRef(ref string) (string, string) {
 func generateHandler(method string, id string, def *lex.TypeSchema, packageName string) string {
 	name := getName(id)
 	structTagPrefix := "query"
+	// queries are bound from their parameters, procedures from their json input body
+	params := def.Parameters
 	if method == "post" {
 		structTagPrefix = "json"
+		params = nil
+		if def.Input != nil {
+			params = def.Input.Schema
+		}
 	}
 
 	contents := fmt.Sprintf(`// GENERATED CODE - DO NOT MODIFY
@@ -225,24 +231,26 @@ import (
 
 type %sInput struct {
 `, packageName, name)
-	_ = contents
 
-	sortedParamNames := make([]string, 0, len(def.Parameters.Properties))
-	for paramName := range def.Parameters.Properties {
-		sortedParamNames = append(sortedParamNames, paramName)
+	var sortedParamNames []string
+	if params != nil {
+		sortedParamNames = make([]string, 0, len(params.Properties))
+		for paramName := range params.Properties {
+			sortedParamNames = append(sortedParamNames, paramName)
+		}
 	}
 	sort.Slice(sortedParamNames, func(i, j int) bool {
 		return sortedParamNames[j] > sortedParamNames[i]
 	})
 
 	for _, paramName := range sortedParamNames {
-		subDef := def.Parameters.Properties[paramName]
+		subDef := params.Properties[paramName]
 		var typeStr = typeToTypeStr(subDef)
 		structTag := structTagPrefix + ":" + "\"" + paramName
-		if !slices.Contains(def.Parameters.Required, paramName) {
+		if !slices.Contains(params.Required, paramName) {
 			typeStr = "*" + typeStr
 			if method == "post" {
-				structTag += "omitempty"
+				structTag += ",omitempty"
 			}
 		}
 		structTag += "\""
@@ -259,7 +267,10 @@ func (h *%s) Handle%s(e echo.Context) error {
 		logger.Error("error binding request", "err", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
 	}
+`, capitalizeFirst(packageName), name, name, name)
 
+	if hasOutput(def) {
+		contents += fmt.Sprintf(`
 	output, err := h.server.Handle%s(e, &input)
 	if err != nil {
 		return err
@@ -267,11 +278,26 @@ func (h *%s) Handle%s(e echo.Context) error {
 
 	return e.JSON(http.StatusOK, &output)
 }
-`, capitalizeFirst(packageName), name, name, name, name)
+`, name)
+	} else {
+		contents += fmt.Sprintf(`
+	if err := h.server.Handle%s(e, &input); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusOK)
+}
+`, name)
+	}
 
 	return contents
 }
 
+// hasOutput reports whether a query or procedure responds with a body. procedures may have no output at all
+func hasOutput(def *lex.TypeSchema) bool {
+	return def.Output != nil && def.Output.Schema != nil
+}
+
 func typeToTypeStr(def *lex.TypeSchema) string {
 	switch def.Type {
 	case "string":
@@ -320,18 +346,28 @@ type Server interface {
 
 `, packageName, lexgenPackageName, lexgenPackageUrl)
 
-	addHandlerToInterface := func(id string, refName string) {
+	addHandlerToInterface := func(id string, def *lex.TypeSchema) {
 		name := getName(id)
 		handlerName := "Handle" + name
 		inputTypeName := name + "Input"
 		requiresAuthName := name + "RequiresAuth"
 
+		if !hasOutput(def) {
+			contents += fmt.Sprintf(`	%s(e echo.Context, input *%s) *echo.HTTPError
+	%s() bool
+`, handlerName, inputTypeName, requiresAuthName)
+			return
+		}
+
 		var outputTypeName string
-		if refName != "" {
-			refNsidName, refTypeName := getTypePartsFromRef(refName)
-			outputTypeName = lexgenPackageName + "." + refNsidName + "_" + refTypeName
-		} else {
+		switch def.Output.Schema.Type {
+		case "object":
 			outputTypeName = lexgenPackageName + "." + name + "_Output"
+		case "ref":
+			refNsidName, refTypeName := getTypePartsFromRef(def.Output.Schema.Ref)
+			outputTypeName = lexgenPackageName + "." + refNsidName + "_" + refTypeName
+		default:
+			return
 		}
 
 		contents += fmt.Sprintf(`	%s(e echo.Context, input *%s) (*%s, *echo.HTTPError)
@@ -340,23 +376,11 @@ type Server interface {
 	}
 
 	for _, id := range queryIds {
-		schema := queryDefs[id]
-		switch schema.Output.Schema.Type {
-		case "object":
-			addHandlerToInterface(id, "")
-		case "ref":
-			addHandlerToInterface(id, schema.Output.Schema.Ref)
-		}
+		addHandlerToInterface(id, queryDefs[id])
 	}
 
 	for _, id := range procedureIds {
-		schema := procedureDefs[id]
-		switch schema.Output.Schema.Type {
-		case "object":
-			addHandlerToInterface(id, "")
-		case "ref":
-			addHandlerToInterface(id, schema.Output.Schema.Ref)
-		}
+		addHandlerToInterface(id, procedureDefs[id])
 	}
 
 	contents += `}
@@ -38,6 +38,36 @@ func main() {
 				Required: true,
 				EnvVars:  []string{"VYLET_INDEXER_CONSUMER_GROUP"},
 			},
+			&cli.IntFlag{
+				Name:    "workers",
+				Usage:   "number of events handled concurrently, events of the same repo are always handled in order",
+				Value:   16,
+				EnvVars: []string{"VYLET_INDEXER_WORKERS"},
+			},
+			&cli.IntFlag{
+				Name:    "worker-queue-size",
+				Usage:   "number of events waiting on each worker before consuming blocks",
+				Value:   100,
+				EnvVars: []string{"VYLET_INDEXER_WORKER_QUEUE_SIZE"},
+			},
+			&cli.StringFlag{
+				Name:    "plc-host",
+				Usage:   "plc directory used to find the pds of repos being resynced",
+				Value:   "https://plc.directory",
+				EnvVars: []string{"VYLET_INDEXER_PLC_HOST", "PLC_HOST"},
+			},
+			&cli.IntFlag{
+				Name:    "resync-workers",
+				Usage:   "number of repos fetched concurrently after #sync events, each reconciled on its repo's worker",
+				Value:   4,
+				EnvVars: []string{"VYLET_INDEXER_RESYNC_WORKERS"},
+			},
+			&cli.IntFlag{
+				Name:    "resync-queue-size",
+				Usage:   "number of pending repos queued in memory for resync, the rest waiting in the database",
+				Value:   10_000,
+				EnvVars: []string{"VYLET_INDEXER_RESYNC_QUEUE_SIZE"},
+			},
 		},
 		Action: run,
 	}
@@ -53,12 +83,17 @@ func run(cmd *cli.Context) error {
 	logger := telemetry.StartLogger(cmd)
 	telemetry.StartMetrics(cmd)
 
-	server, err := indexer.New(&indexer.Args{
+	server, err := indexer.New(ctx, &indexer.Args{
 		Logger:           logger,
 		BootstrapServers: cmd.StringSlice("bootstrap-servers"),
 		InputTopic:       cmd.String("input-topic"),
 		ConsumerGroup:    cmd.String("consumer-group"),
 		DatabaseHost:     cmd.String("database-host"),
+		Workers:          cmd.Int("workers"),
+		WorkerQueueSize:  cmd.Int("worker-queue-size"),
+		PLCHost:          cmd.String("plc-host"),
+		ResyncWorkers:    cmd.Int("resync-workers"),
+		ResyncQueueSize:  cmd.Int("resync-queue-size"),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create new server: %w", err)
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/bluesky-social/go-util/pkg/telemetry"
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/urfave/cli/v2"
+	"github.com/vylet-app/go/notifier"
+)
+
+func main() {
+	app := cli.App{
+		Name: "vylet-notifier",
+		Flags: []cli.Flag{
+			telemetry.CLIFlagDebug,
+			telemetry.CLIFlagMetricsListenAddress,
+			&cli.StringFlag{
+				Name:    "database-host",
+				Value:   "127.0.0.1:9090",
+				EnvVars: []string{"VYLET_NOTIFIER_DATABASE_HOST", "VYLET_DATABASE_HOST"},
+			},
+			&cli.StringSliceFlag{
+				Name:    "bootstrap-servers",
+				Value:   cli.NewStringSlice("localhost:9092"),
+				EnvVars: []string{"VYLET_BOOTSTRAP_SERVERS"},
+			},
+			&cli.StringFlag{
+				Name:    "input-topic",
+				Value:   "firehose-events-prod",
+				EnvVars: []string{"VYLET_NOTIFIER_INPUT_TOPIC"},
+			},
+			&cli.StringFlag{
+				Name:     "consumer-group",
+				Required: true,
+				EnvVars:  []string{"VYLET_NOTIFIER_CONSUMER_GROUP"},
+			},
+			&cli.TimestampFlag{
+				Name:    "skip-before",
+				Usage:   "skip events the firehose received before this RFC 3339 timestamp, such as those already in the topic when the notifier is first deployed",
+				Layout:  time.RFC3339,
+				EnvVars: []string{"VYLET_NOTIFIER_SKIP_BEFORE"},
+			},
+		},
+		Action: run,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(cmd *cli.Context) error {
+	ctx := context.Background()
+
+	logger := telemetry.StartLogger(cmd)
+	telemetry.StartMetrics(cmd)
+
+	args := &notifier.Args{
+		Logger:           logger,
+		BootstrapServers: cmd.StringSlice("bootstrap-servers"),
+		InputTopic:       cmd.String("input-topic"),
+		ConsumerGroup:    cmd.String("consumer-group"),
+		DatabaseHost:     cmd.String("database-host"),
+	}
+	if skipBefore := cmd.Timestamp("skip-before"); skipBefore != nil {
+		args.SkipBefore = *skipBefore
+	}
+
+	server, err := notifier.New(ctx, args)
+	if err != nil {
+		return fmt.Errorf("failed to create new server: %w", err)
+	}
+
+	if err := server.Run(ctx); err != nil {
+		return fmt.Errorf("failed to run server: %w", err)
+	}
+
+	return nil
+}
@@ -10,16 +10,25 @@ import (
 )
 
 type Client struct {
-	client  *grpc.ClientConn
-	Profile vyletdatabase.ProfileServiceClient
-	Post    vyletdatabase.PostServiceClient
-	Like    vyletdatabase.LikeServiceClient
-	Follow  vyletdatabase.FollowServiceClient
-	BlobRef vyletdatabase.BlobRefServiceClient
+	client       *grpc.ClientConn
+	Profile      vyletdatabase.ProfileServiceClient
+	Post         vyletdatabase.PostServiceClient
+	Like         vyletdatabase.LikeServiceClient
+	Follow       vyletdatabase.FollowServiceClient
+	BlobRef      vyletdatabase.BlobRefServiceClient
+	Comment      vyletdatabase.CommentServiceClient
+	Account      vyletdatabase.AccountServiceClient
+	Identity     vyletdatabase.IdentityServiceClient
+	Block        vyletdatabase.BlockServiceClient
+	Mute         vyletdatabase.MuteServiceClient
+	Notification vyletdatabase.NotificationServiceClient
+	Resync       vyletdatabase.ResyncServiceClient
 }
 
 type Args struct {
 	Addr string
+	// Interceptors are run around every rpc, in order
+	Interceptors []grpc.UnaryClientInterceptor
 }
 
 func New(args *Args) (*Client, error) {
@@ -28,7 +37,7 @@ func New(args *Args) (*Client, error) {
 	}
 	creds := credentials.NewTLS(tlsConfig)
 
-	conn, err := grpc.NewClient(args.Addr, grpc.WithTransportCredentials(creds))
+	conn, err := grpc.NewClient(args.Addr, grpc.WithTransportCredentials(creds), grpc.WithChainUnaryInterceptor(args.Interceptors...))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
@@ -38,14 +47,28 @@ func New(args *Args) (*Client, error) {
 	likeClient := vyletdatabase.NewLikeServiceClient(conn)
 	blobRefClient := vyletdatabase.NewBlobRefServiceClient(conn)
 	followClient := vyletdatabase.NewFollowServiceClient(conn)
+	commentClient := vyletdatabase.NewCommentServiceClient(conn)
+	accountClient := vyletdatabase.NewAccountServiceClient(conn)
+	identityClient := vyletdatabase.NewIdentityServiceClient(conn)
+	blockClient := vyletdatabase.NewBlockServiceClient(conn)
+	muteClient := vyletdatabase.NewMuteServiceClient(conn)
+	notificationClient := vyletdatabase.NewNotificationServiceClient(conn)
+	resyncClient := vyletdatabase.NewResyncServiceClient(conn)
 
 	client := Client{
-		client:  conn,
-		Profile: profileClient,
-		Post:    postClient,
-		Like:    likeClient,
-		BlobRef: blobRefClient,
-		Follow:  followClient,
+		client:       conn,
+		Profile:      profileClient,
+		Post:         postClient,
+		Like:         likeClient,
+		BlobRef:      blobRefClient,
+		Follow:       followClient,
+		Comment:      commentClient,
+		Account:      accountClient,
+		Identity:     identityClient,
+		Block:        blockClient,
+		Mute:         muteClient,
+		Notification: notificationClient,
+		Resync:       resyncClient,
 	}
 
 	return &client, nil
@@ -0,0 +1,722 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: block.proto
+
+package vyletdatabase
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Block struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	SubjectDid    string                 `protobuf:"bytes,3,opt,name=subject_did,json=subjectDid,proto3" json:"subject_did,omitempty"`
+	AuthorDid     string                 `protobuf:"bytes,4,opt,name=author_did,json=authorDid,proto3" json:"author_did,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IndexedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_block_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Block) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *Block) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *Block) GetSubjectDid() string {
+	if x != nil {
+		return x.SubjectDid
+	}
+	return ""
+}
+
+func (x *Block) GetAuthorDid() string {
+	if x != nil {
+		return x.AuthorDid
+	}
+	return ""
+}
+
+func (x *Block) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Block) GetIndexedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return nil
+}
+
+type CreateBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBlockRequest) Reset() {
+	*x = CreateBlockRequest{}
+	mi := &file_block_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBlockRequest) ProtoMessage() {}
+
+func (x *CreateBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBlockRequest.ProtoReflect.Descriptor instead.
+func (*CreateBlockRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateBlockRequest) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *CreateBlockRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+type CreateBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBlockResponse) Reset() {
+	*x = CreateBlockResponse{}
+	mi := &file_block_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBlockResponse) ProtoMessage() {}
+
+func (x *CreateBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBlockResponse.ProtoReflect.Descriptor instead.
+func (*CreateBlockResponse) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateBlockResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type DeleteBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBlockRequest) Reset() {
+	*x = DeleteBlockRequest{}
+	mi := &file_block_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBlockRequest) ProtoMessage() {}
+
+func (x *DeleteBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBlockRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBlockRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteBlockRequest) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *DeleteBlockRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+type DeleteBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBlockResponse) Reset() {
+	*x = DeleteBlockResponse{}
+	mi := &file_block_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBlockResponse) ProtoMessage() {}
+
+func (x *DeleteBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBlockResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBlockResponse) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteBlockResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type GetBlocksByActorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Did           string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlocksByActorRequest) Reset() {
+	*x = GetBlocksByActorRequest{}
+	mi := &file_block_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlocksByActorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlocksByActorRequest) ProtoMessage() {}
+
+func (x *GetBlocksByActorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlocksByActorRequest.ProtoReflect.Descriptor instead.
+func (*GetBlocksByActorRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetBlocksByActorRequest) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *GetBlocksByActorRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetBlocksByActorRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type GetBlocksByActorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Blocks        []*Block               `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlocksByActorResponse) Reset() {
+	*x = GetBlocksByActorResponse{}
+	mi := &file_block_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlocksByActorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlocksByActorResponse) ProtoMessage() {}
+
+func (x *GetBlocksByActorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlocksByActorResponse.ProtoReflect.Descriptor instead.
+func (*GetBlocksByActorResponse) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBlocksByActorResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetBlocksByActorResponse) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *GetBlocksByActorResponse) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type GetBlockRelationshipsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewerDid     string                 `protobuf:"bytes,1,opt,name=viewer_did,json=viewerDid,proto3" json:"viewer_did,omitempty"`
+	Dids          []string               `protobuf:"bytes,2,rep,name=dids,proto3" json:"dids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRelationshipsRequest) Reset() {
+	*x = GetBlockRelationshipsRequest{}
+	mi := &file_block_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRelationshipsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRelationshipsRequest) ProtoMessage() {}
+
+func (x *GetBlockRelationshipsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRelationshipsRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBlockRelationshipsRequest) GetViewerDid() string {
+	if x != nil {
+		return x.ViewerDid
+	}
+	return ""
+}
+
+func (x *GetBlockRelationshipsRequest) GetDids() []string {
+	if x != nil {
+		return x.Dids
+	}
+	return nil
+}
+
+// BlockRelationship is how the viewer and another actor block each other
+type BlockRelationship struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the uri of the viewer's block of the actor
+	Blocking *string `protobuf:"bytes,1,opt,name=blocking,proto3,oneof" json:"blocking,omitempty"`
+	// whether the actor blocks the viewer
+	BlockedBy     bool `protobuf:"varint,2,opt,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockRelationship) Reset() {
+	*x = BlockRelationship{}
+	mi := &file_block_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockRelationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRelationship) ProtoMessage() {}
+
+func (x *BlockRelationship) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRelationship.ProtoReflect.Descriptor instead.
+func (*BlockRelationship) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BlockRelationship) GetBlocking() string {
+	if x != nil && x.Blocking != nil {
+		return *x.Blocking
+	}
+	return ""
+}
+
+func (x *BlockRelationship) GetBlockedBy() bool {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return false
+}
+
+type GetBlockRelationshipsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Error *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	// keyed by did, only dids with a block in either direction are included
+	Relationships map[string]*BlockRelationship `protobuf:"bytes,2,rep,name=relationships,proto3" json:"relationships,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRelationshipsResponse) Reset() {
+	*x = GetBlockRelationshipsResponse{}
+	mi := &file_block_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRelationshipsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRelationshipsResponse) ProtoMessage() {}
+
+func (x *GetBlockRelationshipsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRelationshipsResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetBlockRelationshipsResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetBlockRelationshipsResponse) GetRelationships() map[string]*BlockRelationship {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+var File_block_proto protoreflect.FileDescriptor
+
+const file_block_proto_rawDesc = "" +
+	"\n" +
+	"\vblock.proto\x12\rvyletdatabase\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf9\x01\n" +
+	"\x05Block\x12\x18\n" +
+	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\x12\x18\n" +
+	"\x03cid\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03cid\x12'\n" +
+	"\vsubject_did\x18\x03 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"subjectDid\x12\x1d\n" +
+	"\n" +
+	"author_did\x18\x04 \x01(\tR\tauthorDid\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"indexed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\"R\n" +
+	"\x12CreateBlockRequest\x12*\n" +
+	"\x05block\x18\x01 \x01(\v2\x14.vyletdatabase.BlockR\x05block\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\":\n" +
+	"\x13CreateBlockResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"@\n" +
+	"\x12DeleteBlockRequest\x12\x18\n" +
+	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\":\n" +
+	"\x13DeleteBlockResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"q\n" +
+	"\x17GetBlocksByActorRequest\x12\x18\n" +
+	"\x03did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03did\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x00R\x06cursor\x88\x01\x01B\t\n" +
+	"\a_cursor\"\x95\x01\n" +
+	"\x18GetBlocksByActorResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12,\n" +
+	"\x06blocks\x18\x02 \x03(\v2\x14.vyletdatabase.BlockR\x06blocks\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_errorB\t\n" +
+	"\a_cursor\"Y\n" +
+	"\x1cGetBlockRelationshipsRequest\x12%\n" +
+	"\n" +
+	"viewer_did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\tviewerDid\x12\x12\n" +
+	"\x04dids\x18\x02 \x03(\tR\x04dids\"`\n" +
+	"\x11BlockRelationship\x12\x1f\n" +
+	"\bblocking\x18\x01 \x01(\tH\x00R\bblocking\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"blocked_by\x18\x02 \x01(\bR\tblockedByB\v\n" +
+	"\t_blocking\"\x8f\x02\n" +
+	"\x1dGetBlockRelationshipsResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12e\n" +
+	"\rrelationships\x18\x02 \x03(\v2?.vyletdatabase.GetBlockRelationshipsResponse.RelationshipsEntryR\rrelationships\x1ab\n" +
+	"\x12RelationshipsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x126\n" +
+	"\x05value\x18\x02 \x01(\v2 .vyletdatabase.BlockRelationshipR\x05value:\x028\x01B\b\n" +
+	"\x06_error2\x93\x03\n" +
+	"\fBlockService\x12T\n" +
+	"\vCreateBlock\x12!.vyletdatabase.CreateBlockRequest\x1a\".vyletdatabase.CreateBlockResponse\x12T\n" +
+	"\vDeleteBlock\x12!.vyletdatabase.DeleteBlockRequest\x1a\".vyletdatabase.DeleteBlockResponse\x12c\n" +
+	"\x10GetBlocksByActor\x12&.vyletdatabase.GetBlocksByActorRequest\x1a'.vyletdatabase.GetBlocksByActorResponse\x12r\n" +
+	"\x15GetBlockRelationships\x12+.vyletdatabase.GetBlockRelationshipsRequest\x1a,.vyletdatabase.GetBlockRelationshipsResponseB\x85\x01\n" +
+	"\x11com.vyletdatabaseB\n" +
+	"BlockProtoP\x01Z\x10./;vyletdatabase\xa2\x02\x03VXX\xaa\x02\rVyletdatabase\xca\x02\rVyletdatabase\xe2\x02\x19Vyletdatabase\\GPBMetadata\xea\x02\rVyletdatabaseb\x06proto3"
+
+var (
+	file_block_proto_rawDescOnce sync.Once
+	file_block_proto_rawDescData []byte
+)
+
+func file_block_proto_rawDescGZIP() []byte {
+	file_block_proto_rawDescOnce.Do(func() {
+		file_block_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_block_proto_rawDesc), len(file_block_proto_rawDesc)))
+	})
+	return file_block_proto_rawDescData
+}
+
+var file_block_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_block_proto_goTypes = []any{
+	(*Block)(nil),                         // 0: vyletdatabase.Block
+	(*CreateBlockRequest)(nil),            // 1: vyletdatabase.CreateBlockRequest
+	(*CreateBlockResponse)(nil),           // 2: vyletdatabase.CreateBlockResponse
+	(*DeleteBlockRequest)(nil),            // 3: vyletdatabase.DeleteBlockRequest
+	(*DeleteBlockResponse)(nil),           // 4: vyletdatabase.DeleteBlockResponse
+	(*GetBlocksByActorRequest)(nil),       // 5: vyletdatabase.GetBlocksByActorRequest
+	(*GetBlocksByActorResponse)(nil),      // 6: vyletdatabase.GetBlocksByActorResponse
+	(*GetBlockRelationshipsRequest)(nil),  // 7: vyletdatabase.GetBlockRelationshipsRequest
+	(*BlockRelationship)(nil),             // 8: vyletdatabase.BlockRelationship
+	(*GetBlockRelationshipsResponse)(nil), // 9: vyletdatabase.GetBlockRelationshipsResponse
+	nil,                                   // 10: vyletdatabase.GetBlockRelationshipsResponse.RelationshipsEntry
+	(*timestamppb.Timestamp)(nil),         // 11: google.protobuf.Timestamp
+}
+var file_block_proto_depIdxs = []int32{
+	11, // 0: vyletdatabase.Block.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: vyletdatabase.Block.indexed_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: vyletdatabase.CreateBlockRequest.block:type_name -> vyletdatabase.Block
+	0,  // 3: vyletdatabase.GetBlocksByActorResponse.blocks:type_name -> vyletdatabase.Block
+	10, // 4: vyletdatabase.GetBlockRelationshipsResponse.relationships:type_name -> vyletdatabase.GetBlockRelationshipsResponse.RelationshipsEntry
+	8,  // 5: vyletdatabase.GetBlockRelationshipsResponse.RelationshipsEntry.value:type_name -> vyletdatabase.BlockRelationship
+	1,  // 6: vyletdatabase.BlockService.CreateBlock:input_type -> vyletdatabase.CreateBlockRequest
+	3,  // 7: vyletdatabase.BlockService.DeleteBlock:input_type -> vyletdatabase.DeleteBlockRequest
+	5,  // 8: vyletdatabase.BlockService.GetBlocksByActor:input_type -> vyletdatabase.GetBlocksByActorRequest
+	7,  // 9: vyletdatabase.BlockService.GetBlockRelationships:input_type -> vyletdatabase.GetBlockRelationshipsRequest
+	2,  // 10: vyletdatabase.BlockService.CreateBlock:output_type -> vyletdatabase.CreateBlockResponse
+	4,  // 11: vyletdatabase.BlockService.DeleteBlock:output_type -> vyletdatabase.DeleteBlockResponse
+	6,  // 12: vyletdatabase.BlockService.GetBlocksByActor:output_type -> vyletdatabase.GetBlocksByActorResponse
+	9,  // 13: vyletdatabase.BlockService.GetBlockRelationships:output_type -> vyletdatabase.GetBlockRelationshipsResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_block_proto_init() }
+func file_block_proto_init() {
+	if File_block_proto != nil {
+		return
+	}
+	file_block_proto_msgTypes[2].OneofWrappers = []any{}
+	file_block_proto_msgTypes[4].OneofWrappers = []any{}
+	file_block_proto_msgTypes[5].OneofWrappers = []any{}
+	file_block_proto_msgTypes[6].OneofWrappers = []any{}
+	file_block_proto_msgTypes[8].OneofWrappers = []any{}
+	file_block_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_block_proto_rawDesc), len(file_block_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_block_proto_goTypes,
+		DependencyIndexes: file_block_proto_depIdxs,
+		MessageInfos:      file_block_proto_msgTypes,
+	}.Build()
+	File_block_proto = out.File
+	file_block_proto_goTypes = nil
+	file_block_proto_depIdxs = nil
+}
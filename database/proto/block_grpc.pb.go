@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: block.proto
+
+package vyletdatabase
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BlockService_CreateBlock_FullMethodName           = "/vyletdatabase.BlockService/CreateBlock"
+	BlockService_DeleteBlock_FullMethodName           = "/vyletdatabase.BlockService/DeleteBlock"
+	BlockService_GetBlocksByActor_FullMethodName      = "/vyletdatabase.BlockService/GetBlocksByActor"
+	BlockService_GetBlockRelationships_FullMethodName = "/vyletdatabase.BlockService/GetBlockRelationships"
+)
+
+// BlockServiceClient is the client API for BlockService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BlockServiceClient interface {
+	CreateBlock(ctx context.Context, in *CreateBlockRequest, opts ...grpc.CallOption) (*CreateBlockResponse, error)
+	DeleteBlock(ctx context.Context, in *DeleteBlockRequest, opts ...grpc.CallOption) (*DeleteBlockResponse, error)
+	GetBlocksByActor(ctx context.Context, in *GetBlocksByActorRequest, opts ...grpc.CallOption) (*GetBlocksByActorResponse, error)
+	GetBlockRelationships(ctx context.Context, in *GetBlockRelationshipsRequest, opts ...grpc.CallOption) (*GetBlockRelationshipsResponse, error)
+}
+
+type blockServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlockServiceClient(cc grpc.ClientConnInterface) BlockServiceClient {
+	return &blockServiceClient{cc}
+}
+
+func (c *blockServiceClient) CreateBlock(ctx context.Context, in *CreateBlockRequest, opts ...grpc.CallOption) (*CreateBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBlockResponse)
+	err := c.cc.Invoke(ctx, BlockService_CreateBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockServiceClient) DeleteBlock(ctx context.Context, in *DeleteBlockRequest, opts ...grpc.CallOption) (*DeleteBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBlockResponse)
+	err := c.cc.Invoke(ctx, BlockService_DeleteBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockServiceClient) GetBlocksByActor(ctx context.Context, in *GetBlocksByActorRequest, opts ...grpc.CallOption) (*GetBlocksByActorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlocksByActorResponse)
+	err := c.cc.Invoke(ctx, BlockService_GetBlocksByActor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockServiceClient) GetBlockRelationships(ctx context.Context, in *GetBlockRelationshipsRequest, opts ...grpc.CallOption) (*GetBlockRelationshipsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlockRelationshipsResponse)
+	err := c.cc.Invoke(ctx, BlockService_GetBlockRelationships_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BlockServiceServer is the server API for BlockService service.
+// All implementations must embed UnimplementedBlockServiceServer
+// for forward compatibility.
+type BlockServiceServer interface {
+	CreateBlock(context.Context, *CreateBlockRequest) (*CreateBlockResponse, error)
+	DeleteBlock(context.Context, *DeleteBlockRequest) (*DeleteBlockResponse, error)
+	GetBlocksByActor(context.Context, *GetBlocksByActorRequest) (*GetBlocksByActorResponse, error)
+	GetBlockRelationships(context.Context, *GetBlockRelationshipsRequest) (*GetBlockRelationshipsResponse, error)
+	mustEmbedUnimplementedBlockServiceServer()
+}
+
+// UnimplementedBlockServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBlockServiceServer struct{}
+
+func (UnimplementedBlockServiceServer) CreateBlock(context.Context, *CreateBlockRequest) (*CreateBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateBlock not implemented")
+}
+func (UnimplementedBlockServiceServer) DeleteBlock(context.Context, *DeleteBlockRequest) (*DeleteBlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteBlock not implemented")
+}
+func (UnimplementedBlockServiceServer) GetBlocksByActor(context.Context, *GetBlocksByActorRequest) (*GetBlocksByActorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlocksByActor not implemented")
+}
+func (UnimplementedBlockServiceServer) GetBlockRelationships(context.Context, *GetBlockRelationshipsRequest) (*GetBlockRelationshipsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlockRelationships not implemented")
+}
+func (UnimplementedBlockServiceServer) mustEmbedUnimplementedBlockServiceServer() {}
+func (UnimplementedBlockServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeBlockServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlockServiceServer will
+// result in compilation errors.
+type UnsafeBlockServiceServer interface {
+	mustEmbedUnimplementedBlockServiceServer()
+}
+
+func RegisterBlockServiceServer(s grpc.ServiceRegistrar, srv BlockServiceServer) {
+	// If the following call panics, it indicates UnimplementedBlockServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BlockService_ServiceDesc, srv)
+}
+
+func _BlockService_CreateBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockServiceServer).CreateBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockService_CreateBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockServiceServer).CreateBlock(ctx, req.(*CreateBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockService_DeleteBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockServiceServer).DeleteBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockService_DeleteBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockServiceServer).DeleteBlock(ctx, req.(*DeleteBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockService_GetBlocksByActor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlocksByActorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockServiceServer).GetBlocksByActor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockService_GetBlocksByActor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockServiceServer).GetBlocksByActor(ctx, req.(*GetBlocksByActorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockService_GetBlockRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockServiceServer).GetBlockRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockService_GetBlockRelationships_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockServiceServer).GetBlockRelationships(ctx, req.(*GetBlockRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BlockService_ServiceDesc is the grpc.ServiceDesc for BlockService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BlockService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vyletdatabase.BlockService",
+	HandlerType: (*BlockServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBlock",
+			Handler:    _BlockService_CreateBlock_Handler,
+		},
+		{
+			MethodName: "DeleteBlock",
+			Handler:    _BlockService_DeleteBlock_Handler,
+		},
+		{
+			MethodName: "GetBlocksByActor",
+			Handler:    _BlockService_GetBlocksByActor_Handler,
+		},
+		{
+			MethodName: "GetBlockRelationships",
+			Handler:    _BlockService_GetBlockRelationships_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "block.proto",
+}
@@ -110,6 +110,7 @@ func (x *Follow) GetIndexedAt() *timestamppb.Timestamp {
 type CreateFollowRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Follow        *Follow                `protobuf:"bytes,1,opt,name=follow,proto3" json:"follow,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -151,6 +152,13 @@ func (x *CreateFollowRequest) GetFollow() *Follow {
 	return nil
 }
 
+func (x *CreateFollowRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
 type CreateFollowResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
@@ -198,6 +206,7 @@ func (x *CreateFollowResponse) GetError() string {
 type DeleteFollowRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -239,6 +248,13 @@ func (x *DeleteFollowRequest) GetUri() string {
 	return ""
 }
 
+func (x *DeleteFollowRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
 type DeleteFollowResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
@@ -658,14 +674,16 @@ const file_follow_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"indexed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\"D\n" +
+	"indexed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\"V\n" +
 	"\x13CreateFollowRequest\x12-\n" +
-	"\x06follow\x18\x01 \x01(\v2\x15.vyletdatabase.FollowR\x06follow\";\n" +
+	"\x06follow\x18\x01 \x01(\v2\x15.vyletdatabase.FollowR\x06follow\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\";\n" +
 	"\x14CreateFollowResponse\x12\x19\n" +
 	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"/\n" +
+	"\x06_error\"A\n" +
 	"\x13DeleteFollowRequest\x12\x18\n" +
-	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\";\n" +
+	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\";\n" +
 	"\x14DeleteFollowResponse\x12\x19\n" +
 	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
 	"\x06_error\"r\n" +
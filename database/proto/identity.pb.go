@@ -0,0 +1,475 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: identity.proto
+
+package vyletdatabase
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Handle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Did   string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	// handle is handle.invalid when the did document's handle does not resolve back to the did
+	Handle string `protobuf:"bytes,2,opt,name=handle,proto3" json:"handle,omitempty"`
+	// updated_at is the time of the identity event the handle was taken from. SetHandle skips handles older than the
+	// stored one, and a handle without it is only stored when the did has none yet.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Handle) Reset() {
+	*x = Handle{}
+	mi := &file_identity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Handle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Handle) ProtoMessage() {}
+
+func (x *Handle) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Handle.ProtoReflect.Descriptor instead.
+func (*Handle) Descriptor() ([]byte, []int) {
+	return file_identity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Handle) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *Handle) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *Handle) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SetHandleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        *Handle                `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetHandleRequest) Reset() {
+	*x = SetHandleRequest{}
+	mi := &file_identity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetHandleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHandleRequest) ProtoMessage() {}
+
+func (x *SetHandleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHandleRequest.ProtoReflect.Descriptor instead.
+func (*SetHandleRequest) Descriptor() ([]byte, []int) {
+	return file_identity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetHandleRequest) GetHandle() *Handle {
+	if x != nil {
+		return x.Handle
+	}
+	return nil
+}
+
+type SetHandleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetHandleResponse) Reset() {
+	*x = SetHandleResponse{}
+	mi := &file_identity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetHandleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHandleResponse) ProtoMessage() {}
+
+func (x *SetHandleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHandleResponse.ProtoReflect.Descriptor instead.
+func (*SetHandleResponse) Descriptor() ([]byte, []int) {
+	return file_identity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetHandleResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type GetHandlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dids          []string               `protobuf:"bytes,1,rep,name=dids,proto3" json:"dids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHandlesRequest) Reset() {
+	*x = GetHandlesRequest{}
+	mi := &file_identity_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHandlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHandlesRequest) ProtoMessage() {}
+
+func (x *GetHandlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHandlesRequest.ProtoReflect.Descriptor instead.
+func (*GetHandlesRequest) Descriptor() ([]byte, []int) {
+	return file_identity_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetHandlesRequest) GetDids() []string {
+	if x != nil {
+		return x.Dids
+	}
+	return nil
+}
+
+// GetHandlesResponse only holds the dids that have a stored handle
+type GetHandlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Handles       map[string]*Handle     `protobuf:"bytes,2,rep,name=handles,proto3" json:"handles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHandlesResponse) Reset() {
+	*x = GetHandlesResponse{}
+	mi := &file_identity_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHandlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHandlesResponse) ProtoMessage() {}
+
+func (x *GetHandlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHandlesResponse.ProtoReflect.Descriptor instead.
+func (*GetHandlesResponse) Descriptor() ([]byte, []int) {
+	return file_identity_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetHandlesResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetHandlesResponse) GetHandles() map[string]*Handle {
+	if x != nil {
+		return x.Handles
+	}
+	return nil
+}
+
+type GetDidByHandleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        string                 `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDidByHandleRequest) Reset() {
+	*x = GetDidByHandleRequest{}
+	mi := &file_identity_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDidByHandleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDidByHandleRequest) ProtoMessage() {}
+
+func (x *GetDidByHandleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDidByHandleRequest.ProtoReflect.Descriptor instead.
+func (*GetDidByHandleRequest) Descriptor() ([]byte, []int) {
+	return file_identity_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetDidByHandleRequest) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+type GetDidByHandleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Did           *string                `protobuf:"bytes,2,opt,name=did,proto3,oneof" json:"did,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDidByHandleResponse) Reset() {
+	*x = GetDidByHandleResponse{}
+	mi := &file_identity_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDidByHandleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDidByHandleResponse) ProtoMessage() {}
+
+func (x *GetDidByHandleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDidByHandleResponse.ProtoReflect.Descriptor instead.
+func (*GetDidByHandleResponse) Descriptor() ([]byte, []int) {
+	return file_identity_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetDidByHandleResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetDidByHandleResponse) GetDid() string {
+	if x != nil && x.Did != nil {
+		return *x.Did
+	}
+	return ""
+}
+
+var File_identity_proto protoreflect.FileDescriptor
+
+const file_identity_proto_rawDesc = "" +
+	"\n" +
+	"\x0eidentity.proto\x12\rvyletdatabase\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"}\n" +
+	"\x06Handle\x12\x18\n" +
+	"\x03did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03did\x12\x1e\n" +
+	"\x06handle\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06handle\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"A\n" +
+	"\x10SetHandleRequest\x12-\n" +
+	"\x06handle\x18\x01 \x01(\v2\x15.vyletdatabase.HandleR\x06handle\"8\n" +
+	"\x11SetHandleResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"/\n" +
+	"\x11GetHandlesRequest\x12\x1a\n" +
+	"\x04dids\x18\x01 \x03(\tB\x06\xbaH\x03\xc8\x01\x01R\x04dids\"\xd6\x01\n" +
+	"\x12GetHandlesResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12H\n" +
+	"\ahandles\x18\x02 \x03(\v2..vyletdatabase.GetHandlesResponse.HandlesEntryR\ahandles\x1aQ\n" +
+	"\fHandlesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.vyletdatabase.HandleR\x05value:\x028\x01B\b\n" +
+	"\x06_error\"7\n" +
+	"\x15GetDidByHandleRequest\x12\x1e\n" +
+	"\x06handle\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06handle\"\\\n" +
+	"\x16GetDidByHandleResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12\x15\n" +
+	"\x03did\x18\x02 \x01(\tH\x01R\x03did\x88\x01\x01B\b\n" +
+	"\x06_errorB\x06\n" +
+	"\x04_did2\x93\x02\n" +
+	"\x0fIdentityService\x12N\n" +
+	"\tSetHandle\x12\x1f.vyletdatabase.SetHandleRequest\x1a .vyletdatabase.SetHandleResponse\x12Q\n" +
+	"\n" +
+	"GetHandles\x12 .vyletdatabase.GetHandlesRequest\x1a!.vyletdatabase.GetHandlesResponse\x12]\n" +
+	"\x0eGetDidByHandle\x12$.vyletdatabase.GetDidByHandleRequest\x1a%.vyletdatabase.GetDidByHandleResponseB\x88\x01\n" +
+	"\x11com.vyletdatabaseB\rIdentityProtoP\x01Z\x10./;vyletdatabase\xa2\x02\x03VXX\xaa\x02\rVyletdatabase\xca\x02\rVyletdatabase\xe2\x02\x19Vyletdatabase\\GPBMetadata\xea\x02\rVyletdatabaseb\x06proto3"
+
+var (
+	file_identity_proto_rawDescOnce sync.Once
+	file_identity_proto_rawDescData []byte
+)
+
+func file_identity_proto_rawDescGZIP() []byte {
+	file_identity_proto_rawDescOnce.Do(func() {
+		file_identity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_identity_proto_rawDesc), len(file_identity_proto_rawDesc)))
+	})
+	return file_identity_proto_rawDescData
+}
+
+var file_identity_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_identity_proto_goTypes = []any{
+	(*Handle)(nil),                 // 0: vyletdatabase.Handle
+	(*SetHandleRequest)(nil),       // 1: vyletdatabase.SetHandleRequest
+	(*SetHandleResponse)(nil),      // 2: vyletdatabase.SetHandleResponse
+	(*GetHandlesRequest)(nil),      // 3: vyletdatabase.GetHandlesRequest
+	(*GetHandlesResponse)(nil),     // 4: vyletdatabase.GetHandlesResponse
+	(*GetDidByHandleRequest)(nil),  // 5: vyletdatabase.GetDidByHandleRequest
+	(*GetDidByHandleResponse)(nil), // 6: vyletdatabase.GetDidByHandleResponse
+	nil,                            // 7: vyletdatabase.GetHandlesResponse.HandlesEntry
+	(*timestamppb.Timestamp)(nil),  // 8: google.protobuf.Timestamp
+}
+var file_identity_proto_depIdxs = []int32{
+	8, // 0: vyletdatabase.Handle.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 1: vyletdatabase.SetHandleRequest.handle:type_name -> vyletdatabase.Handle
+	7, // 2: vyletdatabase.GetHandlesResponse.handles:type_name -> vyletdatabase.GetHandlesResponse.HandlesEntry
+	0, // 3: vyletdatabase.GetHandlesResponse.HandlesEntry.value:type_name -> vyletdatabase.Handle
+	1, // 4: vyletdatabase.IdentityService.SetHandle:input_type -> vyletdatabase.SetHandleRequest
+	3, // 5: vyletdatabase.IdentityService.GetHandles:input_type -> vyletdatabase.GetHandlesRequest
+	5, // 6: vyletdatabase.IdentityService.GetDidByHandle:input_type -> vyletdatabase.GetDidByHandleRequest
+	2, // 7: vyletdatabase.IdentityService.SetHandle:output_type -> vyletdatabase.SetHandleResponse
+	4, // 8: vyletdatabase.IdentityService.GetHandles:output_type -> vyletdatabase.GetHandlesResponse
+	6, // 9: vyletdatabase.IdentityService.GetDidByHandle:output_type -> vyletdatabase.GetDidByHandleResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_identity_proto_init() }
+func file_identity_proto_init() {
+	if File_identity_proto != nil {
+		return
+	}
+	file_identity_proto_msgTypes[2].OneofWrappers = []any{}
+	file_identity_proto_msgTypes[4].OneofWrappers = []any{}
+	file_identity_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_identity_proto_rawDesc), len(file_identity_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_identity_proto_goTypes,
+		DependencyIndexes: file_identity_proto_depIdxs,
+		MessageInfos:      file_identity_proto_msgTypes,
+	}.Build()
+	File_identity_proto = out.File
+	file_identity_proto_goTypes = nil
+	file_identity_proto_depIdxs = nil
+}
@@ -118,6 +118,7 @@ func (x *Like) GetIndexedAt() *timestamppb.Timestamp {
 type CreateLikeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Like          *Like                  `protobuf:"bytes,1,opt,name=like,proto3" json:"like,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -159,6 +160,13 @@ func (x *CreateLikeRequest) GetLike() *Like {
 	return nil
 }
 
+func (x *CreateLikeRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
 type CreateLikeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
@@ -203,16 +211,115 @@ func (x *CreateLikeResponse) GetError() string {
 	return ""
 }
 
+// UpdateLikeRequest replaces the like's subject, keeping its original created_at. Returns a not found error when the
+// like has not been indexed.
+type UpdateLikeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Like          *Like                  `protobuf:"bytes,1,opt,name=like,proto3" json:"like,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLikeRequest) Reset() {
+	*x = UpdateLikeRequest{}
+	mi := &file_like_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLikeRequest) ProtoMessage() {}
+
+func (x *UpdateLikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_like_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLikeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLikeRequest) Descriptor() ([]byte, []int) {
+	return file_like_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateLikeRequest) GetLike() *Like {
+	if x != nil {
+		return x.Like
+	}
+	return nil
+}
+
+func (x *UpdateLikeRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+type UpdateLikeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLikeResponse) Reset() {
+	*x = UpdateLikeResponse{}
+	mi := &file_like_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLikeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLikeResponse) ProtoMessage() {}
+
+func (x *UpdateLikeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_like_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLikeResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLikeResponse) Descriptor() ([]byte, []int) {
+	return file_like_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateLikeResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
 type DeleteLikeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteLikeRequest) Reset() {
 	*x = DeleteLikeRequest{}
-	mi := &file_like_proto_msgTypes[3]
+	mi := &file_like_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -224,7 +331,7 @@ func (x *DeleteLikeRequest) String() string {
 func (*DeleteLikeRequest) ProtoMessage() {}
 
 func (x *DeleteLikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_like_proto_msgTypes[3]
+	mi := &file_like_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,7 +344,7 @@ func (x *DeleteLikeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteLikeRequest.ProtoReflect.Descriptor instead.
 func (*DeleteLikeRequest) Descriptor() ([]byte, []int) {
-	return file_like_proto_rawDescGZIP(), []int{3}
+	return file_like_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DeleteLikeRequest) GetUri() string {
@@ -247,6 +354,13 @@ func (x *DeleteLikeRequest) GetUri() string {
 	return ""
 }
 
+func (x *DeleteLikeRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
 type DeleteLikeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
@@ -256,7 +370,7 @@ type DeleteLikeResponse struct {
 
 func (x *DeleteLikeResponse) Reset() {
 	*x = DeleteLikeResponse{}
-	mi := &file_like_proto_msgTypes[4]
+	mi := &file_like_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -268,7 +382,7 @@ func (x *DeleteLikeResponse) String() string {
 func (*DeleteLikeResponse) ProtoMessage() {}
 
 func (x *DeleteLikeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_like_proto_msgTypes[4]
+	mi := &file_like_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,7 +395,7 @@ func (x *DeleteLikeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteLikeResponse.ProtoReflect.Descriptor instead.
 func (*DeleteLikeResponse) Descriptor() ([]byte, []int) {
-	return file_like_proto_rawDescGZIP(), []int{4}
+	return file_like_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteLikeResponse) GetError() string {
@@ -302,7 +416,7 @@ type GetLikesBySubjectRequest struct {
 
 func (x *GetLikesBySubjectRequest) Reset() {
 	*x = GetLikesBySubjectRequest{}
-	mi := &file_like_proto_msgTypes[5]
+	mi := &file_like_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -314,7 +428,7 @@ func (x *GetLikesBySubjectRequest) String() string {
 func (*GetLikesBySubjectRequest) ProtoMessage() {}
 
 func (x *GetLikesBySubjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_like_proto_msgTypes[5]
+	mi := &file_like_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,7 +441,7 @@ func (x *GetLikesBySubjectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLikesBySubjectRequest.ProtoReflect.Descriptor instead.
 func (*GetLikesBySubjectRequest) Descriptor() ([]byte, []int) {
-	return file_like_proto_rawDescGZIP(), []int{5}
+	return file_like_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetLikesBySubjectRequest) GetSubjectUri() string {
@@ -363,7 +477,7 @@ type GetLikesBySubjectResponse struct {
 
 func (x *GetLikesBySubjectResponse) Reset() {
 	*x = GetLikesBySubjectResponse{}
-	mi := &file_like_proto_msgTypes[6]
+	mi := &file_like_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -375,7 +489,7 @@ func (x *GetLikesBySubjectResponse) String() string {
 func (*GetLikesBySubjectResponse) ProtoMessage() {}
 
 func (x *GetLikesBySubjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_like_proto_msgTypes[6]
+	mi := &file_like_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +502,7 @@ func (x *GetLikesBySubjectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLikesBySubjectResponse.ProtoReflect.Descriptor instead.
 func (*GetLikesBySubjectResponse) Descriptor() ([]byte, []int) {
-	return file_like_proto_rawDescGZIP(), []int{6}
+	return file_like_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetLikesBySubjectResponse) GetError() string {
@@ -419,6 +533,134 @@ func (x *GetLikesBySubjectResponse) GetCursor() string {
 	return ""
 }
 
+type GetLikesByActorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Did           string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikesByActorRequest) Reset() {
+	*x = GetLikesByActorRequest{}
+	mi := &file_like_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikesByActorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikesByActorRequest) ProtoMessage() {}
+
+func (x *GetLikesByActorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_like_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikesByActorRequest.ProtoReflect.Descriptor instead.
+func (*GetLikesByActorRequest) Descriptor() ([]byte, []int) {
+	return file_like_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetLikesByActorRequest) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *GetLikesByActorRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetLikesByActorRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type GetLikesByActorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Likes         []*Like                `protobuf:"bytes,2,rep,name=likes,proto3" json:"likes,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        *string                `protobuf:"bytes,4,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikesByActorResponse) Reset() {
+	*x = GetLikesByActorResponse{}
+	mi := &file_like_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikesByActorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikesByActorResponse) ProtoMessage() {}
+
+func (x *GetLikesByActorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_like_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikesByActorResponse.ProtoReflect.Descriptor instead.
+func (*GetLikesByActorResponse) Descriptor() ([]byte, []int) {
+	return file_like_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetLikesByActorResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetLikesByActorResponse) GetLikes() []*Like {
+	if x != nil {
+		return x.Likes
+	}
+	return nil
+}
+
+func (x *GetLikesByActorResponse) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetLikesByActorResponse) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
 var File_like_proto protoreflect.FileDescriptor
 
 const file_like_proto_rawDesc = "" +
@@ -437,14 +679,22 @@ const file_like_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"indexed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\"<\n" +
+	"indexed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\"N\n" +
 	"\x11CreateLikeRequest\x12'\n" +
-	"\x04like\x18\x01 \x01(\v2\x13.vyletdatabase.LikeR\x04like\"9\n" +
+	"\x04like\x18\x01 \x01(\v2\x13.vyletdatabase.LikeR\x04like\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\"9\n" +
 	"\x12CreateLikeResponse\x12\x19\n" +
 	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"-\n" +
+	"\x06_error\"N\n" +
+	"\x11UpdateLikeRequest\x12'\n" +
+	"\x04like\x18\x01 \x01(\v2\x13.vyletdatabase.LikeR\x04like\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\"9\n" +
+	"\x12UpdateLikeResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"?\n" +
 	"\x11DeleteLikeRequest\x12\x18\n" +
-	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\"9\n" +
+	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\"9\n" +
 	"\x12DeleteLikeResponse\x12\x19\n" +
 	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
 	"\x06_error\"\x81\x01\n" +
@@ -460,13 +710,28 @@ const file_like_proto_rawDesc = "" +
 	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x1b\n" +
 	"\x06cursor\x18\x04 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
 	"\x06_errorB\t\n" +
-	"\a_cursor2\x9b\x02\n" +
+	"\a_cursor\"p\n" +
+	"\x16GetLikesByActorRequest\x12\x18\n" +
+	"\x03did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03did\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x00R\x06cursor\x88\x01\x01B\t\n" +
+	"\a_cursor\"\xa7\x01\n" +
+	"\x17GetLikesByActorResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12)\n" +
+	"\x05likes\x18\x02 \x03(\v2\x13.vyletdatabase.LikeR\x05likes\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x1b\n" +
+	"\x06cursor\x18\x04 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_errorB\t\n" +
+	"\a_cursor2\xd0\x03\n" +
 	"\vLikeService\x12Q\n" +
 	"\n" +
 	"CreateLike\x12 .vyletdatabase.CreateLikeRequest\x1a!.vyletdatabase.CreateLikeResponse\x12Q\n" +
 	"\n" +
+	"UpdateLike\x12 .vyletdatabase.UpdateLikeRequest\x1a!.vyletdatabase.UpdateLikeResponse\x12Q\n" +
+	"\n" +
 	"DeleteLike\x12 .vyletdatabase.DeleteLikeRequest\x1a!.vyletdatabase.DeleteLikeResponse\x12f\n" +
-	"\x11GetLikesBySubject\x12'.vyletdatabase.GetLikesBySubjectRequest\x1a(.vyletdatabase.GetLikesBySubjectResponseB\x84\x01\n" +
+	"\x11GetLikesBySubject\x12'.vyletdatabase.GetLikesBySubjectRequest\x1a(.vyletdatabase.GetLikesBySubjectResponse\x12`\n" +
+	"\x0fGetLikesByActor\x12%.vyletdatabase.GetLikesByActorRequest\x1a&.vyletdatabase.GetLikesByActorResponseB\x84\x01\n" +
 	"\x11com.vyletdatabaseB\tLikeProtoP\x01Z\x10./;vyletdatabase\xa2\x02\x03VXX\xaa\x02\rVyletdatabase\xca\x02\rVyletdatabase\xe2\x02\x19Vyletdatabase\\GPBMetadata\xea\x02\rVyletdatabaseb\x06proto3"
 
 var (
@@ -481,33 +746,43 @@ func file_like_proto_rawDescGZIP() []byte {
 	return file_like_proto_rawDescData
 }
 
-var file_like_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_like_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_like_proto_goTypes = []any{
 	(*Like)(nil),                      // 0: vyletdatabase.Like
 	(*CreateLikeRequest)(nil),         // 1: vyletdatabase.CreateLikeRequest
 	(*CreateLikeResponse)(nil),        // 2: vyletdatabase.CreateLikeResponse
-	(*DeleteLikeRequest)(nil),         // 3: vyletdatabase.DeleteLikeRequest
-	(*DeleteLikeResponse)(nil),        // 4: vyletdatabase.DeleteLikeResponse
-	(*GetLikesBySubjectRequest)(nil),  // 5: vyletdatabase.GetLikesBySubjectRequest
-	(*GetLikesBySubjectResponse)(nil), // 6: vyletdatabase.GetLikesBySubjectResponse
-	(*timestamppb.Timestamp)(nil),     // 7: google.protobuf.Timestamp
+	(*UpdateLikeRequest)(nil),         // 3: vyletdatabase.UpdateLikeRequest
+	(*UpdateLikeResponse)(nil),        // 4: vyletdatabase.UpdateLikeResponse
+	(*DeleteLikeRequest)(nil),         // 5: vyletdatabase.DeleteLikeRequest
+	(*DeleteLikeResponse)(nil),        // 6: vyletdatabase.DeleteLikeResponse
+	(*GetLikesBySubjectRequest)(nil),  // 7: vyletdatabase.GetLikesBySubjectRequest
+	(*GetLikesBySubjectResponse)(nil), // 8: vyletdatabase.GetLikesBySubjectResponse
+	(*GetLikesByActorRequest)(nil),    // 9: vyletdatabase.GetLikesByActorRequest
+	(*GetLikesByActorResponse)(nil),   // 10: vyletdatabase.GetLikesByActorResponse
+	(*timestamppb.Timestamp)(nil),     // 11: google.protobuf.Timestamp
 }
 var file_like_proto_depIdxs = []int32{
-	7, // 0: vyletdatabase.Like.created_at:type_name -> google.protobuf.Timestamp
-	7, // 1: vyletdatabase.Like.indexed_at:type_name -> google.protobuf.Timestamp
-	0, // 2: vyletdatabase.CreateLikeRequest.like:type_name -> vyletdatabase.Like
-	0, // 3: vyletdatabase.GetLikesBySubjectResponse.likes:type_name -> vyletdatabase.Like
-	1, // 4: vyletdatabase.LikeService.CreateLike:input_type -> vyletdatabase.CreateLikeRequest
-	3, // 5: vyletdatabase.LikeService.DeleteLike:input_type -> vyletdatabase.DeleteLikeRequest
-	5, // 6: vyletdatabase.LikeService.GetLikesBySubject:input_type -> vyletdatabase.GetLikesBySubjectRequest
-	2, // 7: vyletdatabase.LikeService.CreateLike:output_type -> vyletdatabase.CreateLikeResponse
-	4, // 8: vyletdatabase.LikeService.DeleteLike:output_type -> vyletdatabase.DeleteLikeResponse
-	6, // 9: vyletdatabase.LikeService.GetLikesBySubject:output_type -> vyletdatabase.GetLikesBySubjectResponse
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	11, // 0: vyletdatabase.Like.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: vyletdatabase.Like.indexed_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: vyletdatabase.CreateLikeRequest.like:type_name -> vyletdatabase.Like
+	0,  // 3: vyletdatabase.UpdateLikeRequest.like:type_name -> vyletdatabase.Like
+	0,  // 4: vyletdatabase.GetLikesBySubjectResponse.likes:type_name -> vyletdatabase.Like
+	0,  // 5: vyletdatabase.GetLikesByActorResponse.likes:type_name -> vyletdatabase.Like
+	1,  // 6: vyletdatabase.LikeService.CreateLike:input_type -> vyletdatabase.CreateLikeRequest
+	3,  // 7: vyletdatabase.LikeService.UpdateLike:input_type -> vyletdatabase.UpdateLikeRequest
+	5,  // 8: vyletdatabase.LikeService.DeleteLike:input_type -> vyletdatabase.DeleteLikeRequest
+	7,  // 9: vyletdatabase.LikeService.GetLikesBySubject:input_type -> vyletdatabase.GetLikesBySubjectRequest
+	9,  // 10: vyletdatabase.LikeService.GetLikesByActor:input_type -> vyletdatabase.GetLikesByActorRequest
+	2,  // 11: vyletdatabase.LikeService.CreateLike:output_type -> vyletdatabase.CreateLikeResponse
+	4,  // 12: vyletdatabase.LikeService.UpdateLike:output_type -> vyletdatabase.UpdateLikeResponse
+	6,  // 13: vyletdatabase.LikeService.DeleteLike:output_type -> vyletdatabase.DeleteLikeResponse
+	8,  // 14: vyletdatabase.LikeService.GetLikesBySubject:output_type -> vyletdatabase.GetLikesBySubjectResponse
+	10, // 15: vyletdatabase.LikeService.GetLikesByActor:output_type -> vyletdatabase.GetLikesByActorResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_like_proto_init() }
@@ -517,15 +792,18 @@ func file_like_proto_init() {
 	}
 	file_like_proto_msgTypes[2].OneofWrappers = []any{}
 	file_like_proto_msgTypes[4].OneofWrappers = []any{}
-	file_like_proto_msgTypes[5].OneofWrappers = []any{}
 	file_like_proto_msgTypes[6].OneofWrappers = []any{}
+	file_like_proto_msgTypes[7].OneofWrappers = []any{}
+	file_like_proto_msgTypes[8].OneofWrappers = []any{}
+	file_like_proto_msgTypes[9].OneofWrappers = []any{}
+	file_like_proto_msgTypes[10].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_like_proto_rawDesc), len(file_like_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,8 +20,10 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	LikeService_CreateLike_FullMethodName        = "/vyletdatabase.LikeService/CreateLike"
+	LikeService_UpdateLike_FullMethodName        = "/vyletdatabase.LikeService/UpdateLike"
 	LikeService_DeleteLike_FullMethodName        = "/vyletdatabase.LikeService/DeleteLike"
 	LikeService_GetLikesBySubject_FullMethodName = "/vyletdatabase.LikeService/GetLikesBySubject"
+	LikeService_GetLikesByActor_FullMethodName   = "/vyletdatabase.LikeService/GetLikesByActor"
 )
 
 // LikeServiceClient is the client API for LikeService service.
@@ -29,8 +31,10 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LikeServiceClient interface {
 	CreateLike(ctx context.Context, in *CreateLikeRequest, opts ...grpc.CallOption) (*CreateLikeResponse, error)
+	UpdateLike(ctx context.Context, in *UpdateLikeRequest, opts ...grpc.CallOption) (*UpdateLikeResponse, error)
 	DeleteLike(ctx context.Context, in *DeleteLikeRequest, opts ...grpc.CallOption) (*DeleteLikeResponse, error)
 	GetLikesBySubject(ctx context.Context, in *GetLikesBySubjectRequest, opts ...grpc.CallOption) (*GetLikesBySubjectResponse, error)
+	GetLikesByActor(ctx context.Context, in *GetLikesByActorRequest, opts ...grpc.CallOption) (*GetLikesByActorResponse, error)
 }
 
 type likeServiceClient struct {
@@ -51,6 +55,16 @@ func (c *likeServiceClient) CreateLike(ctx context.Context, in *CreateLikeReques
 	return out, nil
 }
 
+func (c *likeServiceClient) UpdateLike(ctx context.Context, in *UpdateLikeRequest, opts ...grpc.CallOption) (*UpdateLikeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateLikeResponse)
+	err := c.cc.Invoke(ctx, LikeService_UpdateLike_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *likeServiceClient) DeleteLike(ctx context.Context, in *DeleteLikeRequest, opts ...grpc.CallOption) (*DeleteLikeResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteLikeResponse)
@@ -71,13 +85,25 @@ func (c *likeServiceClient) GetLikesBySubject(ctx context.Context, in *GetLikesB
 	return out, nil
 }
 
+func (c *likeServiceClient) GetLikesByActor(ctx context.Context, in *GetLikesByActorRequest, opts ...grpc.CallOption) (*GetLikesByActorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLikesByActorResponse)
+	err := c.cc.Invoke(ctx, LikeService_GetLikesByActor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LikeServiceServer is the server API for LikeService service.
 // All implementations must embed UnimplementedLikeServiceServer
 // for forward compatibility.
 type LikeServiceServer interface {
 	CreateLike(context.Context, *CreateLikeRequest) (*CreateLikeResponse, error)
+	UpdateLike(context.Context, *UpdateLikeRequest) (*UpdateLikeResponse, error)
 	DeleteLike(context.Context, *DeleteLikeRequest) (*DeleteLikeResponse, error)
 	GetLikesBySubject(context.Context, *GetLikesBySubjectRequest) (*GetLikesBySubjectResponse, error)
+	GetLikesByActor(context.Context, *GetLikesByActorRequest) (*GetLikesByActorResponse, error)
 	mustEmbedUnimplementedLikeServiceServer()
 }
 
@@ -91,12 +117,18 @@ type UnimplementedLikeServiceServer struct{}
 func (UnimplementedLikeServiceServer) CreateLike(context.Context, *CreateLikeRequest) (*CreateLikeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateLike not implemented")
 }
+func (UnimplementedLikeServiceServer) UpdateLike(context.Context, *UpdateLikeRequest) (*UpdateLikeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateLike not implemented")
+}
 func (UnimplementedLikeServiceServer) DeleteLike(context.Context, *DeleteLikeRequest) (*DeleteLikeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteLike not implemented")
 }
 func (UnimplementedLikeServiceServer) GetLikesBySubject(context.Context, *GetLikesBySubjectRequest) (*GetLikesBySubjectResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetLikesBySubject not implemented")
 }
+func (UnimplementedLikeServiceServer) GetLikesByActor(context.Context, *GetLikesByActorRequest) (*GetLikesByActorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLikesByActor not implemented")
+}
 func (UnimplementedLikeServiceServer) mustEmbedUnimplementedLikeServiceServer() {}
 func (UnimplementedLikeServiceServer) testEmbeddedByValue()                     {}
 
@@ -136,6 +168,24 @@ func _LikeService_CreateLike_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LikeService_UpdateLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).UpdateLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LikeService_UpdateLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).UpdateLike(ctx, req.(*UpdateLikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LikeService_DeleteLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteLikeRequest)
 	if err := dec(in); err != nil {
@@ -172,6 +222,24 @@ func _LikeService_GetLikesBySubject_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LikeService_GetLikesByActor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLikesByActorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).GetLikesByActor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LikeService_GetLikesByActor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).GetLikesByActor(ctx, req.(*GetLikesByActorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LikeService_ServiceDesc is the grpc.ServiceDesc for LikeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -183,6 +251,10 @@ var LikeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateLike",
 			Handler:    _LikeService_CreateLike_Handler,
 		},
+		{
+			MethodName: "UpdateLike",
+			Handler:    _LikeService_UpdateLike_Handler,
+		},
 		{
 			MethodName: "DeleteLike",
 			Handler:    _LikeService_DeleteLike_Handler,
@@ -191,6 +263,10 @@ var LikeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLikesBySubject",
 			Handler:    _LikeService_GetLikesBySubject_Handler,
 		},
+		{
+			MethodName: "GetLikesByActor",
+			Handler:    _LikeService_GetLikesByActor_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "like.proto",
@@ -0,0 +1,627 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: mute.proto
+
+package vyletdatabase
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Mute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorDid      string                 `protobuf:"bytes,1,opt,name=actor_did,json=actorDid,proto3" json:"actor_did,omitempty"`
+	SubjectDid    string                 `protobuf:"bytes,2,opt,name=subject_did,json=subjectDid,proto3" json:"subject_did,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mute) Reset() {
+	*x = Mute{}
+	mi := &file_mute_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mute) ProtoMessage() {}
+
+func (x *Mute) ProtoReflect() protoreflect.Message {
+	mi := &file_mute_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mute.ProtoReflect.Descriptor instead.
+func (*Mute) Descriptor() ([]byte, []int) {
+	return file_mute_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Mute) GetActorDid() string {
+	if x != nil {
+		return x.ActorDid
+	}
+	return ""
+}
+
+func (x *Mute) GetSubjectDid() string {
+	if x != nil {
+		return x.SubjectDid
+	}
+	return ""
+}
+
+func (x *Mute) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateMuteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorDid      string                 `protobuf:"bytes,1,opt,name=actor_did,json=actorDid,proto3" json:"actor_did,omitempty"`
+	SubjectDid    string                 `protobuf:"bytes,2,opt,name=subject_did,json=subjectDid,proto3" json:"subject_did,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMuteRequest) Reset() {
+	*x = CreateMuteRequest{}
+	mi := &file_mute_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMuteRequest) ProtoMessage() {}
+
+func (x *CreateMuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mute_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMuteRequest.ProtoReflect.Descriptor instead.
+func (*CreateMuteRequest) Descriptor() ([]byte, []int) {
+	return file_mute_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateMuteRequest) GetActorDid() string {
+	if x != nil {
+		return x.ActorDid
+	}
+	return ""
+}
+
+func (x *CreateMuteRequest) GetSubjectDid() string {
+	if x != nil {
+		return x.SubjectDid
+	}
+	return ""
+}
+
+type CreateMuteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMuteResponse) Reset() {
+	*x = CreateMuteResponse{}
+	mi := &file_mute_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMuteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMuteResponse) ProtoMessage() {}
+
+func (x *CreateMuteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mute_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMuteResponse.ProtoReflect.Descriptor instead.
+func (*CreateMuteResponse) Descriptor() ([]byte, []int) {
+	return file_mute_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateMuteResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type DeleteMuteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorDid      string                 `protobuf:"bytes,1,opt,name=actor_did,json=actorDid,proto3" json:"actor_did,omitempty"`
+	SubjectDid    string                 `protobuf:"bytes,2,opt,name=subject_did,json=subjectDid,proto3" json:"subject_did,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMuteRequest) Reset() {
+	*x = DeleteMuteRequest{}
+	mi := &file_mute_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMuteRequest) ProtoMessage() {}
+
+func (x *DeleteMuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mute_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMuteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMuteRequest) Descriptor() ([]byte, []int) {
+	return file_mute_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteMuteRequest) GetActorDid() string {
+	if x != nil {
+		return x.ActorDid
+	}
+	return ""
+}
+
+func (x *DeleteMuteRequest) GetSubjectDid() string {
+	if x != nil {
+		return x.SubjectDid
+	}
+	return ""
+}
+
+type DeleteMuteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMuteResponse) Reset() {
+	*x = DeleteMuteResponse{}
+	mi := &file_mute_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMuteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMuteResponse) ProtoMessage() {}
+
+func (x *DeleteMuteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mute_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMuteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMuteResponse) Descriptor() ([]byte, []int) {
+	return file_mute_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteMuteResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type GetMutesByActorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Did           string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMutesByActorRequest) Reset() {
+	*x = GetMutesByActorRequest{}
+	mi := &file_mute_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMutesByActorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMutesByActorRequest) ProtoMessage() {}
+
+func (x *GetMutesByActorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mute_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMutesByActorRequest.ProtoReflect.Descriptor instead.
+func (*GetMutesByActorRequest) Descriptor() ([]byte, []int) {
+	return file_mute_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetMutesByActorRequest) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *GetMutesByActorRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetMutesByActorRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type GetMutesByActorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Mutes         []*Mute                `protobuf:"bytes,2,rep,name=mutes,proto3" json:"mutes,omitempty"`
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMutesByActorResponse) Reset() {
+	*x = GetMutesByActorResponse{}
+	mi := &file_mute_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMutesByActorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMutesByActorResponse) ProtoMessage() {}
+
+func (x *GetMutesByActorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mute_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMutesByActorResponse.ProtoReflect.Descriptor instead.
+func (*GetMutesByActorResponse) Descriptor() ([]byte, []int) {
+	return file_mute_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetMutesByActorResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetMutesByActorResponse) GetMutes() []*Mute {
+	if x != nil {
+		return x.Mutes
+	}
+	return nil
+}
+
+func (x *GetMutesByActorResponse) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type GetMutedDidsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorDid      string                 `protobuf:"bytes,1,opt,name=actor_did,json=actorDid,proto3" json:"actor_did,omitempty"`
+	Dids          []string               `protobuf:"bytes,2,rep,name=dids,proto3" json:"dids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMutedDidsRequest) Reset() {
+	*x = GetMutedDidsRequest{}
+	mi := &file_mute_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMutedDidsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMutedDidsRequest) ProtoMessage() {}
+
+func (x *GetMutedDidsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mute_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMutedDidsRequest.ProtoReflect.Descriptor instead.
+func (*GetMutedDidsRequest) Descriptor() ([]byte, []int) {
+	return file_mute_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMutedDidsRequest) GetActorDid() string {
+	if x != nil {
+		return x.ActorDid
+	}
+	return ""
+}
+
+func (x *GetMutedDidsRequest) GetDids() []string {
+	if x != nil {
+		return x.Dids
+	}
+	return nil
+}
+
+type GetMutedDidsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Error *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	// the subset of the requested dids that the actor has muted
+	MutedDids     []string `protobuf:"bytes,2,rep,name=muted_dids,json=mutedDids,proto3" json:"muted_dids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMutedDidsResponse) Reset() {
+	*x = GetMutedDidsResponse{}
+	mi := &file_mute_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMutedDidsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMutedDidsResponse) ProtoMessage() {}
+
+func (x *GetMutedDidsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mute_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMutedDidsResponse.ProtoReflect.Descriptor instead.
+func (*GetMutedDidsResponse) Descriptor() ([]byte, []int) {
+	return file_mute_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetMutedDidsResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetMutedDidsResponse) GetMutedDids() []string {
+	if x != nil {
+		return x.MutedDids
+	}
+	return nil
+}
+
+var File_mute_proto protoreflect.FileDescriptor
+
+const file_mute_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"mute.proto\x12\rvyletdatabase\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8f\x01\n" +
+	"\x04Mute\x12#\n" +
+	"\tactor_did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bactorDid\x12'\n" +
+	"\vsubject_did\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"subjectDid\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"a\n" +
+	"\x11CreateMuteRequest\x12#\n" +
+	"\tactor_did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bactorDid\x12'\n" +
+	"\vsubject_did\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"subjectDid\"9\n" +
+	"\x12CreateMuteResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"a\n" +
+	"\x11DeleteMuteRequest\x12#\n" +
+	"\tactor_did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bactorDid\x12'\n" +
+	"\vsubject_did\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"subjectDid\"9\n" +
+	"\x12DeleteMuteResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"p\n" +
+	"\x16GetMutesByActorRequest\x12\x18\n" +
+	"\x03did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03did\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x00R\x06cursor\x88\x01\x01B\t\n" +
+	"\a_cursor\"\x91\x01\n" +
+	"\x17GetMutesByActorResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12)\n" +
+	"\x05mutes\x18\x02 \x03(\v2\x13.vyletdatabase.MuteR\x05mutes\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_errorB\t\n" +
+	"\a_cursor\"N\n" +
+	"\x13GetMutedDidsRequest\x12#\n" +
+	"\tactor_did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bactorDid\x12\x12\n" +
+	"\x04dids\x18\x02 \x03(\tR\x04dids\"Z\n" +
+	"\x14GetMutedDidsResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"muted_dids\x18\x02 \x03(\tR\tmutedDidsB\b\n" +
+	"\x06_error2\xee\x02\n" +
+	"\vMuteService\x12Q\n" +
+	"\n" +
+	"CreateMute\x12 .vyletdatabase.CreateMuteRequest\x1a!.vyletdatabase.CreateMuteResponse\x12Q\n" +
+	"\n" +
+	"DeleteMute\x12 .vyletdatabase.DeleteMuteRequest\x1a!.vyletdatabase.DeleteMuteResponse\x12`\n" +
+	"\x0fGetMutesByActor\x12%.vyletdatabase.GetMutesByActorRequest\x1a&.vyletdatabase.GetMutesByActorResponse\x12W\n" +
+	"\fGetMutedDids\x12\".vyletdatabase.GetMutedDidsRequest\x1a#.vyletdatabase.GetMutedDidsResponseB\x84\x01\n" +
+	"\x11com.vyletdatabaseB\tMuteProtoP\x01Z\x10./;vyletdatabase\xa2\x02\x03VXX\xaa\x02\rVyletdatabase\xca\x02\rVyletdatabase\xe2\x02\x19Vyletdatabase\\GPBMetadata\xea\x02\rVyletdatabaseb\x06proto3"
+
+var (
+	file_mute_proto_rawDescOnce sync.Once
+	file_mute_proto_rawDescData []byte
+)
+
+func file_mute_proto_rawDescGZIP() []byte {
+	file_mute_proto_rawDescOnce.Do(func() {
+		file_mute_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mute_proto_rawDesc), len(file_mute_proto_rawDesc)))
+	})
+	return file_mute_proto_rawDescData
+}
+
+var file_mute_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_mute_proto_goTypes = []any{
+	(*Mute)(nil),                    // 0: vyletdatabase.Mute
+	(*CreateMuteRequest)(nil),       // 1: vyletdatabase.CreateMuteRequest
+	(*CreateMuteResponse)(nil),      // 2: vyletdatabase.CreateMuteResponse
+	(*DeleteMuteRequest)(nil),       // 3: vyletdatabase.DeleteMuteRequest
+	(*DeleteMuteResponse)(nil),      // 4: vyletdatabase.DeleteMuteResponse
+	(*GetMutesByActorRequest)(nil),  // 5: vyletdatabase.GetMutesByActorRequest
+	(*GetMutesByActorResponse)(nil), // 6: vyletdatabase.GetMutesByActorResponse
+	(*GetMutedDidsRequest)(nil),     // 7: vyletdatabase.GetMutedDidsRequest
+	(*GetMutedDidsResponse)(nil),    // 8: vyletdatabase.GetMutedDidsResponse
+	(*timestamppb.Timestamp)(nil),   // 9: google.protobuf.Timestamp
+}
+var file_mute_proto_depIdxs = []int32{
+	9, // 0: vyletdatabase.Mute.created_at:type_name -> google.protobuf.Timestamp
+	0, // 1: vyletdatabase.GetMutesByActorResponse.mutes:type_name -> vyletdatabase.Mute
+	1, // 2: vyletdatabase.MuteService.CreateMute:input_type -> vyletdatabase.CreateMuteRequest
+	3, // 3: vyletdatabase.MuteService.DeleteMute:input_type -> vyletdatabase.DeleteMuteRequest
+	5, // 4: vyletdatabase.MuteService.GetMutesByActor:input_type -> vyletdatabase.GetMutesByActorRequest
+	7, // 5: vyletdatabase.MuteService.GetMutedDids:input_type -> vyletdatabase.GetMutedDidsRequest
+	2, // 6: vyletdatabase.MuteService.CreateMute:output_type -> vyletdatabase.CreateMuteResponse
+	4, // 7: vyletdatabase.MuteService.DeleteMute:output_type -> vyletdatabase.DeleteMuteResponse
+	6, // 8: vyletdatabase.MuteService.GetMutesByActor:output_type -> vyletdatabase.GetMutesByActorResponse
+	8, // 9: vyletdatabase.MuteService.GetMutedDids:output_type -> vyletdatabase.GetMutedDidsResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_mute_proto_init() }
+func file_mute_proto_init() {
+	if File_mute_proto != nil {
+		return
+	}
+	file_mute_proto_msgTypes[2].OneofWrappers = []any{}
+	file_mute_proto_msgTypes[4].OneofWrappers = []any{}
+	file_mute_proto_msgTypes[5].OneofWrappers = []any{}
+	file_mute_proto_msgTypes[6].OneofWrappers = []any{}
+	file_mute_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mute_proto_rawDesc), len(file_mute_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mute_proto_goTypes,
+		DependencyIndexes: file_mute_proto_depIdxs,
+		MessageInfos:      file_mute_proto_msgTypes,
+	}.Build()
+	File_mute_proto = out.File
+	file_mute_proto_goTypes = nil
+	file_mute_proto_depIdxs = nil
+}
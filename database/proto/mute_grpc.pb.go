@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: mute.proto
+
+package vyletdatabase
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MuteService_CreateMute_FullMethodName      = "/vyletdatabase.MuteService/CreateMute"
+	MuteService_DeleteMute_FullMethodName      = "/vyletdatabase.MuteService/DeleteMute"
+	MuteService_GetMutesByActor_FullMethodName = "/vyletdatabase.MuteService/GetMutesByActor"
+	MuteService_GetMutedDids_FullMethodName    = "/vyletdatabase.MuteService/GetMutedDids"
+)
+
+// MuteServiceClient is the client API for MuteService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MuteService stores mutes, which are private to the muting actor and have no record in their repo
+type MuteServiceClient interface {
+	CreateMute(ctx context.Context, in *CreateMuteRequest, opts ...grpc.CallOption) (*CreateMuteResponse, error)
+	DeleteMute(ctx context.Context, in *DeleteMuteRequest, opts ...grpc.CallOption) (*DeleteMuteResponse, error)
+	GetMutesByActor(ctx context.Context, in *GetMutesByActorRequest, opts ...grpc.CallOption) (*GetMutesByActorResponse, error)
+	GetMutedDids(ctx context.Context, in *GetMutedDidsRequest, opts ...grpc.CallOption) (*GetMutedDidsResponse, error)
+}
+
+type muteServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMuteServiceClient(cc grpc.ClientConnInterface) MuteServiceClient {
+	return &muteServiceClient{cc}
+}
+
+func (c *muteServiceClient) CreateMute(ctx context.Context, in *CreateMuteRequest, opts ...grpc.CallOption) (*CreateMuteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateMuteResponse)
+	err := c.cc.Invoke(ctx, MuteService_CreateMute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *muteServiceClient) DeleteMute(ctx context.Context, in *DeleteMuteRequest, opts ...grpc.CallOption) (*DeleteMuteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMuteResponse)
+	err := c.cc.Invoke(ctx, MuteService_DeleteMute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *muteServiceClient) GetMutesByActor(ctx context.Context, in *GetMutesByActorRequest, opts ...grpc.CallOption) (*GetMutesByActorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMutesByActorResponse)
+	err := c.cc.Invoke(ctx, MuteService_GetMutesByActor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *muteServiceClient) GetMutedDids(ctx context.Context, in *GetMutedDidsRequest, opts ...grpc.CallOption) (*GetMutedDidsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMutedDidsResponse)
+	err := c.cc.Invoke(ctx, MuteService_GetMutedDids_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MuteServiceServer is the server API for MuteService service.
+// All implementations must embed UnimplementedMuteServiceServer
+// for forward compatibility.
+//
+// MuteService stores mutes, which are private to the muting actor and have no record in their repo
+type MuteServiceServer interface {
+	CreateMute(context.Context, *CreateMuteRequest) (*CreateMuteResponse, error)
+	DeleteMute(context.Context, *DeleteMuteRequest) (*DeleteMuteResponse, error)
+	GetMutesByActor(context.Context, *GetMutesByActorRequest) (*GetMutesByActorResponse, error)
+	GetMutedDids(context.Context, *GetMutedDidsRequest) (*GetMutedDidsResponse, error)
+	mustEmbedUnimplementedMuteServiceServer()
+}
+
+// UnimplementedMuteServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMuteServiceServer struct{}
+
+func (UnimplementedMuteServiceServer) CreateMute(context.Context, *CreateMuteRequest) (*CreateMuteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateMute not implemented")
+}
+func (UnimplementedMuteServiceServer) DeleteMute(context.Context, *DeleteMuteRequest) (*DeleteMuteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteMute not implemented")
+}
+func (UnimplementedMuteServiceServer) GetMutesByActor(context.Context, *GetMutesByActorRequest) (*GetMutesByActorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMutesByActor not implemented")
+}
+func (UnimplementedMuteServiceServer) GetMutedDids(context.Context, *GetMutedDidsRequest) (*GetMutedDidsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMutedDids not implemented")
+}
+func (UnimplementedMuteServiceServer) mustEmbedUnimplementedMuteServiceServer() {}
+func (UnimplementedMuteServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeMuteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MuteServiceServer will
+// result in compilation errors.
+type UnsafeMuteServiceServer interface {
+	mustEmbedUnimplementedMuteServiceServer()
+}
+
+func RegisterMuteServiceServer(s grpc.ServiceRegistrar, srv MuteServiceServer) {
+	// If the following call panics, it indicates UnimplementedMuteServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MuteService_ServiceDesc, srv)
+}
+
+func _MuteService_CreateMute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MuteServiceServer).CreateMute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MuteService_CreateMute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MuteServiceServer).CreateMute(ctx, req.(*CreateMuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MuteService_DeleteMute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MuteServiceServer).DeleteMute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MuteService_DeleteMute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MuteServiceServer).DeleteMute(ctx, req.(*DeleteMuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MuteService_GetMutesByActor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMutesByActorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MuteServiceServer).GetMutesByActor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MuteService_GetMutesByActor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MuteServiceServer).GetMutesByActor(ctx, req.(*GetMutesByActorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MuteService_GetMutedDids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMutedDidsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MuteServiceServer).GetMutedDids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MuteService_GetMutedDids_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MuteServiceServer).GetMutedDids(ctx, req.(*GetMutedDidsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MuteService_ServiceDesc is the grpc.ServiceDesc for MuteService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MuteService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vyletdatabase.MuteService",
+	HandlerType: (*MuteServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMute",
+			Handler:    _MuteService_CreateMute_Handler,
+		},
+		{
+			MethodName: "DeleteMute",
+			Handler:    _MuteService_DeleteMute_Handler,
+		},
+		{
+			MethodName: "GetMutesByActor",
+			Handler:    _MuteService_GetMutesByActor_Handler,
+		},
+		{
+			MethodName: "GetMutedDids",
+			Handler:    _MuteService_GetMutedDids_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mute.proto",
+}
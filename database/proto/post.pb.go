@@ -108,15 +108,17 @@ func (x *Image) GetMime() string {
 }
 
 type Post struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
-	Cid           string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
-	AuthorDid     string                 `protobuf:"bytes,3,opt,name=author_did,json=authorDid,proto3" json:"author_did,omitempty"`
-	Images        []*Image               `protobuf:"bytes,4,rep,name=images,proto3" json:"images,omitempty"`
-	Caption       *string                `protobuf:"bytes,5,opt,name=caption,proto3,oneof" json:"caption,omitempty"`
-	Facets        []byte                 `protobuf:"bytes,6,opt,name=facets,proto3,oneof" json:"facets,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	IndexedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Uri       string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Cid       string                 `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	AuthorDid string                 `protobuf:"bytes,3,opt,name=author_did,json=authorDid,proto3" json:"author_did,omitempty"`
+	Images    []*Image               `protobuf:"bytes,4,rep,name=images,proto3" json:"images,omitempty"`
+	Caption   *string                `protobuf:"bytes,5,opt,name=caption,proto3,oneof" json:"caption,omitempty"`
+	Facets    []byte                 `protobuf:"bytes,6,opt,name=facets,proto3,oneof" json:"facets,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IndexedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	// edited_at is set once the post has been updated, and is the time of the latest update
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=edited_at,json=editedAt,proto3,oneof" json:"edited_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -207,9 +209,20 @@ func (x *Post) GetIndexedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Post) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
 type CreatePostRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Post          *Post                  `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Post  *Post                  `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	// rev is the repo rev of the commit being applied. When set, writes that are not newer than the last one applied
+	// to the record are skipped, and deletes leave a tombstone behind, so that redelivered or reordered commits leave
+	// the database as it was.
+	Rev           string `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -251,6 +264,13 @@ func (x *CreatePostRequest) GetPost() *Post {
 	return nil
 }
 
+func (x *CreatePostRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
 type CreatePostResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
@@ -295,16 +315,115 @@ func (x *CreatePostResponse) GetError() string {
 	return ""
 }
 
+// UpdatePostRequest replaces the post's content, keeping its original created_at. Returns a not found error when the
+// post has not been indexed.
+type UpdatePostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *Post                  `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePostRequest) Reset() {
+	*x = UpdatePostRequest{}
+	mi := &file_post_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePostRequest) ProtoMessage() {}
+
+func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePostRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePostRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdatePostRequest) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *UpdatePostRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+type UpdatePostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePostResponse) Reset() {
+	*x = UpdatePostResponse{}
+	mi := &file_post_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePostResponse) ProtoMessage() {}
+
+func (x *UpdatePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePostResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePostResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdatePostResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
 type DeletePostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeletePostRequest) Reset() {
 	*x = DeletePostRequest{}
-	mi := &file_post_proto_msgTypes[4]
+	mi := &file_post_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -316,7 +435,7 @@ func (x *DeletePostRequest) String() string {
 func (*DeletePostRequest) ProtoMessage() {}
 
 func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[4]
+	mi := &file_post_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -329,7 +448,7 @@ func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
 func (*DeletePostRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{4}
+	return file_post_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeletePostRequest) GetUri() string {
@@ -339,6 +458,13 @@ func (x *DeletePostRequest) GetUri() string {
 	return ""
 }
 
+func (x *DeletePostRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
 type DeletePostResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
@@ -348,7 +474,7 @@ type DeletePostResponse struct {
 
 func (x *DeletePostResponse) Reset() {
 	*x = DeletePostResponse{}
-	mi := &file_post_proto_msgTypes[5]
+	mi := &file_post_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -360,7 +486,7 @@ func (x *DeletePostResponse) String() string {
 func (*DeletePostResponse) ProtoMessage() {}
 
 func (x *DeletePostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[5]
+	mi := &file_post_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +499,7 @@ func (x *DeletePostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePostResponse.ProtoReflect.Descriptor instead.
 func (*DeletePostResponse) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{5}
+	return file_post_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeletePostResponse) GetError() string {
@@ -392,7 +518,7 @@ type GetPostsRequest struct {
 
 func (x *GetPostsRequest) Reset() {
 	*x = GetPostsRequest{}
-	mi := &file_post_proto_msgTypes[6]
+	mi := &file_post_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -404,7 +530,7 @@ func (x *GetPostsRequest) String() string {
 func (*GetPostsRequest) ProtoMessage() {}
 
 func (x *GetPostsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[6]
+	mi := &file_post_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -417,7 +543,7 @@ func (x *GetPostsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostsRequest.ProtoReflect.Descriptor instead.
 func (*GetPostsRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{6}
+	return file_post_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetPostsRequest) GetUris() []string {
@@ -437,7 +563,7 @@ type GetPostsResponse struct {
 
 func (x *GetPostsResponse) Reset() {
 	*x = GetPostsResponse{}
-	mi := &file_post_proto_msgTypes[7]
+	mi := &file_post_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -449,7 +575,7 @@ func (x *GetPostsResponse) String() string {
 func (*GetPostsResponse) ProtoMessage() {}
 
 func (x *GetPostsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[7]
+	mi := &file_post_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +588,7 @@ func (x *GetPostsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostsResponse.ProtoReflect.Descriptor instead.
 func (*GetPostsResponse) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{7}
+	return file_post_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetPostsResponse) GetError() string {
@@ -490,7 +616,7 @@ type GetPostsByActorRequest struct {
 
 func (x *GetPostsByActorRequest) Reset() {
 	*x = GetPostsByActorRequest{}
-	mi := &file_post_proto_msgTypes[8]
+	mi := &file_post_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -502,7 +628,7 @@ func (x *GetPostsByActorRequest) String() string {
 func (*GetPostsByActorRequest) ProtoMessage() {}
 
 func (x *GetPostsByActorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[8]
+	mi := &file_post_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +641,7 @@ func (x *GetPostsByActorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostsByActorRequest.ProtoReflect.Descriptor instead.
 func (*GetPostsByActorRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{8}
+	return file_post_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetPostsByActorRequest) GetDid() string {
@@ -550,7 +676,7 @@ type GetPostsByActorResponse struct {
 
 func (x *GetPostsByActorResponse) Reset() {
 	*x = GetPostsByActorResponse{}
-	mi := &file_post_proto_msgTypes[9]
+	mi := &file_post_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -562,7 +688,7 @@ func (x *GetPostsByActorResponse) String() string {
 func (*GetPostsByActorResponse) ProtoMessage() {}
 
 func (x *GetPostsByActorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[9]
+	mi := &file_post_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -575,7 +701,7 @@ func (x *GetPostsByActorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostsByActorResponse.ProtoReflect.Descriptor instead.
 func (*GetPostsByActorResponse) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{9}
+	return file_post_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetPostsByActorResponse) GetError() string {
@@ -608,7 +734,7 @@ type GetPostInteractionCountsRequest struct {
 
 func (x *GetPostInteractionCountsRequest) Reset() {
 	*x = GetPostInteractionCountsRequest{}
-	mi := &file_post_proto_msgTypes[10]
+	mi := &file_post_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -620,7 +746,7 @@ func (x *GetPostInteractionCountsRequest) String() string {
 func (*GetPostInteractionCountsRequest) ProtoMessage() {}
 
 func (x *GetPostInteractionCountsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[10]
+	mi := &file_post_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -633,7 +759,7 @@ func (x *GetPostInteractionCountsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostInteractionCountsRequest.ProtoReflect.Descriptor instead.
 func (*GetPostInteractionCountsRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{10}
+	return file_post_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetPostInteractionCountsRequest) GetUri() string {
@@ -643,6 +769,8 @@ func (x *GetPostInteractionCountsRequest) GetUri() string {
 	return ""
 }
 
+// PostInteractionCounts are also kept for comments, where replies counts only the direct replies. For posts it
+// counts every comment in the thread.
 type PostInteractionCounts struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Likes         int64                  `protobuf:"varint,1,opt,name=likes,proto3" json:"likes,omitempty"`
@@ -653,7 +781,7 @@ type PostInteractionCounts struct {
 
 func (x *PostInteractionCounts) Reset() {
 	*x = PostInteractionCounts{}
-	mi := &file_post_proto_msgTypes[11]
+	mi := &file_post_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -665,7 +793,7 @@ func (x *PostInteractionCounts) String() string {
 func (*PostInteractionCounts) ProtoMessage() {}
 
 func (x *PostInteractionCounts) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[11]
+	mi := &file_post_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -678,7 +806,7 @@ func (x *PostInteractionCounts) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostInteractionCounts.ProtoReflect.Descriptor instead.
 func (*PostInteractionCounts) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{11}
+	return file_post_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PostInteractionCounts) GetLikes() int64 {
@@ -705,7 +833,7 @@ type GetPostInteractionCountsResponse struct {
 
 func (x *GetPostInteractionCountsResponse) Reset() {
 	*x = GetPostInteractionCountsResponse{}
-	mi := &file_post_proto_msgTypes[12]
+	mi := &file_post_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -717,7 +845,7 @@ func (x *GetPostInteractionCountsResponse) String() string {
 func (*GetPostInteractionCountsResponse) ProtoMessage() {}
 
 func (x *GetPostInteractionCountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[12]
+	mi := &file_post_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -730,7 +858,7 @@ func (x *GetPostInteractionCountsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostInteractionCountsResponse.ProtoReflect.Descriptor instead.
 func (*GetPostInteractionCountsResponse) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{12}
+	return file_post_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetPostInteractionCountsResponse) GetError() string {
@@ -756,7 +884,7 @@ type GetPostsInteractionCountsRequest struct {
 
 func (x *GetPostsInteractionCountsRequest) Reset() {
 	*x = GetPostsInteractionCountsRequest{}
-	mi := &file_post_proto_msgTypes[13]
+	mi := &file_post_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -768,7 +896,7 @@ func (x *GetPostsInteractionCountsRequest) String() string {
 func (*GetPostsInteractionCountsRequest) ProtoMessage() {}
 
 func (x *GetPostsInteractionCountsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[13]
+	mi := &file_post_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -781,7 +909,7 @@ func (x *GetPostsInteractionCountsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostsInteractionCountsRequest.ProtoReflect.Descriptor instead.
 func (*GetPostsInteractionCountsRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{13}
+	return file_post_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetPostsInteractionCountsRequest) GetUris() []string {
@@ -801,7 +929,7 @@ type GetPostsInteractionCountsResponse struct {
 
 func (x *GetPostsInteractionCountsResponse) Reset() {
 	*x = GetPostsInteractionCountsResponse{}
-	mi := &file_post_proto_msgTypes[14]
+	mi := &file_post_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -813,7 +941,7 @@ func (x *GetPostsInteractionCountsResponse) String() string {
 func (*GetPostsInteractionCountsResponse) ProtoMessage() {}
 
 func (x *GetPostsInteractionCountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[14]
+	mi := &file_post_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -826,7 +954,7 @@ func (x *GetPostsInteractionCountsResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use GetPostsInteractionCountsResponse.ProtoReflect.Descriptor instead.
 func (*GetPostsInteractionCountsResponse) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{14}
+	return file_post_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetPostsInteractionCountsResponse) GetError() string {
@@ -843,6 +971,196 @@ func (x *GetPostsInteractionCountsResponse) GetCounts() map[string]*PostInteract
 	return nil
 }
 
+// PostEdit is the content of a post as it was before one of its updates
+type PostEdit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cid           string                 `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Caption       *string                `protobuf:"bytes,2,opt,name=caption,proto3,oneof" json:"caption,omitempty"`
+	Facets        []byte                 `protobuf:"bytes,3,opt,name=facets,proto3,oneof" json:"facets,omitempty"`
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostEdit) Reset() {
+	*x = PostEdit{}
+	mi := &file_post_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostEdit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostEdit) ProtoMessage() {}
+
+func (x *PostEdit) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostEdit.ProtoReflect.Descriptor instead.
+func (*PostEdit) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PostEdit) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *PostEdit) GetCaption() string {
+	if x != nil && x.Caption != nil {
+		return *x.Caption
+	}
+	return ""
+}
+
+func (x *PostEdit) GetFacets() []byte {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *PostEdit) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+type GetPostEditsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostEditsRequest) Reset() {
+	*x = GetPostEditsRequest{}
+	mi := &file_post_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostEditsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostEditsRequest) ProtoMessage() {}
+
+func (x *GetPostEditsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostEditsRequest.ProtoReflect.Descriptor instead.
+func (*GetPostEditsRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetPostEditsRequest) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *GetPostEditsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetPostEditsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+// GetPostEditsResponse lists the post's edits, newest first
+type GetPostEditsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Edits         []*PostEdit            `protobuf:"bytes,2,rep,name=edits,proto3" json:"edits,omitempty"`
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostEditsResponse) Reset() {
+	*x = GetPostEditsResponse{}
+	mi := &file_post_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostEditsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostEditsResponse) ProtoMessage() {}
+
+func (x *GetPostEditsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostEditsResponse.ProtoReflect.Descriptor instead.
+func (*GetPostEditsResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPostEditsResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetPostEditsResponse) GetEdits() []*PostEdit {
+	if x != nil {
+		return x.Edits
+	}
+	return nil
+}
+
+func (x *GetPostEditsResponse) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
 var File_post_proto protoreflect.FileDescriptor
 
 const file_post_proto_rawDesc = "" +
@@ -858,7 +1176,7 @@ const file_post_proto_rawDesc = "" +
 	"\x04mime\x18\x06 \x01(\tR\x04mimeB\x06\n" +
 	"\x04_altB\b\n" +
 	"\x06_widthB\t\n" +
-	"\a_height\"\xd8\x02\n" +
+	"\a_height\"\xa4\x03\n" +
 	"\x04Post\x12\x18\n" +
 	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\x12\x18\n" +
 	"\x03cid\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03cid\x12%\n" +
@@ -870,17 +1188,28 @@ const file_post_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"indexed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAtB\n" +
+	"indexed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\x12<\n" +
+	"\tedited_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x02R\beditedAt\x88\x01\x01B\n" +
 	"\n" +
 	"\b_captionB\t\n" +
-	"\a_facets\"<\n" +
+	"\a_facetsB\f\n" +
+	"\n" +
+	"_edited_at\"N\n" +
 	"\x11CreatePostRequest\x12'\n" +
-	"\x04post\x18\x01 \x01(\v2\x13.vyletdatabase.PostR\x04post\"9\n" +
+	"\x04post\x18\x01 \x01(\v2\x13.vyletdatabase.PostR\x04post\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\"9\n" +
 	"\x12CreatePostResponse\x12\x19\n" +
 	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"-\n" +
+	"\x06_error\"N\n" +
+	"\x11UpdatePostRequest\x12'\n" +
+	"\x04post\x18\x01 \x01(\v2\x13.vyletdatabase.PostR\x04post\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\"9\n" +
+	"\x12UpdatePostResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"?\n" +
 	"\x11DeletePostRequest\x12\x18\n" +
-	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\"9\n" +
+	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\"9\n" +
 	"\x12DeletePostResponse\x12\x19\n" +
 	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
 	"\x06_error\"-\n" +
@@ -927,16 +1256,38 @@ const file_post_proto_rawDesc = "" +
 	"\vCountsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12:\n" +
 	"\x05value\x18\x02 \x01(\v2$.vyletdatabase.PostInteractionCountsR\x05value:\x028\x01B\b\n" +
-	"\x06_error2\xdf\x04\n" +
+	"\x06_error\"\xa8\x01\n" +
+	"\bPostEdit\x12\x10\n" +
+	"\x03cid\x18\x01 \x01(\tR\x03cid\x12\x1d\n" +
+	"\acaption\x18\x02 \x01(\tH\x00R\acaption\x88\x01\x01\x12\x1b\n" +
+	"\x06facets\x18\x03 \x01(\fH\x01R\x06facets\x88\x01\x01\x127\n" +
+	"\tedited_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\beditedAtB\n" +
+	"\n" +
+	"\b_captionB\t\n" +
+	"\a_facets\"u\n" +
+	"\x13GetPostEditsRequest\x12\x18\n" +
+	"\x03uri\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03uri\x12\x1c\n" +
+	"\x05limit\x18\x02 \x01(\x03B\x06\xbaH\x03\xc8\x01\x01R\x05limit\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x00R\x06cursor\x88\x01\x01B\t\n" +
+	"\a_cursor\"\x92\x01\n" +
+	"\x14GetPostEditsResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12-\n" +
+	"\x05edits\x18\x02 \x03(\v2\x17.vyletdatabase.PostEditR\x05edits\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_errorB\t\n" +
+	"\a_cursor2\x8b\x06\n" +
 	"\vPostService\x12Q\n" +
 	"\n" +
 	"CreatePost\x12 .vyletdatabase.CreatePostRequest\x1a!.vyletdatabase.CreatePostResponse\x12Q\n" +
 	"\n" +
+	"UpdatePost\x12 .vyletdatabase.UpdatePostRequest\x1a!.vyletdatabase.UpdatePostResponse\x12Q\n" +
+	"\n" +
 	"DeletePost\x12 .vyletdatabase.DeletePostRequest\x1a!.vyletdatabase.DeletePostResponse\x12K\n" +
 	"\bGetPosts\x12\x1e.vyletdatabase.GetPostsRequest\x1a\x1f.vyletdatabase.GetPostsResponse\x12`\n" +
 	"\x0fGetPostsByActor\x12%.vyletdatabase.GetPostsByActorRequest\x1a&.vyletdatabase.GetPostsByActorResponse\x12{\n" +
 	"\x18GetPostInteractionCounts\x12..vyletdatabase.GetPostInteractionCountsRequest\x1a/.vyletdatabase.GetPostInteractionCountsResponse\x12~\n" +
-	"\x19GetPostsInteractionCounts\x12/.vyletdatabase.GetPostsInteractionCountsRequest\x1a0.vyletdatabase.GetPostsInteractionCountsResponseB\x84\x01\n" +
+	"\x19GetPostsInteractionCounts\x12/.vyletdatabase.GetPostsInteractionCountsRequest\x1a0.vyletdatabase.GetPostsInteractionCountsResponse\x12W\n" +
+	"\fGetPostEdits\x12\".vyletdatabase.GetPostEditsRequest\x1a#.vyletdatabase.GetPostEditsResponseB\x84\x01\n" +
 	"\x11com.vyletdatabaseB\tPostProtoP\x01Z\x10./;vyletdatabase\xa2\x02\x03VXX\xaa\x02\rVyletdatabase\xca\x02\rVyletdatabase\xe2\x02\x19Vyletdatabase\\GPBMetadata\xea\x02\rVyletdatabaseb\x06proto3"
 
 var (
@@ -951,57 +1302,70 @@ func file_post_proto_rawDescGZIP() []byte {
 	return file_post_proto_rawDescData
 }
 
-var file_post_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_post_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_post_proto_goTypes = []any{
 	(*Image)(nil),                             // 0: vyletdatabase.Image
 	(*Post)(nil),                              // 1: vyletdatabase.Post
 	(*CreatePostRequest)(nil),                 // 2: vyletdatabase.CreatePostRequest
 	(*CreatePostResponse)(nil),                // 3: vyletdatabase.CreatePostResponse
-	(*DeletePostRequest)(nil),                 // 4: vyletdatabase.DeletePostRequest
-	(*DeletePostResponse)(nil),                // 5: vyletdatabase.DeletePostResponse
-	(*GetPostsRequest)(nil),                   // 6: vyletdatabase.GetPostsRequest
-	(*GetPostsResponse)(nil),                  // 7: vyletdatabase.GetPostsResponse
-	(*GetPostsByActorRequest)(nil),            // 8: vyletdatabase.GetPostsByActorRequest
-	(*GetPostsByActorResponse)(nil),           // 9: vyletdatabase.GetPostsByActorResponse
-	(*GetPostInteractionCountsRequest)(nil),   // 10: vyletdatabase.GetPostInteractionCountsRequest
-	(*PostInteractionCounts)(nil),             // 11: vyletdatabase.PostInteractionCounts
-	(*GetPostInteractionCountsResponse)(nil),  // 12: vyletdatabase.GetPostInteractionCountsResponse
-	(*GetPostsInteractionCountsRequest)(nil),  // 13: vyletdatabase.GetPostsInteractionCountsRequest
-	(*GetPostsInteractionCountsResponse)(nil), // 14: vyletdatabase.GetPostsInteractionCountsResponse
-	nil,                           // 15: vyletdatabase.GetPostsResponse.PostsEntry
-	nil,                           // 16: vyletdatabase.GetPostsByActorResponse.PostsEntry
-	nil,                           // 17: vyletdatabase.GetPostsInteractionCountsResponse.CountsEntry
-	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+	(*UpdatePostRequest)(nil),                 // 4: vyletdatabase.UpdatePostRequest
+	(*UpdatePostResponse)(nil),                // 5: vyletdatabase.UpdatePostResponse
+	(*DeletePostRequest)(nil),                 // 6: vyletdatabase.DeletePostRequest
+	(*DeletePostResponse)(nil),                // 7: vyletdatabase.DeletePostResponse
+	(*GetPostsRequest)(nil),                   // 8: vyletdatabase.GetPostsRequest
+	(*GetPostsResponse)(nil),                  // 9: vyletdatabase.GetPostsResponse
+	(*GetPostsByActorRequest)(nil),            // 10: vyletdatabase.GetPostsByActorRequest
+	(*GetPostsByActorResponse)(nil),           // 11: vyletdatabase.GetPostsByActorResponse
+	(*GetPostInteractionCountsRequest)(nil),   // 12: vyletdatabase.GetPostInteractionCountsRequest
+	(*PostInteractionCounts)(nil),             // 13: vyletdatabase.PostInteractionCounts
+	(*GetPostInteractionCountsResponse)(nil),  // 14: vyletdatabase.GetPostInteractionCountsResponse
+	(*GetPostsInteractionCountsRequest)(nil),  // 15: vyletdatabase.GetPostsInteractionCountsRequest
+	(*GetPostsInteractionCountsResponse)(nil), // 16: vyletdatabase.GetPostsInteractionCountsResponse
+	(*PostEdit)(nil),                          // 17: vyletdatabase.PostEdit
+	(*GetPostEditsRequest)(nil),               // 18: vyletdatabase.GetPostEditsRequest
+	(*GetPostEditsResponse)(nil),              // 19: vyletdatabase.GetPostEditsResponse
+	nil,                                       // 20: vyletdatabase.GetPostsResponse.PostsEntry
+	nil,                                       // 21: vyletdatabase.GetPostsByActorResponse.PostsEntry
+	nil,                                       // 22: vyletdatabase.GetPostsInteractionCountsResponse.CountsEntry
+	(*timestamppb.Timestamp)(nil),             // 23: google.protobuf.Timestamp
 }
 var file_post_proto_depIdxs = []int32{
 	0,  // 0: vyletdatabase.Post.images:type_name -> vyletdatabase.Image
-	18, // 1: vyletdatabase.Post.created_at:type_name -> google.protobuf.Timestamp
-	18, // 2: vyletdatabase.Post.indexed_at:type_name -> google.protobuf.Timestamp
-	1,  // 3: vyletdatabase.CreatePostRequest.post:type_name -> vyletdatabase.Post
-	15, // 4: vyletdatabase.GetPostsResponse.posts:type_name -> vyletdatabase.GetPostsResponse.PostsEntry
-	16, // 5: vyletdatabase.GetPostsByActorResponse.posts:type_name -> vyletdatabase.GetPostsByActorResponse.PostsEntry
-	11, // 6: vyletdatabase.GetPostInteractionCountsResponse.counts:type_name -> vyletdatabase.PostInteractionCounts
-	17, // 7: vyletdatabase.GetPostsInteractionCountsResponse.counts:type_name -> vyletdatabase.GetPostsInteractionCountsResponse.CountsEntry
-	1,  // 8: vyletdatabase.GetPostsResponse.PostsEntry.value:type_name -> vyletdatabase.Post
-	1,  // 9: vyletdatabase.GetPostsByActorResponse.PostsEntry.value:type_name -> vyletdatabase.Post
-	11, // 10: vyletdatabase.GetPostsInteractionCountsResponse.CountsEntry.value:type_name -> vyletdatabase.PostInteractionCounts
-	2,  // 11: vyletdatabase.PostService.CreatePost:input_type -> vyletdatabase.CreatePostRequest
-	4,  // 12: vyletdatabase.PostService.DeletePost:input_type -> vyletdatabase.DeletePostRequest
-	6,  // 13: vyletdatabase.PostService.GetPosts:input_type -> vyletdatabase.GetPostsRequest
-	8,  // 14: vyletdatabase.PostService.GetPostsByActor:input_type -> vyletdatabase.GetPostsByActorRequest
-	10, // 15: vyletdatabase.PostService.GetPostInteractionCounts:input_type -> vyletdatabase.GetPostInteractionCountsRequest
-	13, // 16: vyletdatabase.PostService.GetPostsInteractionCounts:input_type -> vyletdatabase.GetPostsInteractionCountsRequest
-	3,  // 17: vyletdatabase.PostService.CreatePost:output_type -> vyletdatabase.CreatePostResponse
-	5,  // 18: vyletdatabase.PostService.DeletePost:output_type -> vyletdatabase.DeletePostResponse
-	7,  // 19: vyletdatabase.PostService.GetPosts:output_type -> vyletdatabase.GetPostsResponse
-	9,  // 20: vyletdatabase.PostService.GetPostsByActor:output_type -> vyletdatabase.GetPostsByActorResponse
-	12, // 21: vyletdatabase.PostService.GetPostInteractionCounts:output_type -> vyletdatabase.GetPostInteractionCountsResponse
-	14, // 22: vyletdatabase.PostService.GetPostsInteractionCounts:output_type -> vyletdatabase.GetPostsInteractionCountsResponse
-	17, // [17:23] is the sub-list for method output_type
-	11, // [11:17] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	23, // 1: vyletdatabase.Post.created_at:type_name -> google.protobuf.Timestamp
+	23, // 2: vyletdatabase.Post.indexed_at:type_name -> google.protobuf.Timestamp
+	23, // 3: vyletdatabase.Post.edited_at:type_name -> google.protobuf.Timestamp
+	1,  // 4: vyletdatabase.CreatePostRequest.post:type_name -> vyletdatabase.Post
+	1,  // 5: vyletdatabase.UpdatePostRequest.post:type_name -> vyletdatabase.Post
+	20, // 6: vyletdatabase.GetPostsResponse.posts:type_name -> vyletdatabase.GetPostsResponse.PostsEntry
+	21, // 7: vyletdatabase.GetPostsByActorResponse.posts:type_name -> vyletdatabase.GetPostsByActorResponse.PostsEntry
+	13, // 8: vyletdatabase.GetPostInteractionCountsResponse.counts:type_name -> vyletdatabase.PostInteractionCounts
+	22, // 9: vyletdatabase.GetPostsInteractionCountsResponse.counts:type_name -> vyletdatabase.GetPostsInteractionCountsResponse.CountsEntry
+	23, // 10: vyletdatabase.PostEdit.edited_at:type_name -> google.protobuf.Timestamp
+	17, // 11: vyletdatabase.GetPostEditsResponse.edits:type_name -> vyletdatabase.PostEdit
+	1,  // 12: vyletdatabase.GetPostsResponse.PostsEntry.value:type_name -> vyletdatabase.Post
+	1,  // 13: vyletdatabase.GetPostsByActorResponse.PostsEntry.value:type_name -> vyletdatabase.Post
+	13, // 14: vyletdatabase.GetPostsInteractionCountsResponse.CountsEntry.value:type_name -> vyletdatabase.PostInteractionCounts
+	2,  // 15: vyletdatabase.PostService.CreatePost:input_type -> vyletdatabase.CreatePostRequest
+	4,  // 16: vyletdatabase.PostService.UpdatePost:input_type -> vyletdatabase.UpdatePostRequest
+	6,  // 17: vyletdatabase.PostService.DeletePost:input_type -> vyletdatabase.DeletePostRequest
+	8,  // 18: vyletdatabase.PostService.GetPosts:input_type -> vyletdatabase.GetPostsRequest
+	10, // 19: vyletdatabase.PostService.GetPostsByActor:input_type -> vyletdatabase.GetPostsByActorRequest
+	12, // 20: vyletdatabase.PostService.GetPostInteractionCounts:input_type -> vyletdatabase.GetPostInteractionCountsRequest
+	15, // 21: vyletdatabase.PostService.GetPostsInteractionCounts:input_type -> vyletdatabase.GetPostsInteractionCountsRequest
+	18, // 22: vyletdatabase.PostService.GetPostEdits:input_type -> vyletdatabase.GetPostEditsRequest
+	3,  // 23: vyletdatabase.PostService.CreatePost:output_type -> vyletdatabase.CreatePostResponse
+	5,  // 24: vyletdatabase.PostService.UpdatePost:output_type -> vyletdatabase.UpdatePostResponse
+	7,  // 25: vyletdatabase.PostService.DeletePost:output_type -> vyletdatabase.DeletePostResponse
+	9,  // 26: vyletdatabase.PostService.GetPosts:output_type -> vyletdatabase.GetPostsResponse
+	11, // 27: vyletdatabase.PostService.GetPostsByActor:output_type -> vyletdatabase.GetPostsByActorResponse
+	14, // 28: vyletdatabase.PostService.GetPostInteractionCounts:output_type -> vyletdatabase.GetPostInteractionCountsResponse
+	16, // 29: vyletdatabase.PostService.GetPostsInteractionCounts:output_type -> vyletdatabase.GetPostsInteractionCountsResponse
+	19, // 30: vyletdatabase.PostService.GetPostEdits:output_type -> vyletdatabase.GetPostEditsResponse
+	23, // [23:31] is the sub-list for method output_type
+	15, // [15:23] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_post_proto_init() }
@@ -1014,17 +1378,21 @@ func file_post_proto_init() {
 	file_post_proto_msgTypes[3].OneofWrappers = []any{}
 	file_post_proto_msgTypes[5].OneofWrappers = []any{}
 	file_post_proto_msgTypes[7].OneofWrappers = []any{}
-	file_post_proto_msgTypes[8].OneofWrappers = []any{}
 	file_post_proto_msgTypes[9].OneofWrappers = []any{}
-	file_post_proto_msgTypes[12].OneofWrappers = []any{}
+	file_post_proto_msgTypes[10].OneofWrappers = []any{}
+	file_post_proto_msgTypes[11].OneofWrappers = []any{}
 	file_post_proto_msgTypes[14].OneofWrappers = []any{}
+	file_post_proto_msgTypes[16].OneofWrappers = []any{}
+	file_post_proto_msgTypes[17].OneofWrappers = []any{}
+	file_post_proto_msgTypes[18].OneofWrappers = []any{}
+	file_post_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_post_proto_rawDesc), len(file_post_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,11 +20,13 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	PostService_CreatePost_FullMethodName                = "/vyletdatabase.PostService/CreatePost"
+	PostService_UpdatePost_FullMethodName                = "/vyletdatabase.PostService/UpdatePost"
 	PostService_DeletePost_FullMethodName                = "/vyletdatabase.PostService/DeletePost"
 	PostService_GetPosts_FullMethodName                  = "/vyletdatabase.PostService/GetPosts"
 	PostService_GetPostsByActor_FullMethodName           = "/vyletdatabase.PostService/GetPostsByActor"
 	PostService_GetPostInteractionCounts_FullMethodName  = "/vyletdatabase.PostService/GetPostInteractionCounts"
 	PostService_GetPostsInteractionCounts_FullMethodName = "/vyletdatabase.PostService/GetPostsInteractionCounts"
+	PostService_GetPostEdits_FullMethodName              = "/vyletdatabase.PostService/GetPostEdits"
 )
 
 // PostServiceClient is the client API for PostService service.
@@ -32,11 +34,13 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PostServiceClient interface {
 	CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*CreatePostResponse, error)
+	UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*UpdatePostResponse, error)
 	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error)
 	GetPosts(ctx context.Context, in *GetPostsRequest, opts ...grpc.CallOption) (*GetPostsResponse, error)
 	GetPostsByActor(ctx context.Context, in *GetPostsByActorRequest, opts ...grpc.CallOption) (*GetPostsByActorResponse, error)
 	GetPostInteractionCounts(ctx context.Context, in *GetPostInteractionCountsRequest, opts ...grpc.CallOption) (*GetPostInteractionCountsResponse, error)
 	GetPostsInteractionCounts(ctx context.Context, in *GetPostsInteractionCountsRequest, opts ...grpc.CallOption) (*GetPostsInteractionCountsResponse, error)
+	GetPostEdits(ctx context.Context, in *GetPostEditsRequest, opts ...grpc.CallOption) (*GetPostEditsResponse, error)
 }
 
 type postServiceClient struct {
@@ -57,6 +61,16 @@ func (c *postServiceClient) CreatePost(ctx context.Context, in *CreatePostReques
 	return out, nil
 }
 
+func (c *postServiceClient) UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*UpdatePostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePostResponse)
+	err := c.cc.Invoke(ctx, PostService_UpdatePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postServiceClient) DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeletePostResponse)
@@ -107,16 +121,28 @@ func (c *postServiceClient) GetPostsInteractionCounts(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *postServiceClient) GetPostEdits(ctx context.Context, in *GetPostEditsRequest, opts ...grpc.CallOption) (*GetPostEditsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPostEditsResponse)
+	err := c.cc.Invoke(ctx, PostService_GetPostEdits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostServiceServer is the server API for PostService service.
 // All implementations must embed UnimplementedPostServiceServer
 // for forward compatibility.
 type PostServiceServer interface {
 	CreatePost(context.Context, *CreatePostRequest) (*CreatePostResponse, error)
+	UpdatePost(context.Context, *UpdatePostRequest) (*UpdatePostResponse, error)
 	DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error)
 	GetPosts(context.Context, *GetPostsRequest) (*GetPostsResponse, error)
 	GetPostsByActor(context.Context, *GetPostsByActorRequest) (*GetPostsByActorResponse, error)
 	GetPostInteractionCounts(context.Context, *GetPostInteractionCountsRequest) (*GetPostInteractionCountsResponse, error)
 	GetPostsInteractionCounts(context.Context, *GetPostsInteractionCountsRequest) (*GetPostsInteractionCountsResponse, error)
+	GetPostEdits(context.Context, *GetPostEditsRequest) (*GetPostEditsResponse, error)
 	mustEmbedUnimplementedPostServiceServer()
 }
 
@@ -130,6 +156,9 @@ type UnimplementedPostServiceServer struct{}
 func (UnimplementedPostServiceServer) CreatePost(context.Context, *CreatePostRequest) (*CreatePostResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreatePost not implemented")
 }
+func (UnimplementedPostServiceServer) UpdatePost(context.Context, *UpdatePostRequest) (*UpdatePostResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePost not implemented")
+}
 func (UnimplementedPostServiceServer) DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeletePost not implemented")
 }
@@ -145,6 +174,9 @@ func (UnimplementedPostServiceServer) GetPostInteractionCounts(context.Context,
 func (UnimplementedPostServiceServer) GetPostsInteractionCounts(context.Context, *GetPostsInteractionCountsRequest) (*GetPostsInteractionCountsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPostsInteractionCounts not implemented")
 }
+func (UnimplementedPostServiceServer) GetPostEdits(context.Context, *GetPostEditsRequest) (*GetPostEditsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPostEdits not implemented")
+}
 func (UnimplementedPostServiceServer) mustEmbedUnimplementedPostServiceServer() {}
 func (UnimplementedPostServiceServer) testEmbeddedByValue()                     {}
 
@@ -184,6 +216,24 @@ func _PostService_CreatePost_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_UpdatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).UpdatePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_UpdatePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).UpdatePost(ctx, req.(*UpdatePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostService_DeletePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeletePostRequest)
 	if err := dec(in); err != nil {
@@ -274,6 +324,24 @@ func _PostService_GetPostsInteractionCounts_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_GetPostEdits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostEditsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetPostEdits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_GetPostEdits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetPostEdits(ctx, req.(*GetPostEditsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostService_ServiceDesc is the grpc.ServiceDesc for PostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -285,6 +353,10 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreatePost",
 			Handler:    _PostService_CreatePost_Handler,
 		},
+		{
+			MethodName: "UpdatePost",
+			Handler:    _PostService_UpdatePost_Handler,
+		},
 		{
 			MethodName: "DeletePost",
 			Handler:    _PostService_DeletePost_Handler,
@@ -305,6 +377,10 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPostsInteractionCounts",
 			Handler:    _PostService_GetPostsInteractionCounts_Handler,
 		},
+		{
+			MethodName: "GetPostEdits",
+			Handler:    _PostService_GetPostEdits_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "post.proto",
@@ -118,6 +118,7 @@ func (x *Profile) GetIndexedAt() *timestamppb.Timestamp {
 type CreateProfileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Profile       *Profile               `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -159,6 +160,13 @@ func (x *CreateProfileRequest) GetProfile() *Profile {
 	return nil
 }
 
+func (x *CreateProfileRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
 type CreateProfileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
@@ -206,6 +214,7 @@ func (x *CreateProfileResponse) GetError() string {
 type DeleteProfileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Did           string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	Rev           string                 `protobuf:"bytes,2,opt,name=rev,proto3" json:"rev,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -247,6 +256,13 @@ func (x *DeleteProfileRequest) GetDid() string {
 	return ""
 }
 
+func (x *DeleteProfileRequest) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
 type DeleteProfileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
@@ -501,14 +517,16 @@ const file_profile_proto_rawDesc = "" +
 	"\r_display_nameB\x0e\n" +
 	"\f_descriptionB\v\n" +
 	"\t_pronounsB\t\n" +
-	"\a_avatar\"H\n" +
+	"\a_avatar\"Z\n" +
 	"\x14CreateProfileRequest\x120\n" +
-	"\aprofile\x18\x01 \x01(\v2\x16.vyletdatabase.ProfileR\aprofile\"<\n" +
+	"\aprofile\x18\x01 \x01(\v2\x16.vyletdatabase.ProfileR\aprofile\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\"<\n" +
 	"\x15CreateProfileResponse\x12\x19\n" +
 	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"0\n" +
+	"\x06_error\"B\n" +
 	"\x14DeleteProfileRequest\x12\x18\n" +
-	"\x03did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03did\"<\n" +
+	"\x03did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03did\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\tR\x03rev\"<\n" +
 	"\x15DeleteProfileResponse\x12\x19\n" +
 	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
 	"\x06_error\"-\n" +
@@ -0,0 +1,430 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: resync.proto
+
+package vyletdatabase
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MarkResyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Did           string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	MarkedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=marked_at,json=markedAt,proto3" json:"marked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkResyncRequest) Reset() {
+	*x = MarkResyncRequest{}
+	mi := &file_resync_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkResyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkResyncRequest) ProtoMessage() {}
+
+func (x *MarkResyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resync_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkResyncRequest.ProtoReflect.Descriptor instead.
+func (*MarkResyncRequest) Descriptor() ([]byte, []int) {
+	return file_resync_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MarkResyncRequest) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *MarkResyncRequest) GetMarkedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MarkedAt
+	}
+	return nil
+}
+
+type MarkResyncResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkResyncResponse) Reset() {
+	*x = MarkResyncResponse{}
+	mi := &file_resync_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkResyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkResyncResponse) ProtoMessage() {}
+
+func (x *MarkResyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resync_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkResyncResponse.ProtoReflect.Descriptor instead.
+func (*MarkResyncResponse) Descriptor() ([]byte, []int) {
+	return file_resync_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MarkResyncResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type CompleteResyncRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Did   string                 `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	// marks made after the resync started are kept, as the fetched repo may predate them
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteResyncRequest) Reset() {
+	*x = CompleteResyncRequest{}
+	mi := &file_resync_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteResyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteResyncRequest) ProtoMessage() {}
+
+func (x *CompleteResyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resync_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteResyncRequest.ProtoReflect.Descriptor instead.
+func (*CompleteResyncRequest) Descriptor() ([]byte, []int) {
+	return file_resync_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CompleteResyncRequest) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *CompleteResyncRequest) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+type CompleteResyncResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteResyncResponse) Reset() {
+	*x = CompleteResyncResponse{}
+	mi := &file_resync_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteResyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteResyncResponse) ProtoMessage() {}
+
+func (x *CompleteResyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resync_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteResyncResponse.ProtoReflect.Descriptor instead.
+func (*CompleteResyncResponse) Descriptor() ([]byte, []int) {
+	return file_resync_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CompleteResyncResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type GetPendingResyncsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int64                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        *string                `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingResyncsRequest) Reset() {
+	*x = GetPendingResyncsRequest{}
+	mi := &file_resync_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingResyncsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingResyncsRequest) ProtoMessage() {}
+
+func (x *GetPendingResyncsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resync_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingResyncsRequest.ProtoReflect.Descriptor instead.
+func (*GetPendingResyncsRequest) Descriptor() ([]byte, []int) {
+	return file_resync_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPendingResyncsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetPendingResyncsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type GetPendingResyncsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         *string                `protobuf:"bytes,1,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Dids          []string               `protobuf:"bytes,2,rep,name=dids,proto3" json:"dids,omitempty"`
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingResyncsResponse) Reset() {
+	*x = GetPendingResyncsResponse{}
+	mi := &file_resync_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingResyncsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingResyncsResponse) ProtoMessage() {}
+
+func (x *GetPendingResyncsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resync_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingResyncsResponse.ProtoReflect.Descriptor instead.
+func (*GetPendingResyncsResponse) Descriptor() ([]byte, []int) {
+	return file_resync_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPendingResyncsResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetPendingResyncsResponse) GetDids() []string {
+	if x != nil {
+		return x.Dids
+	}
+	return nil
+}
+
+func (x *GetPendingResyncsResponse) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+var File_resync_proto protoreflect.FileDescriptor
+
+const file_resync_proto_rawDesc = "" +
+	"\n" +
+	"\fresync.proto\x12\rvyletdatabase\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"n\n" +
+	"\x11MarkResyncRequest\x12\x18\n" +
+	"\x03did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03did\x12?\n" +
+	"\tmarked_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\bmarkedAt\"9\n" +
+	"\x12MarkResyncResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"t\n" +
+	"\x15CompleteResyncRequest\x12\x18\n" +
+	"\x03did\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03did\x12A\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\tstartedAt\"=\n" +
+	"\x16CompleteResyncResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"X\n" +
+	"\x18GetPendingResyncsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x03R\x05limit\x12\x1b\n" +
+	"\x06cursor\x18\x02 \x01(\tH\x00R\x06cursor\x88\x01\x01B\t\n" +
+	"\a_cursor\"|\n" +
+	"\x19GetPendingResyncsResponse\x12\x19\n" +
+	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12\x12\n" +
+	"\x04dids\x18\x02 \x03(\tR\x04dids\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_errorB\t\n" +
+	"\a_cursor2\xa9\x02\n" +
+	"\rResyncService\x12Q\n" +
+	"\n" +
+	"MarkResync\x12 .vyletdatabase.MarkResyncRequest\x1a!.vyletdatabase.MarkResyncResponse\x12]\n" +
+	"\x0eCompleteResync\x12$.vyletdatabase.CompleteResyncRequest\x1a%.vyletdatabase.CompleteResyncResponse\x12f\n" +
+	"\x11GetPendingResyncs\x12'.vyletdatabase.GetPendingResyncsRequest\x1a(.vyletdatabase.GetPendingResyncsResponseB\x86\x01\n" +
+	"\x11com.vyletdatabaseB\vResyncProtoP\x01Z\x10./;vyletdatabase\xa2\x02\x03VXX\xaa\x02\rVyletdatabase\xca\x02\rVyletdatabase\xe2\x02\x19Vyletdatabase\\GPBMetadata\xea\x02\rVyletdatabaseb\x06proto3"
+
+var (
+	file_resync_proto_rawDescOnce sync.Once
+	file_resync_proto_rawDescData []byte
+)
+
+func file_resync_proto_rawDescGZIP() []byte {
+	file_resync_proto_rawDescOnce.Do(func() {
+		file_resync_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_resync_proto_rawDesc), len(file_resync_proto_rawDesc)))
+	})
+	return file_resync_proto_rawDescData
+}
+
+var file_resync_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_resync_proto_goTypes = []any{
+	(*MarkResyncRequest)(nil),         // 0: vyletdatabase.MarkResyncRequest
+	(*MarkResyncResponse)(nil),        // 1: vyletdatabase.MarkResyncResponse
+	(*CompleteResyncRequest)(nil),     // 2: vyletdatabase.CompleteResyncRequest
+	(*CompleteResyncResponse)(nil),    // 3: vyletdatabase.CompleteResyncResponse
+	(*GetPendingResyncsRequest)(nil),  // 4: vyletdatabase.GetPendingResyncsRequest
+	(*GetPendingResyncsResponse)(nil), // 5: vyletdatabase.GetPendingResyncsResponse
+	(*timestamppb.Timestamp)(nil),     // 6: google.protobuf.Timestamp
+}
+var file_resync_proto_depIdxs = []int32{
+	6, // 0: vyletdatabase.MarkResyncRequest.marked_at:type_name -> google.protobuf.Timestamp
+	6, // 1: vyletdatabase.CompleteResyncRequest.started_at:type_name -> google.protobuf.Timestamp
+	0, // 2: vyletdatabase.ResyncService.MarkResync:input_type -> vyletdatabase.MarkResyncRequest
+	2, // 3: vyletdatabase.ResyncService.CompleteResync:input_type -> vyletdatabase.CompleteResyncRequest
+	4, // 4: vyletdatabase.ResyncService.GetPendingResyncs:input_type -> vyletdatabase.GetPendingResyncsRequest
+	1, // 5: vyletdatabase.ResyncService.MarkResync:output_type -> vyletdatabase.MarkResyncResponse
+	3, // 6: vyletdatabase.ResyncService.CompleteResync:output_type -> vyletdatabase.CompleteResyncResponse
+	5, // 7: vyletdatabase.ResyncService.GetPendingResyncs:output_type -> vyletdatabase.GetPendingResyncsResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_resync_proto_init() }
+func file_resync_proto_init() {
+	if File_resync_proto != nil {
+		return
+	}
+	file_resync_proto_msgTypes[1].OneofWrappers = []any{}
+	file_resync_proto_msgTypes[3].OneofWrappers = []any{}
+	file_resync_proto_msgTypes[4].OneofWrappers = []any{}
+	file_resync_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_resync_proto_rawDesc), len(file_resync_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_resync_proto_goTypes,
+		DependencyIndexes: file_resync_proto_depIdxs,
+		MessageInfos:      file_resync_proto_msgTypes,
+	}.Build()
+	File_resync_proto = out.File
+	file_resync_proto_goTypes = nil
+	file_resync_proto_depIdxs = nil
+}
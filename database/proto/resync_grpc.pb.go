@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: resync.proto
+
+package vyletdatabase
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ResyncService_MarkResync_FullMethodName        = "/vyletdatabase.ResyncService/MarkResync"
+	ResyncService_CompleteResync_FullMethodName    = "/vyletdatabase.ResyncService/CompleteResync"
+	ResyncService_GetPendingResyncs_FullMethodName = "/vyletdatabase.ResyncService/GetPendingResyncs"
+)
+
+// ResyncServiceClient is the client API for ResyncService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ResyncService stores the repos the indexer has yet to resync, so that a resync queued by a #sync event survives a
+// restart of the indexer
+type ResyncServiceClient interface {
+	MarkResync(ctx context.Context, in *MarkResyncRequest, opts ...grpc.CallOption) (*MarkResyncResponse, error)
+	CompleteResync(ctx context.Context, in *CompleteResyncRequest, opts ...grpc.CallOption) (*CompleteResyncResponse, error)
+	GetPendingResyncs(ctx context.Context, in *GetPendingResyncsRequest, opts ...grpc.CallOption) (*GetPendingResyncsResponse, error)
+}
+
+type resyncServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResyncServiceClient(cc grpc.ClientConnInterface) ResyncServiceClient {
+	return &resyncServiceClient{cc}
+}
+
+func (c *resyncServiceClient) MarkResync(ctx context.Context, in *MarkResyncRequest, opts ...grpc.CallOption) (*MarkResyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkResyncResponse)
+	err := c.cc.Invoke(ctx, ResyncService_MarkResync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resyncServiceClient) CompleteResync(ctx context.Context, in *CompleteResyncRequest, opts ...grpc.CallOption) (*CompleteResyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteResyncResponse)
+	err := c.cc.Invoke(ctx, ResyncService_CompleteResync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resyncServiceClient) GetPendingResyncs(ctx context.Context, in *GetPendingResyncsRequest, opts ...grpc.CallOption) (*GetPendingResyncsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPendingResyncsResponse)
+	err := c.cc.Invoke(ctx, ResyncService_GetPendingResyncs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResyncServiceServer is the server API for ResyncService service.
+// All implementations must embed UnimplementedResyncServiceServer
+// for forward compatibility.
+//
+// ResyncService stores the repos the indexer has yet to resync, so that a resync queued by a #sync event survives a
+// restart of the indexer
+type ResyncServiceServer interface {
+	MarkResync(context.Context, *MarkResyncRequest) (*MarkResyncResponse, error)
+	CompleteResync(context.Context, *CompleteResyncRequest) (*CompleteResyncResponse, error)
+	GetPendingResyncs(context.Context, *GetPendingResyncsRequest) (*GetPendingResyncsResponse, error)
+	mustEmbedUnimplementedResyncServiceServer()
+}
+
+// UnimplementedResyncServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedResyncServiceServer struct{}
+
+func (UnimplementedResyncServiceServer) MarkResync(context.Context, *MarkResyncRequest) (*MarkResyncResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkResync not implemented")
+}
+func (UnimplementedResyncServiceServer) CompleteResync(context.Context, *CompleteResyncRequest) (*CompleteResyncResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteResync not implemented")
+}
+func (UnimplementedResyncServiceServer) GetPendingResyncs(context.Context, *GetPendingResyncsRequest) (*GetPendingResyncsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPendingResyncs not implemented")
+}
+func (UnimplementedResyncServiceServer) mustEmbedUnimplementedResyncServiceServer() {}
+func (UnimplementedResyncServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeResyncServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResyncServiceServer will
+// result in compilation errors.
+type UnsafeResyncServiceServer interface {
+	mustEmbedUnimplementedResyncServiceServer()
+}
+
+func RegisterResyncServiceServer(s grpc.ServiceRegistrar, srv ResyncServiceServer) {
+	// If the following call panics, it indicates UnimplementedResyncServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ResyncService_ServiceDesc, srv)
+}
+
+func _ResyncService_MarkResync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkResyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResyncServiceServer).MarkResync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResyncService_MarkResync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResyncServiceServer).MarkResync(ctx, req.(*MarkResyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResyncService_CompleteResync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteResyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResyncServiceServer).CompleteResync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResyncService_CompleteResync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResyncServiceServer).CompleteResync(ctx, req.(*CompleteResyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResyncService_GetPendingResyncs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPendingResyncsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResyncServiceServer).GetPendingResyncs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResyncService_GetPendingResyncs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResyncServiceServer).GetPendingResyncs(ctx, req.(*GetPendingResyncsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ResyncService_ServiceDesc is the grpc.ServiceDesc for ResyncService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResyncService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vyletdatabase.ResyncService",
+	HandlerType: (*ResyncServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MarkResync",
+			Handler:    _ResyncService_MarkResync_Handler,
+		},
+		{
+			MethodName: "CompleteResync",
+			Handler:    _ResyncService_CompleteResync_Handler,
+		},
+		{
+			MethodName: "GetPendingResyncs",
+			Handler:    _ResyncService_GetPendingResyncs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "resync.proto",
+}
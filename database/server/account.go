@@ -0,0 +1,261 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/internal/helpers"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) SetAccountStatus(ctx context.Context, req *vyletdatabase.SetAccountStatusRequest) (*vyletdatabase.SetAccountStatusResponse, error) {
+	logger := s.logger.With("name", "SetAccountStatus", "did", req.AccountStatus.Did)
+
+	updatedAt := req.AccountStatus.UpdatedAt.AsTime()
+
+	// account events are redelivered after reconnects and replays and fanned in from several relays, so a status only
+	// replaces one from an older event
+	applied, err := s.execCAS(ctx, `
+		INSERT INTO account_statuses
+			(did, active, status, updated_at)
+		VALUES
+			(?, ?, ?, ?)
+		IF NOT EXISTS
+	`,
+		req.AccountStatus.Did,
+		req.AccountStatus.Active,
+		req.AccountStatus.Status,
+		updatedAt,
+	)
+	if err == nil && !applied {
+		_, err = s.execCAS(ctx, `
+			UPDATE account_statuses
+			SET active = ?, status = ?, updated_at = ?
+			WHERE did = ?
+			IF updated_at < ?
+		`,
+			req.AccountStatus.Active,
+			req.AccountStatus.Status,
+			updatedAt,
+			req.AccountStatus.Did,
+			updatedAt,
+		)
+	}
+	if err != nil {
+		logger.Error("failed to set account status", "err", err)
+		return &vyletdatabase.SetAccountStatusResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.SetAccountStatusResponse{}, nil
+}
+
+func (s *Server) GetAccountStatuses(ctx context.Context, req *vyletdatabase.GetAccountStatusesRequest) (*vyletdatabase.GetAccountStatusesResponse, error) {
+	logger := s.logger.With("name", "GetAccountStatuses")
+
+	if len(req.Dids) == 0 {
+		return nil, fmt.Errorf("at least one DID must be specified")
+	}
+
+	query := `
+		SELECT did, active, status, updated_at
+		FROM account_statuses
+		WHERE did IN ?
+	`
+
+	iter := s.cqlSession.Query(query, req.Dids).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	statuses := make(map[string]*vyletdatabase.AccountStatus)
+	for {
+		status := &vyletdatabase.AccountStatus{}
+		var updatedAt time.Time
+
+		if !iter.Scan(
+			&status.Did,
+			&status.Active,
+			&status.Status,
+			&updatedAt,
+		) {
+			break
+		}
+
+		status.UpdatedAt = timestamppb.New(updatedAt)
+		statuses[status.Did] = status
+	}
+
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate account statuses", "err", err)
+		return &vyletdatabase.GetAccountStatusesResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.GetAccountStatusesResponse{
+		AccountStatuses: statuses,
+	}, nil
+}
+
+// PurgeAccount deletes the account's records through the same paths as their deletes, so that the counts of the
+// posts and accounts they referenced are kept in line. The deletes leave tombstones at a rev of the time of the purge,
+// so that creates of the purged records replayed from the bus afterwards are rejected as stale.
+func (s *Server) PurgeAccount(ctx context.Context, req *vyletdatabase.PurgeAccountRequest) (*vyletdatabase.PurgeAccountResponse, error) {
+	logger := s.logger.With("name", "PurgeAccount", "did", req.Did)
+
+	rev := syntax.NewTIDNow(0).String()
+
+	var errs []error
+
+	postUris, err := s.urisByAuthor(ctx, "posts_by_actor", req.Did)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, uri := range postUris {
+		resp, err := s.DeletePost(ctx, &vyletdatabase.DeletePostRequest{Uri: uri, Rev: rev})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete post %s: %w", uri, err))
+		} else if resp.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to delete post %s: %s", uri, *resp.Error))
+		}
+	}
+
+	commentUris, err := s.urisByAuthor(ctx, "comments_by_author_did", req.Did)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, uri := range commentUris {
+		resp, err := s.DeleteComment(ctx, &vyletdatabase.DeleteCommentRequest{Uri: uri, Rev: rev})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete comment %s: %w", uri, err))
+		} else if resp.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to delete comment %s: %s", uri, *resp.Error))
+		}
+	}
+
+	likeUris, err := s.urisByAuthor(ctx, "likes_by_actor", req.Did)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, uri := range likeUris {
+		resp, err := s.DeleteLike(ctx, &vyletdatabase.DeleteLikeRequest{Uri: uri, Rev: rev})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete like %s: %w", uri, err))
+		} else if resp.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to delete like %s: %s", uri, *resp.Error))
+		}
+	}
+
+	followUris, err := s.urisByAuthor(ctx, "follows_by_author_did", req.Did)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, uri := range followUris {
+		resp, err := s.DeleteFollow(ctx, &vyletdatabase.DeleteFollowRequest{Uri: uri, Rev: rev})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete follow %s: %w", uri, err))
+		} else if resp.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to delete follow %s: %s", uri, *resp.Error))
+		}
+	}
+
+	blockUris, err := s.urisByAuthor(ctx, "blocks_by_author_did", req.Did)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, uri := range blockUris {
+		resp, err := s.DeleteBlock(ctx, &vyletdatabase.DeleteBlockRequest{Uri: uri, Rev: rev})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete block %s: %w", uri, err))
+		} else if resp.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to delete block %s: %s", uri, *resp.Error))
+		}
+	}
+
+	// notifications are removed by the notifier when records are deleted, but a purge deletes them without a commit
+	for _, uri := range slices.Concat(postUris, commentUris, likeUris, followUris) {
+		resp, err := s.DeleteNotifications(ctx, &vyletdatabase.DeleteNotificationsRequest{Uri: uri, Rev: rev})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete notifications of %s: %w", uri, err))
+		} else if resp.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to delete notifications of %s: %s", uri, *resp.Error))
+		}
+	}
+
+	if err := s.cqlSession.Query(`
+		DELETE FROM notifications_by_recipient_did
+		WHERE recipient_did = ?
+	`, req.Did).WithContext(ctx).Exec(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete received notifications: %w", err))
+	}
+
+	if err := s.cqlSession.Query(`
+		DELETE FROM notifications_seen
+		WHERE did = ?
+	`, req.Did).WithContext(ctx).Exec(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete notifications seen at: %w", err))
+	}
+
+	// mutes are private to the account, so there are no counts or other actors' views to update
+	for _, table := range []string{"mutes_by_actor_did", "mutes_by_actor_did_subject_did"} {
+		if err := s.cqlSession.Query(fmt.Sprintf(`
+			DELETE FROM %s
+			WHERE actor_did = ?
+		`, table), req.Did).WithContext(ctx).Exec(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete mutes from %s: %w", table, err))
+		}
+	}
+
+	profileResp, err := s.DeleteProfile(ctx, &vyletdatabase.DeleteProfileRequest{Did: req.Did, Rev: rev})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete profile: %w", err))
+	} else if profileResp.Error != nil {
+		errs = append(errs, fmt.Errorf("failed to delete profile: %s", *profileResp.Error))
+	}
+
+	if err := s.cqlSession.Query(`
+		DELETE FROM blob_refs
+		WHERE did = ?
+	`, req.Did).WithContext(ctx).Exec(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete blob refs: %w", err))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		logger.Error("failed to purge account", "err", err)
+		return &vyletdatabase.PurgeAccountResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	logger.Info("purged account", "posts", len(postUris), "comments", len(commentUris), "likes", len(likeUris), "follows", len(followUris), "blocks", len(blockUris))
+
+	return &vyletdatabase.PurgeAccountResponse{}, nil
+}
+
+// urisByAuthor lists the uri of every row in one of the by-author tables for the did
+func (s *Server) urisByAuthor(ctx context.Context, table, did string) ([]string, error) {
+	iter := s.cqlSession.Query(fmt.Sprintf(`
+		SELECT uri
+		FROM %s
+		WHERE author_did = ?
+	`, table), did).WithContext(ctx).Iter()
+
+	var (
+		uris []string
+		uri  string
+	)
+	for iter.Scan(&uri) {
+		uris = append(uris, uri)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to list uris from %s: %w", table, err)
+	}
+
+	return uris, nil
+}
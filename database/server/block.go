@@ -0,0 +1,375 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/internal/helpers"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateBlock(ctx context.Context, req *vyletdatabase.CreateBlockRequest) (*vyletdatabase.CreateBlockResponse, error) {
+	logger := s.logger.With("name", "CreateBlock", "uri", req.Block.Uri, "did", req.Block.AuthorDid, "subjectDid", req.Block.SubjectDid)
+
+	now := time.Now().UTC()
+
+	stale, err := s.staleRev(ctx, req.Block.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "err", err)
+		return &vyletdatabase.CreateBlockResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.CreateBlockResponse{}, nil
+	}
+
+	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+
+	args := []any{
+		req.Block.Uri,
+		req.Block.Cid,
+		req.Block.SubjectDid,
+		req.Block.AuthorDid,
+		req.Block.CreatedAt.AsTime(),
+		now,
+	}
+
+	query := `
+		INSERT INTO %s
+			(uri, cid, subject_did, author_did, created_at, indexed_at)
+		VALUES
+			(?, ?, ?, ?, ?, ?)
+	`
+
+	batch.Query(fmt.Sprintf(query, "blocks_by_uri"), args...)
+	batch.Query(fmt.Sprintf(query, "blocks_by_author_did"), args...)
+	batch.Query(fmt.Sprintf(query, "blocks_by_author_did_subject_did"), args...)
+	batch.Query(fmt.Sprintf(query, "block_uris_by_author_did_subject_did"), args...)
+
+	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
+		logger.Error("failed to create block", "err", err)
+		return &vyletdatabase.CreateBlockResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	if err := s.setRev(ctx, req.Block.Uri, req.Rev, false); err != nil {
+		logger.Error("failed to set record rev", "err", err)
+		return &vyletdatabase.CreateBlockResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.CreateBlockResponse{}, nil
+}
+
+func (s *Server) DeleteBlock(ctx context.Context, req *vyletdatabase.DeleteBlockRequest) (*vyletdatabase.DeleteBlockResponse, error) {
+	logger := s.logger.With("name", "DeleteBlock", "uri", req.Uri)
+
+	stale, err := s.staleRev(ctx, req.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "err", err)
+		return &vyletdatabase.DeleteBlockResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.DeleteBlockResponse{}, nil
+	}
+
+	var (
+		createdAt  time.Time
+		subjectDid string
+		authorDid  string
+	)
+
+	query := `
+		SELECT created_at, subject_did, author_did
+		FROM blocks_by_uri
+		WHERE uri = ?
+	`
+	if err := s.cqlSession.Query(query, req.Uri).WithContext(ctx).Scan(&createdAt, &subjectDid, &authorDid); err != nil {
+		if err == gocql.ErrNotFound {
+			// a delete processed before its create leaves a tombstone, so the create is skipped once it arrives
+			if req.Rev != "" {
+				if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+					logger.Error("failed to set record rev", "err", err)
+					return &vyletdatabase.DeleteBlockResponse{
+						Error: helpers.ToStringPtr(err.Error()),
+					}, nil
+				}
+				return &vyletdatabase.DeleteBlockResponse{}, nil
+			}
+			logger.Warn("block not found", "uri", req.Uri)
+			return &vyletdatabase.DeleteBlockResponse{
+				Error: helpers.ToStringPtr("block not found"),
+			}, nil
+		}
+		logger.Error("failed to fetch block", "uri", req.Uri, "err", err)
+		return &vyletdatabase.DeleteBlockResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	logger = logger.With("authorDid", authorDid, "subjectDid", subjectDid)
+
+	// blocks_by_author_did_subject_did holds a single block per pair, so another block of the same subject, found in
+	// block_uris_by_author_did_subject_did, has to take over its row instead of the pair being unblocked
+	other, err := s.otherBlockOfSubject(ctx, authorDid, subjectDid, req.Uri)
+	if err != nil {
+		logger.Error("failed to fetch other blocks of subject", "err", err)
+		return &vyletdatabase.DeleteBlockResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+
+	batch.Query(`
+		DELETE FROM blocks_by_uri
+		WHERE uri = ?
+	`, req.Uri)
+
+	batch.Query(`
+		DELETE FROM blocks_by_author_did
+		WHERE author_did = ? AND created_at = ? AND uri = ?
+	`, authorDid, createdAt, req.Uri)
+
+	batch.Query(`
+		DELETE FROM block_uris_by_author_did_subject_did
+		WHERE author_did = ? AND subject_did = ? AND uri = ?
+	`, authorDid, subjectDid, req.Uri)
+
+	if other != nil {
+		batch.Query(`
+			INSERT INTO blocks_by_author_did_subject_did
+				(uri, cid, subject_did, author_did, created_at, indexed_at)
+			VALUES
+				(?, ?, ?, ?, ?, ?)
+		`, other.Uri, other.Cid, other.SubjectDid, other.AuthorDid, other.CreatedAt.AsTime(), other.IndexedAt.AsTime())
+	} else {
+		batch.Query(`
+			DELETE FROM blocks_by_author_did_subject_did
+			WHERE author_did = ? AND subject_did = ?
+		`, authorDid, subjectDid)
+	}
+
+	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
+		logger.Error("failed to delete block", "uri", req.Uri, "err", err)
+		return &vyletdatabase.DeleteBlockResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+		logger.Error("failed to set record rev", "err", err)
+		return &vyletdatabase.DeleteBlockResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.DeleteBlockResponse{}, nil
+}
+
+// otherBlockOfSubject returns a block of the subject by the author other than the one at uri, or nil when there is
+// none
+func (s *Server) otherBlockOfSubject(ctx context.Context, authorDid, subjectDid, uri string) (*vyletdatabase.Block, error) {
+	// the block at uri is at most one of the rows, so two are enough to find another
+	iter := s.cqlSession.Query(`
+		SELECT uri, cid, created_at, indexed_at
+		FROM block_uris_by_author_did_subject_did
+		WHERE author_did = ? AND subject_did = ?
+		LIMIT 2
+	`, authorDid, subjectDid).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var (
+		createdAt time.Time
+		indexedAt time.Time
+	)
+	for {
+		block := &vyletdatabase.Block{
+			AuthorDid:  authorDid,
+			SubjectDid: subjectDid,
+		}
+		if !iter.Scan(
+			&block.Uri,
+			&block.Cid,
+			&createdAt,
+			&indexedAt,
+		) {
+			break
+		}
+		if block.Uri == uri {
+			continue
+		}
+		block.CreatedAt = timestamppb.New(createdAt)
+		block.IndexedAt = timestamppb.New(indexedAt)
+
+		return block, nil
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *Server) GetBlocksByActor(ctx context.Context, req *vyletdatabase.GetBlocksByActorRequest) (*vyletdatabase.GetBlocksByActorResponse, error) {
+	logger := s.logger.With("name", "GetBlocksByActor", "did", req.Did)
+
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var (
+		query string
+		args  []any
+	)
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		cursorParts := strings.SplitN(*req.Cursor, "|", 2)
+		if len(cursorParts) != 2 {
+			logger.Error("invalid cursor format", "cursor", *req.Cursor)
+			return &vyletdatabase.GetBlocksByActorResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+
+		cursorTime, err := time.Parse(time.RFC3339Nano, cursorParts[0])
+		if err != nil {
+			logger.Error("failed to parse cursor timestamp", "cursor", *req.Cursor, "err", err)
+			return &vyletdatabase.GetBlocksByActorResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+		cursorUri := cursorParts[1]
+
+		query = `
+			SELECT uri, cid, subject_did, author_did, created_at, indexed_at
+			FROM blocks_by_author_did
+			WHERE author_did = ? AND (created_at, uri) < (?, ?)
+			ORDER BY created_at DESC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, cursorTime, cursorUri, req.Limit + 1}
+	} else {
+		query = `
+			SELECT uri, cid, subject_did, author_did, created_at, indexed_at
+			FROM blocks_by_author_did
+			WHERE author_did = ?
+			ORDER BY created_at DESC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, req.Limit + 1}
+	}
+
+	iter := s.cqlSession.Query(query, args...).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var blocks []*vyletdatabase.Block
+
+	var (
+		createdAt time.Time
+		indexedAt time.Time
+	)
+	for {
+		block := &vyletdatabase.Block{}
+		if !iter.Scan(
+			&block.Uri,
+			&block.Cid,
+			&block.SubjectDid,
+			&block.AuthorDid,
+			&createdAt,
+			&indexedAt,
+		) {
+			break
+		}
+		block.CreatedAt = timestamppb.New(createdAt)
+		block.IndexedAt = timestamppb.New(indexedAt)
+
+		blocks = append(blocks, block)
+	}
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate blocks", "err", err)
+		return &vyletdatabase.GetBlocksByActorResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	var nextCursor *string
+	if len(blocks) > int(req.Limit) {
+		blocks = blocks[:req.Limit]
+		last := blocks[len(blocks)-1]
+		cursorStr := fmt.Sprintf("%s|%s",
+			last.CreatedAt.AsTime().Format(time.RFC3339Nano),
+			last.Uri)
+		nextCursor = &cursorStr
+	}
+
+	return &vyletdatabase.GetBlocksByActorResponse{
+		Blocks: blocks,
+		Cursor: nextCursor,
+	}, nil
+}
+
+func (s *Server) GetBlockRelationships(ctx context.Context, req *vyletdatabase.GetBlockRelationshipsRequest) (*vyletdatabase.GetBlockRelationshipsResponse, error) {
+	logger := s.logger.With("name", "GetBlockRelationships", "viewerDid", req.ViewerDid)
+
+	relationships := make(map[string]*vyletdatabase.BlockRelationship)
+	if len(req.Dids) == 0 {
+		return &vyletdatabase.GetBlockRelationshipsResponse{
+			Relationships: relationships,
+		}, nil
+	}
+
+	relationship := func(did string) *vyletdatabase.BlockRelationship {
+		r, ok := relationships[did]
+		if !ok {
+			r = &vyletdatabase.BlockRelationship{}
+			relationships[did] = r
+		}
+		return r
+	}
+
+	var did, uri string
+
+	iter := s.cqlSession.Query(`
+		SELECT subject_did, uri
+		FROM blocks_by_author_did_subject_did
+		WHERE author_did = ? AND subject_did IN ?
+	`, req.ViewerDid, req.Dids).WithContext(ctx).Iter()
+	for iter.Scan(&did, &uri) {
+		relationship(did).Blocking = helpers.ToStringPtr(uri)
+	}
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate blocks", "err", err)
+		return &vyletdatabase.GetBlockRelationshipsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	iter = s.cqlSession.Query(`
+		SELECT author_did
+		FROM blocks_by_author_did_subject_did
+		WHERE author_did IN ? AND subject_did = ?
+	`, req.Dids, req.ViewerDid).WithContext(ctx).Iter()
+	for iter.Scan(&did) {
+		relationship(did).BlockedBy = true
+	}
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate blocked by", "err", err)
+		return &vyletdatabase.GetBlockRelationshipsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.GetBlockRelationshipsResponse{
+		Relationships: relationships,
+	}, nil
+}
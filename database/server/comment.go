@@ -0,0 +1,487 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/gocql/gocql"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/internal/helpers"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// incrementReplyCounts adds delta to the reply count of the thread's root, and of the parent when it is a comment.
+// Both are sent as one request, so that a failure leaves neither of them changed rather than only the root.
+func (s *Server) incrementReplyCounts(ctx context.Context, rootUri, parentUri string, delta int64) error {
+	query := `
+		UPDATE post_interaction_counts
+		SET reply_count = reply_count + ?
+		WHERE post_uri = ?
+	`
+
+	batch := s.cqlSession.NewBatch(gocql.CounterBatch).WithContext(ctx)
+	batch.Query(query, delta, rootUri)
+	if parentUri != rootUri {
+		batch.Query(query, delta, parentUri)
+	}
+
+	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
+		return fmt.Errorf("failed to update reply counts: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) CreateComment(ctx context.Context, req *vyletdatabase.CreateCommentRequest) (*vyletdatabase.CreateCommentResponse, error) {
+	logger := s.logger.With("name", "CreateComment")
+
+	aturi, err := syntax.ParseATURI(req.Comment.Uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse aturi: %w", err)
+	}
+
+	did := aturi.Authority().String()
+	now := time.Now().UTC()
+
+	stale, err := s.staleRev(ctx, req.Comment.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "uri", req.Comment.Uri, "err", err)
+		return &vyletdatabase.CreateCommentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.CreateCommentResponse{}, nil
+	}
+
+	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+
+	commentArgs := []any{
+		req.Comment.Uri,
+		req.Comment.Cid,
+		did,
+		req.Comment.Text,
+		req.Comment.Facets,
+		req.Comment.RootUri,
+		req.Comment.RootCid,
+		req.Comment.ParentUri,
+		req.Comment.ParentCid,
+		req.Comment.CreatedAt.AsTime(),
+		now,
+	}
+
+	commentQuery := `
+		INSERT INTO %s
+			(uri, cid, author_did, text, facets, root_uri, root_cid, parent_uri, parent_cid, created_at, indexed_at)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	batch.Query(fmt.Sprintf(commentQuery, "comments_by_parent"), commentArgs...)
+	batch.Query(fmt.Sprintf(commentQuery, "comments_by_author_did"), commentArgs...)
+
+	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
+		logger.Error("failed to create comment", "uri", req.Comment.Uri, "err", err)
+		return &vyletdatabase.CreateCommentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	// comments_by_uri is written last, so that a retry after a failure above still inserts it and counts the comment
+	inserted, err := s.execCAS(ctx, fmt.Sprintf(commentQuery, "comments_by_uri")+" IF NOT EXISTS", commentArgs...)
+	if err != nil {
+		logger.Error("failed to create comment", "uri", req.Comment.Uri, "err", err)
+		return &vyletdatabase.CreateCommentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	if inserted {
+		if err := s.incrementReplyCounts(ctx, req.Comment.RootUri, req.Comment.ParentUri, 1); err != nil {
+			logger.Error("failed to increment reply counts", "uri", req.Comment.Uri, "err", err)
+			// release comments_by_uri, which would otherwise make the retry skip counting the comment
+			if _, err := s.execCAS(ctx, `
+				DELETE FROM comments_by_uri
+				WHERE uri = ?
+				IF EXISTS
+			`, req.Comment.Uri); err != nil {
+				logger.Error("failed to release comment", "uri", req.Comment.Uri, "err", err)
+			}
+			return &vyletdatabase.CreateCommentResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	if err := s.setRev(ctx, req.Comment.Uri, req.Rev, false); err != nil {
+		logger.Error("failed to set record rev", "uri", req.Comment.Uri, "err", err)
+		return &vyletdatabase.CreateCommentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.CreateCommentResponse{}, nil
+}
+
+func (s *Server) DeleteComment(ctx context.Context, req *vyletdatabase.DeleteCommentRequest) (*vyletdatabase.DeleteCommentResponse, error) {
+	logger := s.logger.With("name", "DeleteComment", "uri", req.Uri)
+
+	stale, err := s.staleRev(ctx, req.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "err", err)
+		return &vyletdatabase.DeleteCommentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.DeleteCommentResponse{}, nil
+	}
+
+	// the whole comment is read, so that it can be restored when the reply counts fail to update
+	iter := s.cqlSession.Query(`
+		SELECT uri, cid, author_did, text, facets, root_uri, root_cid, parent_uri, parent_cid, created_at, indexed_at
+		FROM comments_by_uri
+		WHERE uri = ?
+	`, req.Uri).WithContext(ctx).Iter()
+	comment, found := scanComment(iter)
+	err = iter.Close()
+	if err == nil && !found {
+		err = gocql.ErrNotFound
+	}
+	if err != nil {
+		if err == gocql.ErrNotFound {
+			// a delete processed before its create leaves a tombstone, so the create is skipped once it arrives
+			if req.Rev != "" {
+				if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+					logger.Error("failed to set record rev", "err", err)
+					return &vyletdatabase.DeleteCommentResponse{
+						Error: helpers.ToStringPtr(err.Error()),
+					}, nil
+				}
+				return &vyletdatabase.DeleteCommentResponse{}, nil
+			}
+			logger.Warn("comment not found", "uri", req.Uri)
+			return &vyletdatabase.DeleteCommentResponse{
+				Error: helpers.ToStringPtr("comment not found"),
+			}, nil
+		}
+		logger.Error("failed to fetch comment", "uri", req.Uri, "err", err)
+		return &vyletdatabase.DeleteCommentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	var (
+		createdAt = comment.CreatedAt.AsTime()
+		authorDid = comment.AuthorDid
+		rootUri   = comment.RootUri
+		parentUri = comment.ParentUri
+	)
+
+	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+
+	batch.Query(`
+		DELETE FROM comments_by_parent
+		WHERE parent_uri = ? AND created_at = ? AND uri = ?
+	`, parentUri, createdAt, req.Uri)
+
+	batch.Query(`
+		DELETE FROM comments_by_author_did
+		WHERE author_did = ? AND created_at = ? AND uri = ?
+	`, authorDid, createdAt, req.Uri)
+
+	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
+		logger.Error("failed to delete comment", "uri", req.Uri, "err", err)
+		return &vyletdatabase.DeleteCommentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	// comments_by_uri is deleted last, so that a retry after a failure above can still find the comment's keys
+	removed, err := s.execCAS(ctx, `
+		DELETE FROM comments_by_uri
+		WHERE uri = ?
+		IF EXISTS
+	`, req.Uri)
+	if err != nil {
+		logger.Error("failed to delete comment", "uri", req.Uri, "err", err)
+		return &vyletdatabase.DeleteCommentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	// replies to the deleted comment are kept, and still count towards the thread
+	if removed {
+		if err := s.incrementReplyCounts(ctx, rootUri, parentUri, -1); err != nil {
+			logger.Error("failed to decrement reply counts", "uri", req.Uri, "err", err)
+			// restore comments_by_uri, which the retry needs to find the comment and uncount it
+			if _, err := s.execCAS(ctx, `
+				INSERT INTO comments_by_uri
+					(uri, cid, author_did, text, facets, root_uri, root_cid, parent_uri, parent_cid, created_at, indexed_at)
+				VALUES
+					(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				IF NOT EXISTS
+			`,
+				comment.Uri,
+				comment.Cid,
+				comment.AuthorDid,
+				comment.Text,
+				comment.Facets,
+				comment.RootUri,
+				comment.RootCid,
+				comment.ParentUri,
+				comment.ParentCid,
+				createdAt,
+				comment.IndexedAt.AsTime(),
+			); err != nil {
+				logger.Error("failed to restore comment", "uri", req.Uri, "err", err)
+			}
+			return &vyletdatabase.DeleteCommentResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+		logger.Error("failed to set record rev", "err", err)
+		return &vyletdatabase.DeleteCommentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.DeleteCommentResponse{}, nil
+}
+
+func (s *Server) GetComments(ctx context.Context, req *vyletdatabase.GetCommentsRequest) (*vyletdatabase.GetCommentsResponse, error) {
+	logger := s.logger.With("name", "GetComments", "uris", req.Uris)
+
+	if len(req.Uris) == 0 {
+		return nil, fmt.Errorf("at least one URI must be specified")
+	}
+
+	query := `
+		SELECT uri, cid, author_did, text, facets, root_uri, root_cid, parent_uri, parent_cid, created_at, indexed_at
+		FROM comments_by_uri
+		WHERE uri IN ?
+	`
+
+	iter := s.cqlSession.Query(query, req.Uris).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	comments := make(map[string]*vyletdatabase.Comment)
+	for {
+		comment, ok := scanComment(iter)
+		if !ok {
+			break
+		}
+		comments[comment.Uri] = comment
+	}
+
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate comments", "err", err)
+		return &vyletdatabase.GetCommentsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.GetCommentsResponse{
+		Comments: comments,
+	}, nil
+}
+
+func (s *Server) GetCommentsByParent(ctx context.Context, req *vyletdatabase.GetCommentsByParentRequest) (*vyletdatabase.GetCommentsByParentResponse, error) {
+	logger := s.logger.With("name", "GetCommentsByParent", "parentUri", req.ParentUri)
+
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var (
+		query string
+		args  []any
+	)
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		cursorParts := strings.SplitN(*req.Cursor, "|", 2)
+		if len(cursorParts) != 2 {
+			logger.Error("invalid cursor format", "cursor", *req.Cursor)
+			return &vyletdatabase.GetCommentsByParentResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+
+		cursorTime, err := time.Parse(time.RFC3339Nano, cursorParts[0])
+		if err != nil {
+			logger.Error("failed to parse cursor timestamp", "cursor", *req.Cursor, "err", err)
+			return &vyletdatabase.GetCommentsByParentResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+		cursorUri := cursorParts[1]
+
+		query = `
+			SELECT uri, cid, author_did, text, facets, root_uri, root_cid, parent_uri, parent_cid, created_at, indexed_at
+			FROM comments_by_parent
+			WHERE parent_uri = ? AND (created_at, uri) > (?, ?)
+			ORDER BY created_at ASC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.ParentUri, cursorTime, cursorUri, req.Limit + 1}
+	} else {
+		query = `
+			SELECT uri, cid, author_did, text, facets, root_uri, root_cid, parent_uri, parent_cid, created_at, indexed_at
+			FROM comments_by_parent
+			WHERE parent_uri = ?
+			ORDER BY created_at ASC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.ParentUri, req.Limit + 1}
+	}
+
+	iter := s.cqlSession.Query(query, args...).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var comments []*vyletdatabase.Comment
+	for {
+		comment, ok := scanComment(iter)
+		if !ok {
+			break
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate comments", "err", err)
+		return &vyletdatabase.GetCommentsByParentResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	var nextCursor *string
+	if len(comments) > int(req.Limit) {
+		comments = comments[:req.Limit]
+		last := comments[len(comments)-1]
+		cursorStr := fmt.Sprintf("%s|%s",
+			last.CreatedAt.AsTime().Format(time.RFC3339Nano),
+			last.Uri)
+		nextCursor = &cursorStr
+	}
+
+	return &vyletdatabase.GetCommentsByParentResponse{
+		Comments: comments,
+		Cursor:   nextCursor,
+	}, nil
+}
+
+func (s *Server) GetCommentsByActor(ctx context.Context, req *vyletdatabase.GetCommentsByActorRequest) (*vyletdatabase.GetCommentsByActorResponse, error) {
+	logger := s.logger.With("name", "GetCommentsByActor", "did", req.Did)
+
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var (
+		query string
+		args  []any
+	)
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		cursorParts := strings.SplitN(*req.Cursor, "|", 2)
+		if len(cursorParts) != 2 {
+			logger.Error("invalid cursor format", "cursor", *req.Cursor)
+			return &vyletdatabase.GetCommentsByActorResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+
+		cursorTime, err := time.Parse(time.RFC3339Nano, cursorParts[0])
+		if err != nil {
+			logger.Error("failed to parse cursor timestamp", "cursor", *req.Cursor, "err", err)
+			return &vyletdatabase.GetCommentsByActorResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+		cursorUri := cursorParts[1]
+
+		query = `
+			SELECT uri, cid, author_did, text, facets, root_uri, root_cid, parent_uri, parent_cid, created_at, indexed_at
+			FROM comments_by_author_did
+			WHERE author_did = ? AND (created_at, uri) < (?, ?)
+			ORDER BY created_at DESC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, cursorTime, cursorUri, req.Limit + 1}
+	} else {
+		query = `
+			SELECT uri, cid, author_did, text, facets, root_uri, root_cid, parent_uri, parent_cid, created_at, indexed_at
+			FROM comments_by_author_did
+			WHERE author_did = ?
+			ORDER BY created_at DESC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, req.Limit + 1}
+	}
+
+	iter := s.cqlSession.Query(query, args...).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var comments []*vyletdatabase.Comment
+	for {
+		comment, ok := scanComment(iter)
+		if !ok {
+			break
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate comments", "err", err)
+		return &vyletdatabase.GetCommentsByActorResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	var nextCursor *string
+	if len(comments) > int(req.Limit) {
+		comments = comments[:req.Limit]
+		last := comments[len(comments)-1]
+		cursorStr := fmt.Sprintf("%s|%s",
+			last.CreatedAt.AsTime().Format(time.RFC3339Nano),
+			last.Uri)
+		nextCursor = &cursorStr
+	}
+
+	return &vyletdatabase.GetCommentsByActorResponse{
+		Comments: comments,
+		Cursor:   nextCursor,
+	}, nil
+}
+
+func scanComment(iter *gocql.Iter) (*vyletdatabase.Comment, bool) {
+	comment := &vyletdatabase.Comment{}
+	var createdAt, indexedAt time.Time
+
+	if !iter.Scan(
+		&comment.Uri,
+		&comment.Cid,
+		&comment.AuthorDid,
+		&comment.Text,
+		&comment.Facets,
+		&comment.RootUri,
+		&comment.RootCid,
+		&comment.ParentUri,
+		&comment.ParentCid,
+		&createdAt,
+		&indexedAt,
+	) {
+		return nil, false
+	}
+
+	comment.CreatedAt = timestamppb.New(createdAt)
+	comment.IndexedAt = timestamppb.New(indexedAt)
+
+	return comment, true
+}
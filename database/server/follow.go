@@ -18,6 +18,17 @@ func (s *Server) CreateFollow(ctx context.Context, req *vyletdatabase.CreateFoll
 
 	now := time.Now().UTC()
 
+	stale, err := s.staleRev(ctx, req.Follow.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "err", err)
+		return &vyletdatabase.CreateFollowResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.CreateFollowResponse{}, nil
+	}
+
 	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	args := []any{
@@ -38,7 +49,6 @@ func (s *Server) CreateFollow(ctx context.Context, req *vyletdatabase.CreateFoll
 
 	batch.Query(fmt.Sprintf(query, "follows_by_subject_did"), args...)
 	batch.Query(fmt.Sprintf(query, "follows_by_author_did"), args...)
-	batch.Query(fmt.Sprintf(query, "follows_by_uri"), args...)
 	batch.Query(fmt.Sprintf(query, "follows_by_author_did_subject_did"), args...)
 
 	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
@@ -48,23 +58,41 @@ func (s *Server) CreateFollow(ctx context.Context, req *vyletdatabase.CreateFoll
 		}, nil
 	}
 
-	if err := s.cqlSession.Query(`
-		UPDATE follow_counts
-		SET follows_count = follows_count + 1
-		WHERE did = ?
-	`, req.Follow.AuthorDid).WithContext(ctx).Exec(); err != nil {
-		logger.Error("failed to increment follows count", "err", err)
+	// follows_by_uri is written last, so that a retry after a failure above still inserts it and counts the follow
+	inserted, err := s.execCAS(ctx, fmt.Sprintf(query, "follows_by_uri")+" IF NOT EXISTS", args...)
+	if err != nil {
+		logger.Error("failed to create follow", "err", err)
 		return &vyletdatabase.CreateFollowResponse{
 			Error: helpers.ToStringPtr(err.Error()),
 		}, nil
 	}
 
-	if err := s.cqlSession.Query(`
-		UPDATE follow_counts
-		SET followers_count = followers_count + 1
-		WHERE did = ?
-	`, req.Follow.SubjectDid).WithContext(ctx).Exec(); err != nil {
-		logger.Error("failed to increment followers count", "err", err)
+	if inserted {
+		if err := s.cqlSession.Query(`
+			UPDATE follow_counts
+			SET follows_count = follows_count + 1
+			WHERE did = ?
+		`, req.Follow.AuthorDid).WithContext(ctx).Exec(); err != nil {
+			logger.Error("failed to increment follows count", "err", err)
+			return &vyletdatabase.CreateFollowResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+
+		if err := s.cqlSession.Query(`
+			UPDATE follow_counts
+			SET followers_count = followers_count + 1
+			WHERE did = ?
+		`, req.Follow.SubjectDid).WithContext(ctx).Exec(); err != nil {
+			logger.Error("failed to increment followers count", "err", err)
+			return &vyletdatabase.CreateFollowResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	if err := s.setRev(ctx, req.Follow.Uri, req.Rev, false); err != nil {
+		logger.Error("failed to set record rev", "err", err)
 		return &vyletdatabase.CreateFollowResponse{
 			Error: helpers.ToStringPtr(err.Error()),
 		}, nil
@@ -76,6 +104,17 @@ func (s *Server) CreateFollow(ctx context.Context, req *vyletdatabase.CreateFoll
 func (s *Server) DeleteFollow(ctx context.Context, req *vyletdatabase.DeleteFollowRequest) (*vyletdatabase.DeleteFollowResponse, error) {
 	logger := s.logger.With("name", "DeleteFollow", "uri", req.Uri)
 
+	stale, err := s.staleRev(ctx, req.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "err", err)
+		return &vyletdatabase.DeleteFollowResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.DeleteFollowResponse{}, nil
+	}
+
 	var (
 		createdAt  time.Time
 		subjectDid string
@@ -89,6 +128,16 @@ func (s *Server) DeleteFollow(ctx context.Context, req *vyletdatabase.DeleteFoll
 	`
 	if err := s.cqlSession.Query(query, req.Uri).WithContext(ctx).Scan(&createdAt, &subjectDid, &authorDid); err != nil {
 		if err == gocql.ErrNotFound {
+			// a delete processed before its create leaves a tombstone, so the create is skipped once it arrives
+			if req.Rev != "" {
+				if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+					logger.Error("failed to set record rev", "err", err)
+					return &vyletdatabase.DeleteFollowResponse{
+						Error: helpers.ToStringPtr(err.Error()),
+					}, nil
+				}
+				return &vyletdatabase.DeleteFollowResponse{}, nil
+			}
 			logger.Warn("follow not found", "uri", req.Uri)
 			return &vyletdatabase.DeleteFollowResponse{
 				Error: helpers.ToStringPtr("follow not found"),
@@ -104,11 +153,6 @@ func (s *Server) DeleteFollow(ctx context.Context, req *vyletdatabase.DeleteFoll
 
 	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
-	batch.Query(`
-		DELETE FROM follows_by_uri
-		WHERE uri = ?
-	`, req.Uri)
-
 	batch.Query(`
 		DELETE FROM follows_by_subject_did
 		WHERE subject_did = ? AND created_at = ? AND uri = ?
@@ -131,23 +175,45 @@ func (s *Server) DeleteFollow(ctx context.Context, req *vyletdatabase.DeleteFoll
 		}, nil
 	}
 
-	if err := s.cqlSession.Query(`
-		UPDATE follow_counts
-		SET follows_count = follows_count - 1
-		WHERE did = ?
-	`, authorDid).WithContext(ctx).Exec(); err != nil {
-		logger.Error("failed to decrement follows count", "err", err)
+	// follows_by_uri is deleted last, so that a retry after a failure above can still find the follow's keys
+	removed, err := s.execCAS(ctx, `
+		DELETE FROM follows_by_uri
+		WHERE uri = ?
+		IF EXISTS
+	`, req.Uri)
+	if err != nil {
+		logger.Error("failed to delete follow", "uri", req.Uri, "err", err)
 		return &vyletdatabase.DeleteFollowResponse{
 			Error: helpers.ToStringPtr(err.Error()),
 		}, nil
 	}
 
-	if err := s.cqlSession.Query(`
-		UPDATE follow_counts
-		SET followers_count = followers_count - 1
-		WHERE did = ?
-	`, subjectDid).WithContext(ctx).Exec(); err != nil {
-		logger.Error("failed to decrement followers count", "err", err)
+	if removed {
+		if err := s.cqlSession.Query(`
+			UPDATE follow_counts
+			SET follows_count = follows_count - 1
+			WHERE did = ?
+		`, authorDid).WithContext(ctx).Exec(); err != nil {
+			logger.Error("failed to decrement follows count", "err", err)
+			return &vyletdatabase.DeleteFollowResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+
+		if err := s.cqlSession.Query(`
+			UPDATE follow_counts
+			SET followers_count = followers_count - 1
+			WHERE did = ?
+		`, subjectDid).WithContext(ctx).Exec(); err != nil {
+			logger.Error("failed to decrement followers count", "err", err)
+			return &vyletdatabase.DeleteFollowResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+		logger.Error("failed to set record rev", "err", err)
 		return &vyletdatabase.DeleteFollowResponse{
 			Error: helpers.ToStringPtr(err.Error()),
 		}, nil
@@ -202,7 +268,7 @@ func (s *Server) GetFollowsByActor(ctx context.Context, req *vyletdatabase.GetFo
 			ORDER BY created_at DESC, uri ASC
 			LIMIT ?
 		`
-		args = []any{req.Did, req.Limit}
+		args = []any{req.Did, req.Limit + 1}
 	}
 
 	iter := s.cqlSession.Query(query, args...).WithContext(ctx).Iter()
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/gocql/gocql"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/internal/helpers"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxHandleCASAttempts bounds how often a handle write is retried when the row changes between reading and writing it
+const maxHandleCASAttempts = 5
+
+func (s *Server) SetHandle(ctx context.Context, req *vyletdatabase.SetHandleRequest) (*vyletdatabase.SetHandleResponse, error) {
+	logger := s.logger.With("name", "SetHandle", "did", req.Handle.Did)
+
+	// handles are case insensitive, so they are stored normalized
+	handle := strings.ToLower(req.Handle.Handle)
+
+	var updatedAt *time.Time
+	if req.Handle.UpdatedAt != nil {
+		t := req.Handle.UpdatedAt.AsTime()
+		updatedAt = &t
+	}
+
+	prevHandle, applied, err := s.setNewerHandle(ctx, "handles_by_did", "did", req.Handle.Did, "handle", handle, updatedAt)
+	if err != nil {
+		logger.Error("failed to set handle", "err", err)
+		return &vyletdatabase.SetHandleResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if !applied {
+		// a newer handle is already stored
+		return &vyletdatabase.SetHandleResponse{}, nil
+	}
+
+	if handle != syntax.HandleInvalid.String() {
+		if _, _, err := s.setNewerHandle(ctx, "did_by_handle", "handle", handle, "did", req.Handle.Did, updatedAt); err != nil {
+			logger.Error("failed to set did by handle", "err", err)
+			return &vyletdatabase.SetHandleResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	// the previous handle may already belong to another did, in which case its row is left alone
+	if prevHandle != "" && prevHandle != handle && prevHandle != syntax.HandleInvalid.String() {
+		if _, err := s.execCAS(ctx, `
+			DELETE FROM did_by_handle
+			WHERE handle = ?
+			IF did = ?
+		`, prevHandle, req.Handle.Did); err != nil {
+			logger.Error("failed to delete previous handle", "handle", prevHandle, "err", err)
+			return &vyletdatabase.SetHandleResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	return &vyletdatabase.SetHandleResponse{}, nil
+}
+
+// setNewerHandle sets the value of the key in one of the handle tables, unless the stored row is at least as recent.
+// A nil updatedAt only inserts a missing row. Every write is a lightweight transaction, so that concurrent updates and
+// the conditional deletes of previous handles are applied in a consistent order. Returns the value that was replaced
+// and whether the write applied.
+func (s *Server) setNewerHandle(ctx context.Context, table, keyCol, key, valCol, val string, updatedAt *time.Time) (string, bool, error) {
+	for range maxHandleCASAttempts {
+		var (
+			prevVal       string
+			prevUpdatedAt *time.Time
+		)
+		err := s.cqlSession.Query(fmt.Sprintf(`
+			SELECT %s, updated_at
+			FROM %s
+			WHERE %s = ?
+		`, valCol, table, keyCol), key).WithContext(ctx).Scan(&prevVal, &prevUpdatedAt)
+		if err == gocql.ErrNotFound {
+			applied, err := s.execCAS(ctx, fmt.Sprintf(`
+				INSERT INTO %s
+					(%s, %s, updated_at)
+				VALUES
+					(?, ?, ?)
+				IF NOT EXISTS
+			`, table, keyCol, valCol), key, val, updatedAt)
+			if err != nil {
+				return "", false, err
+			}
+			if applied {
+				return "", true, nil
+			}
+			// inserted concurrently, so compare against that row
+			continue
+		}
+		if err != nil {
+			return "", false, err
+		}
+
+		if updatedAt == nil || (prevUpdatedAt != nil && !prevUpdatedAt.Before(*updatedAt)) {
+			return prevVal, false, nil
+		}
+
+		applied, err := s.execCAS(ctx, fmt.Sprintf(`
+			UPDATE %s
+			SET %s = ?, updated_at = ?
+			WHERE %s = ?
+			IF %s = ? AND updated_at = ?
+		`, table, valCol, keyCol, valCol), val, updatedAt, key, prevVal, prevUpdatedAt)
+		if err != nil {
+			return "", false, err
+		}
+		if applied {
+			return prevVal, true, nil
+		}
+	}
+
+	return "", false, fmt.Errorf("%s row %s kept changing", table, key)
+}
+
+func (s *Server) GetHandles(ctx context.Context, req *vyletdatabase.GetHandlesRequest) (*vyletdatabase.GetHandlesResponse, error) {
+	logger := s.logger.With("name", "GetHandles")
+
+	if len(req.Dids) == 0 {
+		return nil, fmt.Errorf("at least one DID must be specified")
+	}
+
+	iter := s.cqlSession.Query(`
+		SELECT did, handle, updated_at
+		FROM handles_by_did
+		WHERE did IN ?
+	`, req.Dids).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	handles := make(map[string]*vyletdatabase.Handle)
+	for {
+		handle := &vyletdatabase.Handle{}
+		var updatedAt *time.Time
+
+		if !iter.Scan(
+			&handle.Did,
+			&handle.Handle,
+			&updatedAt,
+		) {
+			break
+		}
+
+		// handles written back after a directory lookup have no update time
+		if updatedAt != nil {
+			handle.UpdatedAt = timestamppb.New(*updatedAt)
+		}
+		handles[handle.Did] = handle
+	}
+
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate handles", "err", err)
+		return &vyletdatabase.GetHandlesResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.GetHandlesResponse{
+		Handles: handles,
+	}, nil
+}
+
+func (s *Server) GetDidByHandle(ctx context.Context, req *vyletdatabase.GetDidByHandleRequest) (*vyletdatabase.GetDidByHandleResponse, error) {
+	logger := s.logger.With("name", "GetDidByHandle", "handle", req.Handle)
+
+	var did string
+	if err := s.cqlSession.Query(`
+		SELECT did
+		FROM did_by_handle
+		WHERE handle = ?
+	`, strings.ToLower(req.Handle)).WithContext(ctx).Scan(&did); err != nil {
+		if err == gocql.ErrNotFound {
+			return &vyletdatabase.GetDidByHandleResponse{
+				Error: helpers.ToStringPtr("not found"),
+			}, nil
+		}
+		logger.Error("failed to fetch did", "err", err)
+		return &vyletdatabase.GetDidByHandleResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.GetDidByHandleResponse{
+		Did: &did,
+	}, nil
+}
@@ -24,6 +24,17 @@ func (s *Server) CreateLike(ctx context.Context, req *vyletdatabase.CreateLikeRe
 	did := aturi.Authority().String()
 	now := time.Now().UTC()
 
+	stale, err := s.staleRev(ctx, req.Like.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "uri", req.Like.Uri, "err", err)
+		return &vyletdatabase.CreateLikeResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.CreateLikeResponse{}, nil
+	}
+
 	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	likeArgs := []any{
@@ -45,7 +56,6 @@ func (s *Server) CreateLike(ctx context.Context, req *vyletdatabase.CreateLikeRe
 
 	batch.Query(fmt.Sprintf(likeQuery, "likes_by_subject"), likeArgs...)
 	batch.Query(fmt.Sprintf(likeQuery, "likes_by_actor"), likeArgs...)
-	batch.Query(fmt.Sprintf(likeQuery, "likes_by_uri"), likeArgs...)
 	batch.Query(fmt.Sprintf(likeQuery, "likes_by_actor_subject"), likeArgs...)
 
 	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
@@ -55,12 +65,30 @@ func (s *Server) CreateLike(ctx context.Context, req *vyletdatabase.CreateLikeRe
 		}, nil
 	}
 
-	if err := s.cqlSession.Query(`
-		UPDATE post_interaction_counts
-		SET like_count = like_count + 1
-		WHERE post_uri = ?
-	`, req.Like.SubjectUri).WithContext(ctx).Exec(); err != nil {
-		logger.Error("failed to increment like count", "subject_uri", req.Like.SubjectUri, "err", err)
+	// likes_by_uri is written last, so that a retry after a failure above still inserts it and counts the like
+	inserted, err := s.execCAS(ctx, fmt.Sprintf(likeQuery, "likes_by_uri")+" IF NOT EXISTS", likeArgs...)
+	if err != nil {
+		logger.Error("failed to create like", "uri", req.Like.Uri, "err", err)
+		return &vyletdatabase.CreateLikeResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	if inserted {
+		if err := s.cqlSession.Query(`
+			UPDATE post_interaction_counts
+			SET like_count = like_count + 1
+			WHERE post_uri = ?
+		`, req.Like.SubjectUri).WithContext(ctx).Exec(); err != nil {
+			logger.Error("failed to increment like count", "subject_uri", req.Like.SubjectUri, "err", err)
+			return &vyletdatabase.CreateLikeResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	if err := s.setRev(ctx, req.Like.Uri, req.Rev, false); err != nil {
+		logger.Error("failed to set record rev", "uri", req.Like.Uri, "err", err)
 		return &vyletdatabase.CreateLikeResponse{
 			Error: helpers.ToStringPtr(err.Error()),
 		}, nil
@@ -69,9 +97,161 @@ func (s *Server) CreateLike(ctx context.Context, req *vyletdatabase.CreateLikeRe
 	return &vyletdatabase.CreateLikeResponse{}, nil
 }
 
+func (s *Server) UpdateLike(ctx context.Context, req *vyletdatabase.UpdateLikeRequest) (*vyletdatabase.UpdateLikeResponse, error) {
+	logger := s.logger.With("name", "UpdateLike", "uri", req.Like.Uri)
+
+	stale, err := s.staleRev(ctx, req.Like.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "err", err)
+		return &vyletdatabase.UpdateLikeResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.UpdateLikeResponse{}, nil
+	}
+
+	var (
+		prevCid        string
+		prevSubjectUri string
+		authorDid      string
+		createdAt      time.Time
+		indexedAt      time.Time
+	)
+
+	query := `
+		SELECT cid, subject_uri, author_did, created_at, indexed_at
+		FROM likes_by_uri
+		WHERE uri = ?
+	`
+	if err := s.cqlSession.Query(query, req.Like.Uri).WithContext(ctx).Scan(&prevCid, &prevSubjectUri, &authorDid, &createdAt, &indexedAt); err != nil {
+		if err == gocql.ErrNotFound {
+			return &vyletdatabase.UpdateLikeResponse{
+				Error: helpers.ToStringPtr("not found"),
+			}, nil
+		}
+		logger.Error("failed to fetch like", "err", err)
+		return &vyletdatabase.UpdateLikeResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	// the same update may be delivered more than once
+	if prevCid == req.Like.Cid {
+		if err := s.setRev(ctx, req.Like.Uri, req.Rev, false); err != nil {
+			logger.Error("failed to set record rev", "err", err)
+			return &vyletdatabase.UpdateLikeResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+		return &vyletdatabase.UpdateLikeResponse{}, nil
+	}
+
+	subjectChanged := prevSubjectUri != req.Like.SubjectUri
+
+	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+
+	if subjectChanged {
+		batch.Query(`
+			DELETE FROM likes_by_subject
+			WHERE subject_uri = ? AND created_at = ? AND uri = ?
+		`, prevSubjectUri, createdAt, req.Like.Uri)
+
+		batch.Query(`
+			DELETE FROM likes_by_actor_subject
+			WHERE author_did = ? AND subject_uri = ?
+		`, authorDid, prevSubjectUri)
+	}
+
+	// created_at is part of the tables' keys, so the original is kept to overwrite the existing rows
+	likeArgs := []any{
+		req.Like.Uri,
+		req.Like.Cid,
+		req.Like.SubjectUri,
+		req.Like.SubjectCid,
+		authorDid,
+		createdAt,
+		indexedAt,
+	}
+
+	likeQuery := `
+		INSERT INTO %s
+			(uri, cid, subject_uri, subject_cid, author_did, created_at, indexed_at)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?)
+	`
+
+	batch.Query(fmt.Sprintf(likeQuery, "likes_by_subject"), likeArgs...)
+	batch.Query(fmt.Sprintf(likeQuery, "likes_by_actor"), likeArgs...)
+	batch.Query(fmt.Sprintf(likeQuery, "likes_by_actor_subject"), likeArgs...)
+
+	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
+		logger.Error("failed to update like", "err", err)
+		return &vyletdatabase.UpdateLikeResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	// likes_by_uri is only written through lightweight transactions, and last, so that a retry after a failure above
+	// still finds the previous cid. Only the write that moves the like off its previous cid moves the counts, so a
+	// duplicate delivery racing this one can't move them twice.
+	updated, err := s.execCAS(ctx, `
+		UPDATE likes_by_uri
+		SET cid = ?, subject_uri = ?, subject_cid = ?
+		WHERE uri = ?
+		IF cid = ?
+	`, req.Like.Cid, req.Like.SubjectUri, req.Like.SubjectCid, req.Like.Uri, prevCid)
+	if err != nil {
+		logger.Error("failed to update like", "err", err)
+		return &vyletdatabase.UpdateLikeResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	if updated && subjectChanged {
+		countQuery := `
+			UPDATE post_interaction_counts
+			SET like_count = like_count + ?
+			WHERE post_uri = ?
+		`
+
+		// both moves are sent as one request, so the like is never taken off one post without being added to the other
+		countBatch := s.cqlSession.NewBatch(gocql.CounterBatch).WithContext(ctx)
+		countBatch.Query(countQuery, -1, prevSubjectUri)
+		countBatch.Query(countQuery, 1, req.Like.SubjectUri)
+
+		if err := s.cqlSession.ExecuteBatch(countBatch); err != nil {
+			logger.Error("failed to move like count", "subject_uri", req.Like.SubjectUri, "prev_subject_uri", prevSubjectUri, "err", err)
+			return &vyletdatabase.UpdateLikeResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	if err := s.setRev(ctx, req.Like.Uri, req.Rev, false); err != nil {
+		logger.Error("failed to set record rev", "err", err)
+		return &vyletdatabase.UpdateLikeResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.UpdateLikeResponse{}, nil
+}
+
 func (s *Server) DeleteLike(ctx context.Context, req *vyletdatabase.DeleteLikeRequest) (*vyletdatabase.DeleteLikeResponse, error) {
 	logger := s.logger.With("name", "DeleteLike", "uri", req.Uri)
 
+	stale, err := s.staleRev(ctx, req.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "err", err)
+		return &vyletdatabase.DeleteLikeResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.DeleteLikeResponse{}, nil
+	}
+
 	var (
 		createdAt  time.Time
 		subjectUri string
@@ -85,6 +265,16 @@ func (s *Server) DeleteLike(ctx context.Context, req *vyletdatabase.DeleteLikeRe
 	`
 	if err := s.cqlSession.Query(query, req.Uri).WithContext(ctx).Scan(&createdAt, &subjectUri, &authorDid); err != nil {
 		if err == gocql.ErrNotFound {
+			// a delete processed before its create leaves a tombstone, so the create is skipped once it arrives
+			if req.Rev != "" {
+				if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+					logger.Error("failed to set record rev", "err", err)
+					return &vyletdatabase.DeleteLikeResponse{
+						Error: helpers.ToStringPtr(err.Error()),
+					}, nil
+				}
+				return &vyletdatabase.DeleteLikeResponse{}, nil
+			}
 			logger.Warn("like not found", "uri", req.Uri)
 			return &vyletdatabase.DeleteLikeResponse{
 				Error: helpers.ToStringPtr("like not found"),
@@ -98,11 +288,6 @@ func (s *Server) DeleteLike(ctx context.Context, req *vyletdatabase.DeleteLikeRe
 
 	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
-	batch.Query(`
-		DELETE FROM likes_by_uri
-		WHERE uri = ?
-	`, req.Uri)
-
 	batch.Query(`
 		DELETE FROM likes_by_subject
 		WHERE subject_uri = ? AND created_at = ? AND uri = ?
@@ -125,12 +310,34 @@ func (s *Server) DeleteLike(ctx context.Context, req *vyletdatabase.DeleteLikeRe
 		}, nil
 	}
 
-	if err := s.cqlSession.Query(`
-		UPDATE post_interaction_counts
-		SET like_count = like_count - 1
-		WHERE post_uri = ?
-	`, subjectUri).WithContext(ctx).Exec(); err != nil {
-		logger.Error("failed to increment like count", "subject_uri", subjectUri, "err", err)
+	// likes_by_uri is deleted last, so that a retry after a failure above can still find the like's keys
+	removed, err := s.execCAS(ctx, `
+		DELETE FROM likes_by_uri
+		WHERE uri = ?
+		IF EXISTS
+	`, req.Uri)
+	if err != nil {
+		logger.Error("failed to delete like", "uri", req.Uri, "err", err)
+		return &vyletdatabase.DeleteLikeResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	if removed {
+		if err := s.cqlSession.Query(`
+			UPDATE post_interaction_counts
+			SET like_count = like_count - 1
+			WHERE post_uri = ?
+		`, subjectUri).WithContext(ctx).Exec(); err != nil {
+			logger.Error("failed to decrement like count", "subject_uri", subjectUri, "err", err)
+			return &vyletdatabase.DeleteLikeResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+		logger.Error("failed to set record rev", "err", err)
 		return &vyletdatabase.DeleteLikeResponse{
 			Error: helpers.ToStringPtr(err.Error()),
 		}, nil
@@ -235,3 +442,100 @@ func (s *Server) GetLikesBySubject(ctx context.Context, req *vyletdatabase.GetLi
 		Cursor: nextCursor,
 	}, nil
 }
+
+func (s *Server) GetLikesByActor(ctx context.Context, req *vyletdatabase.GetLikesByActorRequest) (*vyletdatabase.GetLikesByActorResponse, error) {
+	logger := s.logger.With("name", "GetLikesByActor", "did", req.Did)
+
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var (
+		query string
+		args  []any
+	)
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		cursorParts := strings.SplitN(*req.Cursor, "|", 2)
+		if len(cursorParts) != 2 {
+			logger.Error("invalid cursor format", "cursor", *req.Cursor)
+			return &vyletdatabase.GetLikesByActorResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+
+		cursorTime, err := time.Parse(time.RFC3339Nano, cursorParts[0])
+		if err != nil {
+			logger.Error("failed to parse cursor timestamp", "cursor", *req.Cursor, "err", err)
+			return &vyletdatabase.GetLikesByActorResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+		cursorUri := cursorParts[1]
+
+		query = `
+			SELECT uri, cid, subject_uri, subject_cid, author_did, created_at, indexed_at
+			FROM likes_by_actor
+			WHERE author_did = ? AND (created_at, uri) < (?, ?)
+			ORDER BY created_at DESC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, cursorTime, cursorUri, req.Limit + 1}
+	} else {
+		query = `
+			SELECT uri, cid, subject_uri, subject_cid, author_did, created_at, indexed_at
+			FROM likes_by_actor
+			WHERE author_did = ?
+			ORDER BY created_at DESC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, req.Limit + 1}
+	}
+
+	iter := s.cqlSession.Query(query, args...).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var likes []*vyletdatabase.Like
+
+	var createdAt time.Time
+	var indexedAt time.Time
+	for {
+		like := &vyletdatabase.Like{}
+		if !iter.Scan(
+			&like.Uri,
+			&like.Cid,
+			&like.SubjectUri,
+			&like.SubjectCid,
+			&like.AuthorDid,
+			&createdAt,
+			&indexedAt,
+		) {
+			break
+		}
+		like.CreatedAt = timestamppb.New(createdAt)
+		like.IndexedAt = timestamppb.New(indexedAt)
+
+		likes = append(likes, like)
+	}
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate likes", "err", err)
+		return &vyletdatabase.GetLikesByActorResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	var nextCursor *string
+	if len(likes) > int(req.Limit) {
+		likes = likes[:req.Limit]
+		lastLike := likes[len(likes)-1]
+		cursorStr := fmt.Sprintf("%s|%s",
+			lastLike.CreatedAt.AsTime().Format(time.RFC3339Nano),
+			lastLike.Uri)
+		nextCursor = &cursorStr
+	}
+
+	return &vyletdatabase.GetLikesByActorResponse{
+		Likes:  likes,
+		Cursor: nextCursor,
+	}, nil
+}
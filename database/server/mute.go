@@ -0,0 +1,235 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/internal/helpers"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateMute(ctx context.Context, req *vyletdatabase.CreateMuteRequest) (*vyletdatabase.CreateMuteResponse, error) {
+	logger := s.logger.With("name", "CreateMute", "did", req.ActorDid, "subjectDid", req.SubjectDid)
+
+	now := time.Now().UTC()
+
+	// the pair row is claimed first, so that two concurrent mutes of the same subject cannot both list it in
+	// mutes_by_actor_did
+	applied, err := s.execCAS(ctx, `
+		INSERT INTO mutes_by_actor_did_subject_did
+			(actor_did, subject_did, created_at)
+		VALUES
+			(?, ?, ?)
+		IF NOT EXISTS
+	`, req.ActorDid, req.SubjectDid, now)
+	if err != nil {
+		logger.Error("failed to create mute", "err", err)
+		return &vyletdatabase.CreateMuteResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if !applied {
+		// already muted
+		return &vyletdatabase.CreateMuteResponse{}, nil
+	}
+
+	if err := s.cqlSession.Query(`
+		INSERT INTO mutes_by_actor_did
+			(actor_did, subject_did, created_at)
+		VALUES
+			(?, ?, ?)
+	`, req.ActorDid, req.SubjectDid, now).WithContext(ctx).Exec(); err != nil {
+		logger.Error("failed to create mute", "err", err)
+		// release the pair row, which would otherwise make a retry look like the subject is already muted
+		if err := s.cqlSession.Query(`
+			DELETE FROM mutes_by_actor_did_subject_did
+			WHERE actor_did = ? AND subject_did = ?
+			IF created_at = ?
+		`, req.ActorDid, req.SubjectDid, now).WithContext(ctx).Exec(); err != nil {
+			logger.Error("failed to release mute", "err", err)
+		}
+		return &vyletdatabase.CreateMuteResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.CreateMuteResponse{}, nil
+}
+
+func (s *Server) DeleteMute(ctx context.Context, req *vyletdatabase.DeleteMuteRequest) (*vyletdatabase.DeleteMuteResponse, error) {
+	logger := s.logger.With("name", "DeleteMute", "did", req.ActorDid, "subjectDid", req.SubjectDid)
+
+	var createdAt time.Time
+	if err := s.cqlSession.Query(`
+		SELECT created_at
+		FROM mutes_by_actor_did_subject_did
+		WHERE actor_did = ? AND subject_did = ?
+	`, req.ActorDid, req.SubjectDid).WithContext(ctx).Scan(&createdAt); err != nil {
+		if err == gocql.ErrNotFound {
+			// unmuting an actor that is not muted leaves nothing to do
+			return &vyletdatabase.DeleteMuteResponse{}, nil
+		}
+		logger.Error("failed to fetch mute", "err", err)
+		return &vyletdatabase.DeleteMuteResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	// the pair row is written with lightweight transactions by CreateMute, so it is released the same way, and the
+	// listing row is only removed by the delete that released it. Matching on created_at keeps a mute that replaced
+	// the one read above from being released.
+	applied, err := s.execCAS(ctx, `
+		DELETE FROM mutes_by_actor_did_subject_did
+		WHERE actor_did = ? AND subject_did = ?
+		IF created_at = ?
+	`, req.ActorDid, req.SubjectDid, createdAt)
+	if err != nil {
+		logger.Error("failed to delete mute", "err", err)
+		return &vyletdatabase.DeleteMuteResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if !applied {
+		// unmuted, or unmuted and muted again, concurrently
+		return &vyletdatabase.DeleteMuteResponse{}, nil
+	}
+
+	if err := s.cqlSession.Query(`
+		DELETE FROM mutes_by_actor_did
+		WHERE actor_did = ? AND created_at = ? AND subject_did = ?
+	`, req.ActorDid, createdAt, req.SubjectDid).WithContext(ctx).Exec(); err != nil {
+		logger.Error("failed to delete mute", "err", err)
+		return &vyletdatabase.DeleteMuteResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.DeleteMuteResponse{}, nil
+}
+
+func (s *Server) GetMutesByActor(ctx context.Context, req *vyletdatabase.GetMutesByActorRequest) (*vyletdatabase.GetMutesByActorResponse, error) {
+	logger := s.logger.With("name", "GetMutesByActor", "did", req.Did)
+
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var (
+		query string
+		args  []any
+	)
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		cursorParts := strings.SplitN(*req.Cursor, "|", 2)
+		if len(cursorParts) != 2 {
+			logger.Error("invalid cursor format", "cursor", *req.Cursor)
+			return &vyletdatabase.GetMutesByActorResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+
+		cursorTime, err := time.Parse(time.RFC3339Nano, cursorParts[0])
+		if err != nil {
+			logger.Error("failed to parse cursor timestamp", "cursor", *req.Cursor, "err", err)
+			return &vyletdatabase.GetMutesByActorResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+		cursorDid := cursorParts[1]
+
+		query = `
+			SELECT actor_did, subject_did, created_at
+			FROM mutes_by_actor_did
+			WHERE actor_did = ? AND (created_at, subject_did) < (?, ?)
+			ORDER BY created_at DESC, subject_did ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, cursorTime, cursorDid, req.Limit + 1}
+	} else {
+		query = `
+			SELECT actor_did, subject_did, created_at
+			FROM mutes_by_actor_did
+			WHERE actor_did = ?
+			ORDER BY created_at DESC, subject_did ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, req.Limit + 1}
+	}
+
+	iter := s.cqlSession.Query(query, args...).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var mutes []*vyletdatabase.Mute
+
+	var createdAt time.Time
+	for {
+		mute := &vyletdatabase.Mute{}
+		if !iter.Scan(
+			&mute.ActorDid,
+			&mute.SubjectDid,
+			&createdAt,
+		) {
+			break
+		}
+		mute.CreatedAt = timestamppb.New(createdAt)
+
+		mutes = append(mutes, mute)
+	}
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate mutes", "err", err)
+		return &vyletdatabase.GetMutesByActorResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	var nextCursor *string
+	if len(mutes) > int(req.Limit) {
+		mutes = mutes[:req.Limit]
+		last := mutes[len(mutes)-1]
+		cursorStr := fmt.Sprintf("%s|%s",
+			last.CreatedAt.AsTime().Format(time.RFC3339Nano),
+			last.SubjectDid)
+		nextCursor = &cursorStr
+	}
+
+	return &vyletdatabase.GetMutesByActorResponse{
+		Mutes:  mutes,
+		Cursor: nextCursor,
+	}, nil
+}
+
+func (s *Server) GetMutedDids(ctx context.Context, req *vyletdatabase.GetMutedDidsRequest) (*vyletdatabase.GetMutedDidsResponse, error) {
+	logger := s.logger.With("name", "GetMutedDids", "did", req.ActorDid)
+
+	if len(req.Dids) == 0 {
+		return &vyletdatabase.GetMutedDidsResponse{}, nil
+	}
+
+	iter := s.cqlSession.Query(`
+		SELECT subject_did
+		FROM mutes_by_actor_did_subject_did
+		WHERE actor_did = ? AND subject_did IN ?
+	`, req.ActorDid, req.Dids).WithContext(ctx).Iter()
+
+	var (
+		mutedDids []string
+		did       string
+	)
+	for iter.Scan(&did) {
+		mutedDids = append(mutedDids, did)
+	}
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate mutes", "err", err)
+		return &vyletdatabase.GetMutedDidsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.GetMutedDidsResponse{
+		MutedDids: mutedDids,
+	}, nil
+}
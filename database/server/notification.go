@@ -0,0 +1,392 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/internal/helpers"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxUnreadNotificationCount is the most unread notifications that are counted. Counting stops there rather than
+// reading every notification of an account that never looks at them, and clients show it as "99+".
+const maxUnreadNotificationCount = 100
+
+// notificationRevsTable holds the last rev the notifier applied to each record. It is kept apart from record_revs,
+// which the indexer writes as it applies the same commits.
+const notificationRevsTable = "notification_revs"
+
+// storedNotification is the part of a notification kept by uri, enough to tell whether it changed and to delete it
+type storedNotification struct {
+	reason        string
+	reasonSubject string
+	indexedAt     time.Time
+}
+
+func (s *Server) PutNotifications(ctx context.Context, req *vyletdatabase.PutNotificationsRequest) (*vyletdatabase.PutNotificationsResponse, error) {
+	logger := s.logger.With("name", "PutNotifications", "uri", req.Uri)
+
+	stale, err := s.staleRevIn(ctx, notificationRevsTable, req.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check notification rev", "err", err)
+		return &vyletdatabase.PutNotificationsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		logger.Info("skipping stale notifications", "rev", req.Rev)
+		return &vyletdatabase.PutNotificationsResponse{}, nil
+	}
+
+	existing, err := s.notificationsByUri(ctx, req.Uri)
+	if err != nil {
+		logger.Error("failed to fetch notifications", "err", err)
+		return &vyletdatabase.PutNotificationsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	now := time.Now().UTC()
+
+	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+
+	wanted := make(map[string]bool, len(req.Notifications))
+	for _, n := range req.Notifications {
+		stored, ok := existing[n.RecipientDid]
+		if ok && stored.reason == n.Reason && stored.reasonSubject == n.GetReasonSubject() {
+			wanted[n.RecipientDid] = true
+			continue
+		}
+		if wanted[n.RecipientDid] {
+			continue
+		}
+		wanted[n.RecipientDid] = true
+
+		// a changed notification sorts as new. its row by uri is overwritten below, as deleting it in the same batch
+		// would shadow the insert
+		if ok {
+			batch.Query(`
+				DELETE FROM notifications_by_recipient_did
+				WHERE recipient_did = ? AND indexed_at = ? AND uri = ?
+			`, n.RecipientDid, stored.indexedAt, req.Uri)
+		}
+
+		batch.Query(`
+			INSERT INTO notifications_by_recipient_did
+				(recipient_did, uri, cid, author_did, reason, reason_subject, created_at, indexed_at)
+			VALUES
+				(?, ?, ?, ?, ?, ?, ?, ?)
+		`, n.RecipientDid, req.Uri, n.Cid, n.AuthorDid, n.Reason, n.ReasonSubject, n.CreatedAt.AsTime(), now)
+
+		batch.Query(`
+			INSERT INTO notifications_by_uri
+				(uri, recipient_did, reason, reason_subject, indexed_at)
+			VALUES
+				(?, ?, ?, ?, ?)
+		`, req.Uri, n.RecipientDid, n.Reason, n.ReasonSubject, now)
+	}
+
+	for recipientDid, stored := range existing {
+		if wanted[recipientDid] {
+			continue
+		}
+		s.deleteNotification(batch, req.Uri, recipientDid, stored.indexedAt)
+	}
+
+	if len(batch.Entries) > 0 {
+		if err := s.cqlSession.ExecuteBatch(batch); err != nil {
+			logger.Error("failed to put notifications", "err", err)
+			return &vyletdatabase.PutNotificationsResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	if err := s.setRevIn(ctx, notificationRevsTable, req.Uri, req.Rev, false); err != nil {
+		logger.Error("failed to set notification rev", "err", err)
+		return &vyletdatabase.PutNotificationsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.PutNotificationsResponse{}, nil
+}
+
+func (s *Server) DeleteNotifications(ctx context.Context, req *vyletdatabase.DeleteNotificationsRequest) (*vyletdatabase.DeleteNotificationsResponse, error) {
+	logger := s.logger.With("name", "DeleteNotifications", "uri", req.Uri)
+
+	stale, err := s.staleRevIn(ctx, notificationRevsTable, req.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check notification rev", "err", err)
+		return &vyletdatabase.DeleteNotificationsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		logger.Info("skipping stale notifications delete", "rev", req.Rev)
+		return &vyletdatabase.DeleteNotificationsResponse{}, nil
+	}
+
+	existing, err := s.notificationsByUri(ctx, req.Uri)
+	if err != nil {
+		logger.Error("failed to fetch notifications", "err", err)
+		return &vyletdatabase.DeleteNotificationsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	if len(existing) > 0 {
+		batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+		for recipientDid, stored := range existing {
+			s.deleteNotification(batch, req.Uri, recipientDid, stored.indexedAt)
+		}
+
+		if err := s.cqlSession.ExecuteBatch(batch); err != nil {
+			logger.Error("failed to delete notifications", "err", err)
+			return &vyletdatabase.DeleteNotificationsResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+	}
+
+	// the tombstone keeps a create replayed after the delete from notifying again
+	if err := s.setRevIn(ctx, notificationRevsTable, req.Uri, req.Rev, true); err != nil {
+		logger.Error("failed to set notification rev", "err", err)
+		return &vyletdatabase.DeleteNotificationsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.DeleteNotificationsResponse{}, nil
+}
+
+// notificationsByUri returns the notifications caused by a record, keyed by recipient did
+func (s *Server) notificationsByUri(ctx context.Context, uri string) (map[string]storedNotification, error) {
+	iter := s.cqlSession.Query(`
+		SELECT recipient_did, reason, reason_subject, indexed_at
+		FROM notifications_by_uri
+		WHERE uri = ?
+	`, uri).WithContext(ctx).Iter()
+
+	notifications := make(map[string]storedNotification)
+
+	var (
+		recipientDid string
+		stored       storedNotification
+	)
+	for iter.Scan(&recipientDid, &stored.reason, &stored.reasonSubject, &stored.indexedAt) {
+		notifications[recipientDid] = stored
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (s *Server) deleteNotification(batch *gocql.Batch, uri, recipientDid string, indexedAt time.Time) {
+	batch.Query(`
+		DELETE FROM notifications_by_recipient_did
+		WHERE recipient_did = ? AND indexed_at = ? AND uri = ?
+	`, recipientDid, indexedAt, uri)
+
+	batch.Query(`
+		DELETE FROM notifications_by_uri
+		WHERE uri = ? AND recipient_did = ?
+	`, uri, recipientDid)
+}
+
+func (s *Server) GetNotifications(ctx context.Context, req *vyletdatabase.GetNotificationsRequest) (*vyletdatabase.GetNotificationsResponse, error) {
+	logger := s.logger.With("name", "GetNotifications", "did", req.Did)
+
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var (
+		query string
+		args  []any
+	)
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		cursorParts := strings.SplitN(*req.Cursor, "|", 2)
+		if len(cursorParts) != 2 {
+			logger.Error("invalid cursor format", "cursor", *req.Cursor)
+			return &vyletdatabase.GetNotificationsResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+
+		cursorTime, err := time.Parse(time.RFC3339Nano, cursorParts[0])
+		if err != nil {
+			logger.Error("failed to parse cursor timestamp", "cursor", *req.Cursor, "err", err)
+			return &vyletdatabase.GetNotificationsResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+		cursorUri := cursorParts[1]
+
+		query = `
+			SELECT recipient_did, uri, cid, author_did, reason, reason_subject, created_at, indexed_at
+			FROM notifications_by_recipient_did
+			WHERE recipient_did = ? AND (indexed_at, uri) < (?, ?)
+			ORDER BY indexed_at DESC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, cursorTime, cursorUri, req.Limit + 1}
+	} else {
+		query = `
+			SELECT recipient_did, uri, cid, author_did, reason, reason_subject, created_at, indexed_at
+			FROM notifications_by_recipient_did
+			WHERE recipient_did = ?
+			ORDER BY indexed_at DESC, uri ASC
+			LIMIT ?
+		`
+		args = []any{req.Did, req.Limit + 1}
+	}
+
+	iter := s.cqlSession.Query(query, args...).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var notifications []*vyletdatabase.Notification
+
+	var (
+		reasonSubject string
+		createdAt     time.Time
+		indexedAt     time.Time
+	)
+	for {
+		notification := &vyletdatabase.Notification{}
+		if !iter.Scan(
+			&notification.RecipientDid,
+			&notification.Uri,
+			&notification.Cid,
+			&notification.AuthorDid,
+			&notification.Reason,
+			&reasonSubject,
+			&createdAt,
+			&indexedAt,
+		) {
+			break
+		}
+		if reasonSubject != "" {
+			notification.ReasonSubject = helpers.ToStringPtr(reasonSubject)
+		}
+		notification.CreatedAt = timestamppb.New(createdAt)
+		notification.IndexedAt = timestamppb.New(indexedAt)
+
+		notifications = append(notifications, notification)
+	}
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate notifications", "err", err)
+		return &vyletdatabase.GetNotificationsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	var nextCursor *string
+	if len(notifications) > int(req.Limit) {
+		notifications = notifications[:req.Limit]
+		last := notifications[len(notifications)-1]
+		cursorStr := fmt.Sprintf("%s|%s",
+			last.IndexedAt.AsTime().Format(time.RFC3339Nano),
+			last.Uri)
+		nextCursor = &cursorStr
+	}
+
+	seenAt, err := s.notificationsSeenAt(ctx, req.Did)
+	if err != nil {
+		logger.Error("failed to fetch seen at", "err", err)
+		return &vyletdatabase.GetNotificationsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	resp := &vyletdatabase.GetNotificationsResponse{
+		Notifications: notifications,
+		Cursor:        nextCursor,
+	}
+	if !seenAt.IsZero() {
+		resp.SeenAt = timestamppb.New(seenAt)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) GetUnreadNotificationCount(ctx context.Context, req *vyletdatabase.GetUnreadNotificationCountRequest) (*vyletdatabase.GetUnreadNotificationCountResponse, error) {
+	logger := s.logger.With("name", "GetUnreadNotificationCount", "did", req.Did)
+
+	seenAt, err := s.notificationsSeenAt(ctx, req.Did)
+	if err != nil {
+		logger.Error("failed to fetch seen at", "err", err)
+		return &vyletdatabase.GetUnreadNotificationCountResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	// COUNT(*) reads every matching row regardless of LIMIT, so the rows are counted here instead
+	iter := s.cqlSession.Query(`
+		SELECT indexed_at
+		FROM notifications_by_recipient_did
+		WHERE recipient_did = ? AND indexed_at > ?
+		LIMIT ?
+	`, req.Did, seenAt, maxUnreadNotificationCount).WithContext(ctx).Iter()
+
+	var (
+		count     int64
+		indexedAt time.Time
+	)
+	for iter.Scan(&indexedAt) {
+		count++
+	}
+
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to count notifications", "err", err)
+		return &vyletdatabase.GetUnreadNotificationCountResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.GetUnreadNotificationCountResponse{
+		Count: count,
+	}, nil
+}
+
+func (s *Server) UpdateNotificationsSeen(ctx context.Context, req *vyletdatabase.UpdateNotificationsSeenRequest) (*vyletdatabase.UpdateNotificationsSeenResponse, error) {
+	logger := s.logger.With("name", "UpdateNotificationsSeen", "did", req.Did)
+
+	if err := s.cqlSession.Query(`
+		INSERT INTO notifications_seen
+			(did, seen_at)
+		VALUES
+			(?, ?)
+	`, req.Did, req.SeenAt.AsTime()).WithContext(ctx).Exec(); err != nil {
+		logger.Error("failed to update seen at", "err", err)
+		return &vyletdatabase.UpdateNotificationsSeenResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.UpdateNotificationsSeenResponse{}, nil
+}
+
+// notificationsSeenAt returns when the did last saw their notifications, or the zero time if they never have
+func (s *Server) notificationsSeenAt(ctx context.Context, did string) (time.Time, error) {
+	var seenAt time.Time
+	if err := s.cqlSession.Query(`
+		SELECT seen_at
+		FROM notifications_seen
+		WHERE did = ?
+	`, did).WithContext(ctx).Scan(&seenAt); err != nil {
+		if err == gocql.ErrNotFound {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	return seenAt, nil
+}
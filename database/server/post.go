@@ -64,6 +64,17 @@ func (s *Server) CreatePost(ctx context.Context, req *vyletdatabase.CreatePostRe
 	did := aturi.Authority().String()
 	now := time.Now().UTC()
 
+	stale, err := s.staleRev(ctx, req.Post.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "uri", req.Post.Uri, "err", err)
+		return &vyletdatabase.CreatePostResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.CreatePostResponse{}, nil
+	}
+
 	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	postArgs := []any{
@@ -110,9 +121,145 @@ func (s *Server) CreatePost(ctx context.Context, req *vyletdatabase.CreatePostRe
 		}, nil
 	}
 
+	if err := s.setRev(ctx, req.Post.Uri, req.Rev, false); err != nil {
+		logger.Error("failed to set record rev", "uri", req.Post.Uri, "err", err)
+		return &vyletdatabase.CreatePostResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
 	return &vyletdatabase.CreatePostResponse{}, nil
 }
 
+func (s *Server) UpdatePost(ctx context.Context, req *vyletdatabase.UpdatePostRequest) (*vyletdatabase.UpdatePostResponse, error) {
+	logger := s.logger.With("name", "UpdatePost", "uri", req.Post.Uri)
+
+	aturi, err := syntax.ParseATURI(req.Post.Uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse aturi: %w", err)
+	}
+	did := aturi.Authority().String()
+
+	stale, err := s.staleRev(ctx, req.Post.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "err", err)
+		return &vyletdatabase.UpdatePostResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.UpdatePostResponse{}, nil
+	}
+
+	var (
+		prevCid     string
+		prevCaption *string
+		prevFacets  []byte
+		createdAt   time.Time
+		indexedAt   time.Time
+	)
+	query := `
+		SELECT cid, caption, facets, created_at, indexed_at
+		FROM posts_by_uri
+		WHERE uri = ?
+	`
+	if err := s.cqlSession.Query(query, req.Post.Uri).WithContext(ctx).Scan(&prevCid, &prevCaption, &prevFacets, &createdAt, &indexedAt); err != nil {
+		if err == gocql.ErrNotFound {
+			return &vyletdatabase.UpdatePostResponse{
+				Error: helpers.ToStringPtr("not found"),
+			}, nil
+		}
+		logger.Error("failed to fetch post", "err", err)
+		return &vyletdatabase.UpdatePostResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	// the same update may be delivered more than once
+	if prevCid == req.Post.Cid {
+		if err := s.setRev(ctx, req.Post.Uri, req.Rev, false); err != nil {
+			logger.Error("failed to set record rev", "err", err)
+			return &vyletdatabase.UpdatePostResponse{
+				Error: helpers.ToStringPtr(err.Error()),
+			}, nil
+		}
+		return &vyletdatabase.UpdatePostResponse{}, nil
+	}
+
+	now := time.Now().UTC()
+
+	batch := s.cqlSession.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+
+	batch.Query(`
+		INSERT INTO post_edits
+			(post_uri, edited_at, cid, caption, facets)
+		VALUES
+			(?, ?, ?, ?, ?)
+	`, req.Post.Uri, now, prevCid, prevCaption, prevFacets)
+
+	// created_at is part of the posts_by_actor key, so the original is kept to overwrite the existing row
+	postArgs := []any{
+		req.Post.Uri,
+		req.Post.Cid,
+		did,
+		req.Post.Caption,
+		req.Post.Facets,
+		createdAt,
+		indexedAt,
+		now,
+	}
+
+	postQuery := `
+		INSERT INTO %s
+			(uri, cid, author_did, caption, facets, created_at, indexed_at, edited_at)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	batch.Query(fmt.Sprintf(postQuery, "posts_by_uri"), postArgs...)
+	batch.Query(fmt.Sprintf(postQuery, "posts_by_actor"), postArgs...)
+
+	for idx, img := range req.Post.Images {
+		batch.Query(
+			`INSERT INTO images_by_post
+				(post_uri, image_index, cid, alt, width, height, size, mime)
+			VALUES
+				(?, ?, ?, ?, ?, ?, ?, ?)`,
+			req.Post.Uri,
+			idx,
+			img.Cid,
+			img.Alt,
+			img.Width,
+			img.Height,
+			img.Size,
+			img.Mime,
+		)
+	}
+
+	// statements in a batch share a timestamp, and a delete wins over an insert with the same timestamp, so only the
+	// images past the new ones are deleted
+	batch.Query(`
+		DELETE FROM images_by_post
+		WHERE post_uri = ? AND image_index >= ?
+	`, req.Post.Uri, len(req.Post.Images))
+
+	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
+		logger.Error("failed to update post", "err", err)
+		return &vyletdatabase.UpdatePostResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	if err := s.setRev(ctx, req.Post.Uri, req.Rev, false); err != nil {
+		logger.Error("failed to set record rev", "err", err)
+		return &vyletdatabase.UpdatePostResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.UpdatePostResponse{}, nil
+}
+
 func (s *Server) DeletePost(ctx context.Context, req *vyletdatabase.DeletePostRequest) (*vyletdatabase.DeletePostResponse, error) {
 	logger := s.logger.With("name", "DeletePost", "uri", req.Uri)
 
@@ -122,6 +269,17 @@ func (s *Server) DeletePost(ctx context.Context, req *vyletdatabase.DeletePostRe
 	}
 	did := aturi.Authority().String()
 
+	stale, err := s.staleRev(ctx, req.Uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "err", err)
+		return &vyletdatabase.DeletePostResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.DeletePostResponse{}, nil
+	}
+
 	var createdAt time.Time
 	query := `
 		SELECT created_at
@@ -130,6 +288,16 @@ func (s *Server) DeletePost(ctx context.Context, req *vyletdatabase.DeletePostRe
 	`
 	if err := s.cqlSession.Query(query, req.Uri).WithContext(ctx).Scan(&createdAt); err != nil {
 		if err == gocql.ErrNotFound {
+			// a delete processed before its create leaves a tombstone, so the create is skipped once it arrives
+			if req.Rev != "" {
+				if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+					logger.Error("failed to set record rev", "err", err)
+					return &vyletdatabase.DeletePostResponse{
+						Error: helpers.ToStringPtr(err.Error()),
+					}, nil
+				}
+				return &vyletdatabase.DeletePostResponse{}, nil
+			}
 			logger.Warn("post not found", "uri", req.Uri)
 			return &vyletdatabase.DeletePostResponse{
 				Error: helpers.ToStringPtr("post not found"),
@@ -158,6 +326,11 @@ func (s *Server) DeletePost(ctx context.Context, req *vyletdatabase.DeletePostRe
 		WHERE post_uri = ?
 	`, req.Uri)
 
+	batch.Query(`
+		DELETE FROM post_edits
+		WHERE post_uri = ?
+	`, req.Uri)
+
 	if err := s.cqlSession.ExecuteBatch(batch); err != nil {
 		logger.Error("failed to delete post", "uri", req.Uri, "err", err)
 		return &vyletdatabase.DeletePostResponse{
@@ -165,6 +338,13 @@ func (s *Server) DeletePost(ctx context.Context, req *vyletdatabase.DeletePostRe
 		}, nil
 	}
 
+	if err := s.setRev(ctx, req.Uri, req.Rev, true); err != nil {
+		logger.Error("failed to set record rev", "err", err)
+		return &vyletdatabase.DeletePostResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
 	return &vyletdatabase.DeletePostResponse{}, nil
 }
 
@@ -176,7 +356,7 @@ func (s *Server) GetPosts(ctx context.Context, req *vyletdatabase.GetPostsReques
 	}
 
 	query := `
-		SELECT uri, cid, author_did, caption, facets, created_at, indexed_at
+		SELECT uri, cid, author_did, caption, facets, created_at, indexed_at, edited_at
 		FROM posts_by_uri
 		WHERE uri IN ?
 	`
@@ -187,7 +367,7 @@ func (s *Server) GetPosts(ctx context.Context, req *vyletdatabase.GetPostsReques
 	posts := make(map[string]*vyletdatabase.Post)
 	for {
 		post := &vyletdatabase.Post{}
-		var createdAt, indexedAt time.Time
+		var createdAt, indexedAt, editedAt time.Time
 
 		if !iter.Scan(
 			&post.Uri,
@@ -197,12 +377,16 @@ func (s *Server) GetPosts(ctx context.Context, req *vyletdatabase.GetPostsReques
 			&post.Facets,
 			&createdAt,
 			&indexedAt,
+			&editedAt,
 		) {
 			break
 		}
 
 		post.CreatedAt = timestamppb.New(createdAt)
 		post.IndexedAt = timestamppb.New(indexedAt)
+		if !editedAt.IsZero() {
+			post.EditedAt = timestamppb.New(editedAt)
+		}
 
 		images, err := s.getPostImages(ctx, post.Uri)
 		if err != nil {
@@ -257,7 +441,7 @@ func (s *Server) GetPostsByActor(ctx context.Context, req *vyletdatabase.GetPost
 		cursorUri := cursorParts[1]
 
 		query = `
-			SELECT uri, cid, author_did, caption, facets, created_at, indexed_at
+			SELECT uri, cid, author_did, caption, facets, created_at, indexed_at, edited_at
 			FROM posts_by_actor
 			WHERE author_did = ? AND (created_at, uri) < (?, ?)
 			ORDER BY created_at DESC, uri ASC
@@ -266,7 +450,7 @@ func (s *Server) GetPostsByActor(ctx context.Context, req *vyletdatabase.GetPost
 		args = []any{req.Did, cursorTime, cursorUri, req.Limit + 1}
 	} else {
 		query = `
-			SELECT uri, cid, author_did, caption, facets, created_at, indexed_at
+			SELECT uri, cid, author_did, caption, facets, created_at, indexed_at, edited_at
 			FROM posts_by_actor
 			WHERE author_did = ?
 			ORDER BY created_at DESC, uri ASC
@@ -281,7 +465,7 @@ func (s *Server) GetPostsByActor(ctx context.Context, req *vyletdatabase.GetPost
 	var postsList []*vyletdatabase.Post
 	for {
 		post := &vyletdatabase.Post{}
-		var createdAt, indexedAt time.Time
+		var createdAt, indexedAt, editedAt time.Time
 
 		if !iter.Scan(
 			&post.Uri,
@@ -291,12 +475,16 @@ func (s *Server) GetPostsByActor(ctx context.Context, req *vyletdatabase.GetPost
 			&post.Facets,
 			&createdAt,
 			&indexedAt,
+			&editedAt,
 		) {
 			break
 		}
 
 		post.CreatedAt = timestamppb.New(createdAt)
 		post.IndexedAt = timestamppb.New(indexedAt)
+		if !editedAt.IsZero() {
+			post.EditedAt = timestamppb.New(editedAt)
+		}
 		postsList = append(postsList, post)
 	}
 
@@ -340,12 +528,12 @@ func (s *Server) GetPostInteractionCounts(ctx context.Context, req *vyletdatabas
 	var likeCount, replyCount int64
 
 	query := `
-		SELECT like_count
+		SELECT like_count, reply_count
 		FROM post_interaction_counts
 		WHERE post_uri = ?
 	`
 
-	err := s.cqlSession.Query(query, req.Uri).WithContext(ctx).Scan(&likeCount)
+	err := s.cqlSession.Query(query, req.Uri).WithContext(ctx).Scan(&likeCount, &replyCount)
 	if err != nil {
 		if errors.Is(err, gocql.ErrNotFound) {
 			return &vyletdatabase.GetPostInteractionCountsResponse{
@@ -373,7 +561,7 @@ func (s *Server) GetPostsInteractionCounts(ctx context.Context, req *vyletdataba
 	logger := s.logger.With("name", "GetPostsInteractionCounts")
 
 	query := `
-		SELECT post_uri, like_count
+		SELECT post_uri, like_count, reply_count
 		FROM post_interaction_counts
 		WHERE post_uri IN ?
 	`
@@ -385,7 +573,7 @@ func (s *Server) GetPostsInteractionCounts(ctx context.Context, req *vyletdataba
 
 	var uri string
 	var likeCount, replyCount int64
-	for iter.Scan(&uri, &likeCount) {
+	for iter.Scan(&uri, &likeCount, &replyCount) {
 		counts[uri] = &vyletdatabase.PostInteractionCounts{
 			Likes:   likeCount,
 			Replies: replyCount,
@@ -412,3 +600,84 @@ func (s *Server) GetPostsInteractionCounts(ctx context.Context, req *vyletdataba
 		Counts: counts,
 	}, nil
 }
+
+func (s *Server) GetPostEdits(ctx context.Context, req *vyletdatabase.GetPostEditsRequest) (*vyletdatabase.GetPostEditsResponse, error) {
+	logger := s.logger.With("name", "GetPostEdits", "uri", req.Uri)
+
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var (
+		query string
+		args  []any
+	)
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		cursorTime, err := time.Parse(time.RFC3339Nano, *req.Cursor)
+		if err != nil {
+			logger.Error("failed to parse cursor timestamp", "cursor", *req.Cursor, "err", err)
+			return &vyletdatabase.GetPostEditsResponse{
+				Error: helpers.ToStringPtr("invalid cursor format"),
+			}, nil
+		}
+
+		query = `
+			SELECT cid, caption, facets, edited_at
+			FROM post_edits
+			WHERE post_uri = ? AND edited_at < ?
+			ORDER BY edited_at DESC
+			LIMIT ?
+		`
+		args = []any{req.Uri, cursorTime, req.Limit + 1}
+	} else {
+		query = `
+			SELECT cid, caption, facets, edited_at
+			FROM post_edits
+			WHERE post_uri = ?
+			ORDER BY edited_at DESC
+			LIMIT ?
+		`
+		args = []any{req.Uri, req.Limit + 1}
+	}
+
+	iter := s.cqlSession.Query(query, args...).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var edits []*vyletdatabase.PostEdit
+	for {
+		edit := &vyletdatabase.PostEdit{}
+		var editedAt time.Time
+
+		if !iter.Scan(
+			&edit.Cid,
+			&edit.Caption,
+			&edit.Facets,
+			&editedAt,
+		) {
+			break
+		}
+
+		edit.EditedAt = timestamppb.New(editedAt)
+		edits = append(edits, edit)
+	}
+
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate post edits", "err", err)
+		return &vyletdatabase.GetPostEditsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	var nextCursor *string
+	if len(edits) > int(req.Limit) {
+		edits = edits[:req.Limit]
+		cursorStr := edits[len(edits)-1].EditedAt.AsTime().Format(time.RFC3339Nano)
+		nextCursor = &cursorStr
+	}
+
+	return &vyletdatabase.GetPostEditsResponse{
+		Edits:  edits,
+		Cursor: nextCursor,
+	}, nil
+}
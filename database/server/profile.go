@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	vyletdatabase "github.com/vylet-app/go/database/proto"
@@ -9,10 +10,27 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// profileUri is the uri of the did's profile record, which the record revs are kept by
+func profileUri(did string) string {
+	return fmt.Sprintf("at://%s/app.vylet.actor.profile/self", did)
+}
+
 func (s *Server) CreateProfile(ctx context.Context, req *vyletdatabase.CreateProfileRequest) (*vyletdatabase.CreateProfileResponse, error) {
 	logger := s.logger.With("name", "CreateProfile")
 	now := time.Now().UTC()
 
+	uri := profileUri(req.Profile.Did)
+	stale, err := s.staleRev(ctx, uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "did", req.Profile.Did, "err", err)
+		return &vyletdatabase.CreateProfileResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.CreateProfileResponse{}, nil
+	}
+
 	if err := s.cqlSession.Query(
 		`
 		INSERT INTO profiles
@@ -35,6 +53,13 @@ func (s *Server) CreateProfile(ctx context.Context, req *vyletdatabase.CreatePro
 		}, nil
 	}
 
+	if err := s.setRev(ctx, uri, req.Rev, false); err != nil {
+		logger.Error("failed to set record rev", "did", req.Profile.Did, "err", err)
+		return &vyletdatabase.CreateProfileResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
 	return &vyletdatabase.CreateProfileResponse{}, nil
 }
 
@@ -43,6 +68,18 @@ func (s *Server) UpdateProfile(ctx context.Context, req *vyletdatabase.CreatePro
 
 	now := time.Now().UTC()
 
+	uri := profileUri(req.Profile.Did)
+	stale, err := s.staleRev(ctx, uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "did", req.Profile.Did, "err", err)
+		return &vyletdatabase.CreateProfileResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.CreateProfileResponse{}, nil
+	}
+
 	if err := s.cqlSession.Query(
 		`
 		UPDATE profiles
@@ -68,12 +105,31 @@ func (s *Server) UpdateProfile(ctx context.Context, req *vyletdatabase.CreatePro
 		}, nil
 	}
 
+	if err := s.setRev(ctx, uri, req.Rev, false); err != nil {
+		logger.Error("failed to set record rev", "did", req.Profile.Did, "err", err)
+		return &vyletdatabase.CreateProfileResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
 	return &vyletdatabase.CreateProfileResponse{}, nil
 }
 
 func (s *Server) DeleteProfile(ctx context.Context, req *vyletdatabase.DeleteProfileRequest) (*vyletdatabase.DeleteProfileResponse, error) {
 	logger := s.logger.With("name", "DeleteProfile")
 
+	uri := profileUri(req.Did)
+	stale, err := s.staleRev(ctx, uri, req.Rev)
+	if err != nil {
+		logger.Error("failed to check record rev", "did", req.Did, "err", err)
+		return &vyletdatabase.DeleteProfileResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+	if stale {
+		return &vyletdatabase.DeleteProfileResponse{}, nil
+	}
+
 	if err := s.cqlSession.Query(
 		`
 		DELETE FROM profiles
@@ -88,6 +144,13 @@ func (s *Server) DeleteProfile(ctx context.Context, req *vyletdatabase.DeletePro
 		}, nil
 	}
 
+	if err := s.setRev(ctx, uri, req.Rev, true); err != nil {
+		logger.Error("failed to set record rev", "did", req.Did, "err", err)
+		return &vyletdatabase.DeleteProfileResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
 	return &vyletdatabase.DeleteProfileResponse{}, nil
 }
 
@@ -0,0 +1,226 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTestServer returns a server backed by a fresh keyspace with every migration applied. The tests run against the
+// Cassandra cluster named by VYLET_TEST_CASSANDRA_ADDRS, and are skipped when it is unset.
+func newTestServer(t *testing.T) *Server {
+	addrs := os.Getenv("VYLET_TEST_CASSANDRA_ADDRS")
+	if addrs == "" {
+		t.Skip("VYLET_TEST_CASSANDRA_ADDRS is not set")
+	}
+
+	keyspace := fmt.Sprintf("vylet_test_%d", time.Now().UnixNano())
+
+	cluster := gocql.NewCluster(strings.Split(addrs, ",")...)
+	cluster.ProtoVersion = 4
+	cluster.ConnectTimeout = 10 * time.Second
+	cluster.Timeout = 10 * time.Second
+
+	admin, err := cluster.CreateSession()
+	if err != nil {
+		t.Fatalf("failed to connect to cassandra: %v", err)
+	}
+	t.Cleanup(admin.Close)
+
+	if err := admin.Query(fmt.Sprintf(`
+		CREATE KEYSPACE %s
+		WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}
+	`, keyspace)).Exec(); err != nil {
+		t.Fatalf("failed to create keyspace: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Query(fmt.Sprintf("DROP KEYSPACE IF EXISTS %s", keyspace)).Exec()
+	})
+
+	cluster.Keyspace = keyspace
+	cluster.Consistency = gocql.Quorum
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		t.Fatalf("failed to connect to keyspace: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	if err := RunMigrations(session, "../../migrations"); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	return &Server{
+		logger:       slog.Default(),
+		cqlSession:   session,
+		tombstoneTTL: time.Hour,
+	}
+}
+
+func testLike(uri, subjectUri string) *vyletdatabase.Like {
+	return &vyletdatabase.Like{
+		Uri:        uri,
+		Cid:        "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm",
+		SubjectUri: subjectUri,
+		SubjectCid: "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm",
+		CreatedAt:  timestamppb.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+}
+
+func testLikeCount(t *testing.T, s *Server, subjectUri string) int64 {
+	t.Helper()
+
+	var count int64
+	if err := s.cqlSession.Query(`
+		SELECT like_count
+		FROM post_interaction_counts
+		WHERE post_uri = ?
+	`, subjectUri).Scan(&count); err != nil && err != gocql.ErrNotFound {
+		t.Fatalf("failed to get like count: %v", err)
+	}
+	return count
+}
+
+func testLikeStored(t *testing.T, s *Server, uri string) bool {
+	t.Helper()
+
+	var cid string
+	if err := s.cqlSession.Query(`
+		SELECT cid
+		FROM likes_by_uri
+		WHERE uri = ?
+	`, uri).Scan(&cid); err != nil {
+		if err == gocql.ErrNotFound {
+			return false
+		}
+		t.Fatalf("failed to get like: %v", err)
+	}
+	return true
+}
+
+func testCreateLike(t *testing.T, s *Server, like *vyletdatabase.Like, rev string) {
+	t.Helper()
+
+	resp, err := s.CreateLike(t.Context(), &vyletdatabase.CreateLikeRequest{Like: like, Rev: rev})
+	if err != nil {
+		t.Fatalf("failed to create like: %v", err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("error creating like: %s", *resp.Error)
+	}
+}
+
+func testDeleteLike(t *testing.T, s *Server, uri, rev string) {
+	t.Helper()
+
+	resp, err := s.DeleteLike(t.Context(), &vyletdatabase.DeleteLikeRequest{Uri: uri, Rev: rev})
+	if err != nil {
+		t.Fatalf("failed to delete like: %v", err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("error deleting like: %s", *resp.Error)
+	}
+}
+
+// TestLikeReplay applies likes the way a replayed topic delivers them: more than once, and deletes ahead of their
+// creates. The like and its count must end up as if every commit was applied once, in order.
+func TestLikeReplay(t *testing.T) {
+	s := newTestServer(t)
+
+	const (
+		subjectUri = "at://did:plc:bob/app.vylet.feed.post/3kpost"
+		likeUri    = "at://did:plc:alice/app.vylet.feed.like/3klike"
+		createRev  = "3kcreate22222"
+		deleteRev  = "3kdelete22222"
+	)
+
+	t.Run("create twice", func(t *testing.T) {
+		testCreateLike(t, s, testLike(likeUri, subjectUri), createRev)
+		testCreateLike(t, s, testLike(likeUri, subjectUri), createRev)
+
+		if !testLikeStored(t, s, likeUri) {
+			t.Fatalf("like was not stored")
+		}
+		if count := testLikeCount(t, s, subjectUri); count != 1 {
+			t.Fatalf("like count is %d, want 1", count)
+		}
+	})
+
+	t.Run("delete twice", func(t *testing.T) {
+		testDeleteLike(t, s, likeUri, deleteRev)
+		testDeleteLike(t, s, likeUri, deleteRev)
+
+		if testLikeStored(t, s, likeUri) {
+			t.Fatalf("like was not deleted")
+		}
+		if count := testLikeCount(t, s, subjectUri); count != 0 {
+			t.Fatalf("like count is %d, want 0", count)
+		}
+	})
+
+	t.Run("create after delete", func(t *testing.T) {
+		testCreateLike(t, s, testLike(likeUri, subjectUri), createRev)
+
+		if testLikeStored(t, s, likeUri) {
+			t.Fatalf("replayed create brought the deleted like back")
+		}
+		if count := testLikeCount(t, s, subjectUri); count != 0 {
+			t.Fatalf("like count is %d, want 0", count)
+		}
+	})
+
+	t.Run("delete before create", func(t *testing.T) {
+		const otherUri = "at://did:plc:carol/app.vylet.feed.like/3klike"
+
+		testDeleteLike(t, s, otherUri, deleteRev)
+		testCreateLike(t, s, testLike(otherUri, subjectUri), createRev)
+
+		if testLikeStored(t, s, otherUri) {
+			t.Fatalf("create processed after its delete was stored")
+		}
+		if count := testLikeCount(t, s, subjectUri); count != 0 {
+			t.Fatalf("like count is %d, want 0", count)
+		}
+	})
+}
+
+// TestLikeUpdateReplay moves a like to another subject twice, which must only move the counts once
+func TestLikeUpdateReplay(t *testing.T) {
+	s := newTestServer(t)
+
+	const (
+		firstUri  = "at://did:plc:bob/app.vylet.feed.post/3kfirst"
+		secondUri = "at://did:plc:bob/app.vylet.feed.post/3ksecond"
+		likeUri   = "at://did:plc:alice/app.vylet.feed.like/3klike"
+	)
+
+	testCreateLike(t, s, testLike(likeUri, firstUri), "3kcreate22222")
+
+	updated := testLike(likeUri, secondUri)
+	updated.Cid = "bafyreibupdatedcid"
+
+	// without a rev, so that the second delivery is not already rejected as stale
+	for range 2 {
+		resp, err := s.UpdateLike(t.Context(), &vyletdatabase.UpdateLikeRequest{Like: updated})
+		if err != nil {
+			t.Fatalf("failed to update like: %v", err)
+		}
+		if resp.Error != nil {
+			t.Fatalf("error updating like: %s", *resp.Error)
+		}
+	}
+
+	if count := testLikeCount(t, s, firstUri); count != 0 {
+		t.Fatalf("like count of the previous subject is %d, want 0", count)
+	}
+	if count := testLikeCount(t, s, secondUri); count != 1 {
+		t.Fatalf("like count of the new subject is %d, want 1", count)
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/internal/helpers"
+)
+
+func (s *Server) MarkResync(ctx context.Context, req *vyletdatabase.MarkResyncRequest) (*vyletdatabase.MarkResyncResponse, error) {
+	logger := s.logger.With("name", "MarkResync", "did", req.Did)
+
+	if err := s.cqlSession.Query(`
+		INSERT INTO pending_resyncs
+			(did, marked_at)
+		VALUES
+			(?, ?)
+	`, req.Did, req.MarkedAt.AsTime()).WithContext(ctx).Exec(); err != nil {
+		logger.Error("failed to mark resync", "err", err)
+		return &vyletdatabase.MarkResyncResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.MarkResyncResponse{}, nil
+}
+
+func (s *Server) CompleteResync(ctx context.Context, req *vyletdatabase.CompleteResyncRequest) (*vyletdatabase.CompleteResyncResponse, error) {
+	logger := s.logger.With("name", "CompleteResync", "did", req.Did)
+
+	if err := s.cqlSession.Query(`
+		DELETE FROM pending_resyncs
+		WHERE did = ? AND marked_at <= ?
+	`, req.Did, req.StartedAt.AsTime()).WithContext(ctx).Exec(); err != nil {
+		logger.Error("failed to complete resync", "err", err)
+		return &vyletdatabase.CompleteResyncResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	return &vyletdatabase.CompleteResyncResponse{}, nil
+}
+
+// GetPendingResyncs pages through the whole table in token order, using the last did of a page as the cursor
+func (s *Server) GetPendingResyncs(ctx context.Context, req *vyletdatabase.GetPendingResyncsRequest) (*vyletdatabase.GetPendingResyncsResponse, error) {
+	logger := s.logger.With("name", "GetPendingResyncs")
+
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var (
+		query string
+		args  []any
+	)
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		query = `
+			SELECT DISTINCT did
+			FROM pending_resyncs
+			WHERE token(did) > token(?)
+			LIMIT ?
+		`
+		args = []any{*req.Cursor, req.Limit + 1}
+	} else {
+		query = `
+			SELECT DISTINCT did
+			FROM pending_resyncs
+			LIMIT ?
+		`
+		args = []any{req.Limit + 1}
+	}
+
+	iter := s.cqlSession.Query(query, args...).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var (
+		dids []string
+		did  string
+	)
+	for iter.Scan(&did) {
+		dids = append(dids, did)
+	}
+	if err := iter.Close(); err != nil {
+		logger.Error("failed to iterate pending resyncs", "err", err)
+		return &vyletdatabase.GetPendingResyncsResponse{
+			Error: helpers.ToStringPtr(err.Error()),
+		}, nil
+	}
+
+	var nextCursor *string
+	if len(dids) > int(req.Limit) {
+		dids = dids[:req.Limit]
+		nextCursor = helpers.ToStringPtr(dids[len(dids)-1])
+	}
+
+	return &vyletdatabase.GetPendingResyncsResponse{
+		Dids:   dids,
+		Cursor: nextCursor,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// staleRev reports whether a write at rev is not newer than the last one applied to the record, including a delete,
+// in which case it must be skipped. Writes without a rev are never stale.
+func (s *Server) staleRev(ctx context.Context, uri, rev string) (bool, error) {
+	return s.staleRevIn(ctx, "record_revs", uri, rev)
+}
+
+// setRev records rev as the last one applied to the record. A delete's rev is kept as a tombstone, so that an older
+// create processed after it does not bring the record back.
+func (s *Server) setRev(ctx context.Context, uri, rev string, deleted bool) error {
+	return s.setRevIn(ctx, "record_revs", uri, rev, deleted)
+}
+
+// staleRevIn is staleRev against a table of revs of its own, for state derived from records by another consumer than
+// the indexer, which would otherwise find every rev already applied
+func (s *Server) staleRevIn(ctx context.Context, table, uri, rev string) (bool, error) {
+	if rev == "" {
+		return false, nil
+	}
+
+	var lastRev string
+	if err := s.cqlSession.Query(fmt.Sprintf(`
+		SELECT rev
+		FROM %s
+		WHERE uri = ?
+	`, table), uri).WithContext(ctx).Scan(&lastRev); err != nil {
+		if err == gocql.ErrNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to fetch record rev: %w", err)
+	}
+
+	// revs are TIDs, which sort in time order
+	return rev <= lastRev, nil
+}
+
+// setRevIn is setRev against a table of revs of its own. Tombstones expire after the tombstone TTL, by when no create
+// they could reject is left to replay, while the revs of live records are kept for as long as the record is.
+func (s *Server) setRevIn(ctx context.Context, table, uri, rev string, deleted bool) error {
+	if rev == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s
+			(uri, rev, deleted, updated_at)
+		VALUES
+			(?, ?, ?, ?)
+	`, table)
+	args := []any{uri, rev, deleted, time.Now().UTC()}
+
+	if deleted {
+		query += " USING TTL ?"
+		args = append(args, int(s.tombstoneTTL.Seconds()))
+	}
+
+	if err := s.cqlSession.Query(query, args...).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to set record rev: %w", err)
+	}
+
+	return nil
+}
+
+// execCAS runs a lightweight transaction, reporting whether it was applied. Counters are only changed when the row
+// they count was actually inserted or removed, which keeps them right when a write is retried.
+func (s *Server) execCAS(ctx context.Context, query string, args ...any) (bool, error) {
+	applied, err := s.cqlSession.Query(query, args...).WithContext(ctx).MapScanCAS(map[string]any{})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
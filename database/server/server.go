@@ -35,6 +35,13 @@ type Server struct {
 	vyletdatabase.UnimplementedLikeServiceServer
 	vyletdatabase.UnimplementedBlobRefServiceServer
 	vyletdatabase.UnimplementedFollowServiceServer
+	vyletdatabase.UnimplementedCommentServiceServer
+	vyletdatabase.UnimplementedAccountServiceServer
+	vyletdatabase.UnimplementedIdentityServiceServer
+	vyletdatabase.UnimplementedBlockServiceServer
+	vyletdatabase.UnimplementedMuteServiceServer
+	vyletdatabase.UnimplementedNotificationServiceServer
+	vyletdatabase.UnimplementedResyncServiceServer
 
 	logger *slog.Logger
 
@@ -45,6 +52,8 @@ type Server struct {
 
 	cassandraAddrs    []string
 	cassandraKeyspace string
+
+	tombstoneTTL time.Duration
 }
 
 type Args struct {
@@ -54,6 +63,10 @@ type Args struct {
 
 	CassandraAddrs    []string
 	CassandraKeyspace string
+
+	// TombstoneTTL is how long the rev of a deleted record is kept, defaulting to 30 days. It must outlast the
+	// retention of the firehose topic, so that a create replayed from the topic never finds its delete forgotten.
+	TombstoneTTL time.Duration
 }
 
 func New(args *Args) (*Server, error) {
@@ -63,6 +76,10 @@ func New(args *Args) (*Server, error) {
 
 	logger := args.Logger
 
+	if args.TombstoneTTL <= 0 {
+		args.TombstoneTTL = 30 * 24 * time.Hour
+	}
+
 	certificate, err := GenerateTLSCertificate("localhost")
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate TLS certificate: %w", err)
@@ -98,6 +115,8 @@ func New(args *Args) (*Server, error) {
 		cassandraAddrs:    args.CassandraAddrs,
 		cassandraKeyspace: args.CassandraKeyspace,
 
+		tombstoneTTL: args.TombstoneTTL,
+
 		listenerAddr: args.ListenAddr,
 
 		cqlSession: session,
@@ -163,6 +182,13 @@ func (s *Server) registerServices() {
 	vyletdatabase.RegisterLikeServiceServer(s.grpcServer, s)
 	vyletdatabase.RegisterBlobRefServiceServer(s.grpcServer, s)
 	vyletdatabase.RegisterFollowServiceServer(s.grpcServer, s)
+	vyletdatabase.RegisterCommentServiceServer(s.grpcServer, s)
+	vyletdatabase.RegisterAccountServiceServer(s.grpcServer, s)
+	vyletdatabase.RegisterIdentityServiceServer(s.grpcServer, s)
+	vyletdatabase.RegisterBlockServiceServer(s.grpcServer, s)
+	vyletdatabase.RegisterMuteServiceServer(s.grpcServer, s)
+	vyletdatabase.RegisterNotificationServiceServer(s.grpcServer, s)
+	vyletdatabase.RegisterResyncServiceServer(s.grpcServer, s)
 	reflection.Register(s.grpcServer)
 }
 
@@ -39,6 +39,9 @@ cleanup() {
     if [ ! -z "$CDN_PID" ]; then
         kill $CDN_PID 2>/dev/null || true
     fi
+    if [ ! -z "$NOTIFIER_PID" ]; then
+        kill $NOTIFIER_PID 2>/dev/null || true
+    fi
 
     print_success "Services stopped"
     exit 0
@@ -127,6 +130,19 @@ fi
 
 print_success "CDN running (PID: $CDN_PID)"
 
+print_status "Starting notifier (deriving notifications)..."
+go run ./cmd/notifier &
+NOTIFIER_PID=$!
+sleep 3
+
+if ! ps -p $NOTIFIER_PID > /dev/null; then
+    print_error "Notifier failed to start"
+    cleanup
+    exit 1
+fi
+
+print_success "Notifier running (PID: $NOTIFIER_PID)"
+
 echo ""
 print_success "Full stack is running!"
 echo ""
@@ -140,12 +156,14 @@ echo "  - Database Server: localhost:9090"
 echo "  - Firehose:        PID $FIREHOSE_PID"
 echo "  - Indexer:         PID $INDEXER_PID"
 echo "  - CDN:             PID $CDN_PID"
+echo "  - Notifier:        PID $NOTIFIER_PID"
 echo ""
 echo "Process IDs:"
 echo "  - Database: $DATABASE_PID"
 echo "  - Firehose: $FIREHOSE_PID"
 echo "  - Indexer:  $INDEXER_PID"
 echo "  - CDN:      $CDN_PID"
+echo "  - Notifier: $NOTIFIER_PID"
 echo ""
 print_warning "Press Ctrl+C to stop all services"
 echo ""
@@ -23,6 +23,7 @@ func main() {
 		vylet.FeedComment{},
 		vylet.FeedLike{},
 		vylet.FeedPost{},
+		vylet.GraphBlock{},
 		vylet.GraphFollow{},
 		vylet.RichtextFacet{},
 		vylet.RichtextFacet_ByteSlice{},
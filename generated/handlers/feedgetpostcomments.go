@@ -0,0 +1,33 @@
+// GENERATED CODE - DO NOT MODIFY
+// Generated by vylet-app/handlergen
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FeedGetPostCommentsInput struct {
+	Cursor *string `query:"cursor"`
+	Depth *int64 `query:"depth"`
+	Limit *int64 `query:"limit"`
+	Uri string `query:"uri"`
+}
+
+func (h *Handlers) HandleFeedGetPostComments(e echo.Context) error {
+	var input FeedGetPostCommentsInput
+	if err := e.Bind(&input); err != nil {
+		logger := h.server.Logger().With("handler", "HandleFeedGetPostComments")
+		logger.Error("error binding request", "err", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	output, err := h.server.HandleFeedGetPostComments(e, &input)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, &output)
+}
@@ -0,0 +1,32 @@
+// GENERATED CODE - DO NOT MODIFY
+// Generated by vylet-app/handlergen
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FeedGetPostEditsInput struct {
+	Cursor *string `query:"cursor"`
+	Limit *int64 `query:"limit"`
+	Uri string `query:"uri"`
+}
+
+func (h *Handlers) HandleFeedGetPostEdits(e echo.Context) error {
+	var input FeedGetPostEditsInput
+	if err := e.Bind(&input); err != nil {
+		logger := h.server.Logger().With("handler", "HandleFeedGetPostEdits")
+		logger.Error("error binding request", "err", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	output, err := h.server.HandleFeedGetPostEdits(e, &input)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, &output)
+}
@@ -0,0 +1,31 @@
+// GENERATED CODE - DO NOT MODIFY
+// Generated by vylet-app/handlergen
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GraphGetMutesInput struct {
+	Cursor *string `query:"cursor"`
+	Limit *int64 `query:"limit"`
+}
+
+func (h *Handlers) HandleGraphGetMutes(e echo.Context) error {
+	var input GraphGetMutesInput
+	if err := e.Bind(&input); err != nil {
+		logger := h.server.Logger().With("handler", "HandleGraphGetMutes")
+		logger.Error("error binding request", "err", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	output, err := h.server.HandleGraphGetMutes(e, &input)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, &output)
+}
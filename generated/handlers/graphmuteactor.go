@@ -0,0 +1,29 @@
+// GENERATED CODE - DO NOT MODIFY
+// Generated by vylet-app/handlergen
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GraphMuteActorInput struct {
+	Actor string `json:"actor"`
+}
+
+func (h *Handlers) HandleGraphMuteActor(e echo.Context) error {
+	var input GraphMuteActorInput
+	if err := e.Bind(&input); err != nil {
+		logger := h.server.Logger().With("handler", "HandleGraphMuteActor")
+		logger.Error("error binding request", "err", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	if err := h.server.HandleGraphMuteActor(e, &input); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusOK)
+}
@@ -0,0 +1,29 @@
+// GENERATED CODE - DO NOT MODIFY
+// Generated by vylet-app/handlergen
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GraphUnmuteActorInput struct {
+	Actor string `json:"actor"`
+}
+
+func (h *Handlers) HandleGraphUnmuteActor(e echo.Context) error {
+	var input GraphUnmuteActorInput
+	if err := e.Bind(&input); err != nil {
+		logger := h.server.Logger().With("handler", "HandleGraphUnmuteActor")
+		logger.Error("error binding request", "err", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	if err := h.server.HandleGraphUnmuteActor(e, &input); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusOK)
+}
@@ -20,6 +20,10 @@ type Server interface {
 	ActorGetProfilesRequiresAuth() bool
 	HandleFeedGetActorPosts(e echo.Context, input *FeedGetActorPostsInput) (*vylet.FeedGetActorPosts_Output, *echo.HTTPError)
 	FeedGetActorPostsRequiresAuth() bool
+	HandleFeedGetPostComments(e echo.Context, input *FeedGetPostCommentsInput) (*vylet.FeedGetPostComments_Output, *echo.HTTPError)
+	FeedGetPostCommentsRequiresAuth() bool
+	HandleFeedGetPostEdits(e echo.Context, input *FeedGetPostEditsInput) (*vylet.FeedGetPostEdits_Output, *echo.HTTPError)
+	FeedGetPostEditsRequiresAuth() bool
 	HandleFeedGetPosts(e echo.Context, input *FeedGetPostsInput) (*vylet.FeedGetPosts_Output, *echo.HTTPError)
 	FeedGetPostsRequiresAuth() bool
 	HandleFeedGetSubjectLikes(e echo.Context, input *FeedGetSubjectLikesInput) (*vylet.FeedGetSubjectLikes_Output, *echo.HTTPError)
@@ -28,6 +32,18 @@ type Server interface {
 	GraphGetActorFollowersRequiresAuth() bool
 	HandleGraphGetActorFollows(e echo.Context, input *GraphGetActorFollowsInput) (*vylet.GraphGetActorFollows_Output, *echo.HTTPError)
 	GraphGetActorFollowsRequiresAuth() bool
+	HandleGraphGetMutes(e echo.Context, input *GraphGetMutesInput) (*vylet.GraphGetMutes_Output, *echo.HTTPError)
+	GraphGetMutesRequiresAuth() bool
+	HandleNotificationGetUnreadCount(e echo.Context, input *NotificationGetUnreadCountInput) (*vylet.NotificationGetUnreadCount_Output, *echo.HTTPError)
+	NotificationGetUnreadCountRequiresAuth() bool
+	HandleNotificationListNotifications(e echo.Context, input *NotificationListNotificationsInput) (*vylet.NotificationListNotifications_Output, *echo.HTTPError)
+	NotificationListNotificationsRequiresAuth() bool
+	HandleGraphMuteActor(e echo.Context, input *GraphMuteActorInput) *echo.HTTPError
+	GraphMuteActorRequiresAuth() bool
+	HandleGraphUnmuteActor(e echo.Context, input *GraphUnmuteActorInput) *echo.HTTPError
+	GraphUnmuteActorRequiresAuth() bool
+	HandleNotificationUpdateSeen(e echo.Context, input *NotificationUpdateSeenInput) *echo.HTTPError
+	NotificationUpdateSeenRequiresAuth() bool
 }
 
 type Handlers struct {
@@ -42,10 +58,18 @@ func RegisterHandlers(e *echo.Echo, s Server) {
 	e.GET("/xrpc/app.vylet.actor.getProfile", h.HandleActorGetProfile, CreateAuthRequiredMiddleware(s.ActorGetProfileRequiresAuth()))
 	e.GET("/xrpc/app.vylet.actor.getProfiles", h.HandleActorGetProfiles, CreateAuthRequiredMiddleware(s.ActorGetProfilesRequiresAuth()))
 	e.GET("/xrpc/app.vylet.feed.getActorPosts", h.HandleFeedGetActorPosts, CreateAuthRequiredMiddleware(s.FeedGetActorPostsRequiresAuth()))
+	e.GET("/xrpc/app.vylet.feed.getPostComments", h.HandleFeedGetPostComments, CreateAuthRequiredMiddleware(s.FeedGetPostCommentsRequiresAuth()))
+	e.GET("/xrpc/app.vylet.feed.getPostEdits", h.HandleFeedGetPostEdits, CreateAuthRequiredMiddleware(s.FeedGetPostEditsRequiresAuth()))
 	e.GET("/xrpc/app.vylet.feed.getPosts", h.HandleFeedGetPosts, CreateAuthRequiredMiddleware(s.FeedGetPostsRequiresAuth()))
 	e.GET("/xrpc/app.vylet.feed.getSubjectLikes", h.HandleFeedGetSubjectLikes, CreateAuthRequiredMiddleware(s.FeedGetSubjectLikesRequiresAuth()))
 	e.GET("/xrpc/app.vylet.graph.getActorFollowers", h.HandleGraphGetActorFollowers, CreateAuthRequiredMiddleware(s.GraphGetActorFollowersRequiresAuth()))
 	e.GET("/xrpc/app.vylet.graph.getActorFollows", h.HandleGraphGetActorFollows, CreateAuthRequiredMiddleware(s.GraphGetActorFollowsRequiresAuth()))
+	e.GET("/xrpc/app.vylet.graph.getMutes", h.HandleGraphGetMutes, CreateAuthRequiredMiddleware(s.GraphGetMutesRequiresAuth()))
+	e.GET("/xrpc/app.vylet.notification.getUnreadCount", h.HandleNotificationGetUnreadCount, CreateAuthRequiredMiddleware(s.NotificationGetUnreadCountRequiresAuth()))
+	e.GET("/xrpc/app.vylet.notification.listNotifications", h.HandleNotificationListNotifications, CreateAuthRequiredMiddleware(s.NotificationListNotificationsRequiresAuth()))
+	e.POST("/xrpc/app.vylet.graph.muteActor", h.HandleGraphMuteActor, CreateAuthRequiredMiddleware(s.GraphMuteActorRequiresAuth()))
+	e.POST("/xrpc/app.vylet.graph.unmuteActor", h.HandleGraphUnmuteActor, CreateAuthRequiredMiddleware(s.GraphUnmuteActorRequiresAuth()))
+	e.POST("/xrpc/app.vylet.notification.updateSeen", h.HandleNotificationUpdateSeen, CreateAuthRequiredMiddleware(s.NotificationUpdateSeenRequiresAuth()))
 }
 
 func AuthRequiredMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
@@ -0,0 +1,31 @@
+// GENERATED CODE - DO NOT MODIFY
+// Generated by vylet-app/handlergen
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationListNotificationsInput struct {
+	Cursor *string `query:"cursor"`
+	Limit *int64 `query:"limit"`
+}
+
+func (h *Handlers) HandleNotificationListNotifications(e echo.Context) error {
+	var input NotificationListNotificationsInput
+	if err := e.Bind(&input); err != nil {
+		logger := h.server.Logger().With("handler", "HandleNotificationListNotifications")
+		logger.Error("error binding request", "err", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	output, err := h.server.HandleNotificationListNotifications(e, &input)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, &output)
+}
@@ -0,0 +1,29 @@
+// GENERATED CODE - DO NOT MODIFY
+// Generated by vylet-app/handlergen
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationUpdateSeenInput struct {
+	SeenAt string `json:"seenAt"`
+}
+
+func (h *Handlers) HandleNotificationUpdateSeen(e echo.Context) error {
+	var input NotificationUpdateSeenInput
+	if err := e.Bind(&input); err != nil {
+		logger := h.server.Logger().With("handler", "HandleNotificationUpdateSeen")
+		logger.Error("error binding request", "err", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	if err := h.server.HandleNotificationUpdateSeen(e, &input); err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusOK)
+}
@@ -34,6 +34,7 @@ type FeedDefs_PostView struct {
 	Caption    *string                       `json:"caption,omitempty" cborgen:"caption,omitempty"`
 	Cid        string                        `json:"cid" cborgen:"cid"`
 	CreatedAt  string                        `json:"createdAt" cborgen:"createdAt"`
+	EditedAt   *string                       `json:"editedAt,omitempty" cborgen:"editedAt,omitempty"`
 	Facets     []*RichtextFacet              `json:"facets,omitempty" cborgen:"facets,omitempty"`
 	IndexedAt  string                        `json:"indexedAt" cborgen:"indexedAt"`
 	Labels     []*comatproto.LabelDefs_Label `json:"labels,omitempty" cborgen:"labels,omitempty"`
@@ -0,0 +1,63 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+)
+
+const (
+	accountStatusActive  = "active"
+	accountStatusDeleted = "deleted"
+)
+
+func (s *Server) handleAccount(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
+	var acct comatproto.SyncSubscribeRepos_Account
+	if err := json.Unmarshal(evt.Account, &acct); err != nil {
+		return fmt.Errorf("failed to unmarshal account event: %w", err)
+	}
+
+	status := accountStatusActive
+	if !acct.Active {
+		// inactive accounts without a reason are treated as deactivated
+		status = "deactivated"
+		if acct.Status != nil && *acct.Status != "" {
+			status = *acct.Status
+		}
+	}
+
+	resp, err := s.db.Account.SetAccountStatus(ctx, &vyletdatabase.SetAccountStatusRequest{
+		AccountStatus: &vyletdatabase.AccountStatus{
+			Did:       evt.Did,
+			Active:    acct.Active,
+			Status:    status,
+			UpdatedAt: evt.Timestamp,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create set account status request: %w", err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("error setting account status: %s", *resp.Error)
+	}
+
+	if status != accountStatusDeleted {
+		return nil
+	}
+
+	purgeResp, err := s.db.Account.PurgeAccount(ctx, &vyletdatabase.PurgeAccountRequest{
+		Did: evt.Did,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create purge account request: %w", err)
+	}
+	if purgeResp.Error != nil {
+		return fmt.Errorf("error purging account: %s", *purgeResp.Error)
+	}
+
+	return nil
+}
@@ -2,23 +2,22 @@ package indexer
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
 	vyletdatabase "github.com/vylet-app/go/database/proto"
-	"github.com/vylet-app/go/generated/vylet"
 	"github.com/vylet-app/go/internal/helpers"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) handleActorProfile(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
-	var rec vylet.ActorProfile
 	op := evt.Commit
 	switch op.Operation {
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE:
-		if err := json.Unmarshal(op.Record, &rec); err != nil {
+		rec, err := records.ActorProfile(op)
+		if err != nil {
 			return fmt.Errorf("failed to unmarshal profile record: %w", err)
 		}
 
@@ -35,6 +34,7 @@ func (s *Server) handleActorProfile(ctx context.Context, evt *vyletkafka.Firehos
 				Pronouns:    rec.Pronouns,
 				CreatedAt:   timestamppb.New(createdAtTime),
 			},
+			Rev: evt.Commit.Rev,
 		}
 
 		if rec.Avatar != nil {
@@ -49,7 +49,8 @@ func (s *Server) handleActorProfile(ctx context.Context, evt *vyletkafka.Firehos
 			return fmt.Errorf("error creating profile: %s", *resp.Error)
 		}
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE:
-		if err := json.Unmarshal(op.Record, &rec); err != nil {
+		rec, err := records.ActorProfile(op)
+		if err != nil {
 			return fmt.Errorf("failed to unmarshal profile record: %w", err)
 		}
 
@@ -60,6 +61,7 @@ func (s *Server) handleActorProfile(ctx context.Context, evt *vyletkafka.Firehos
 				Description: rec.Description,
 				Pronouns:    rec.Pronouns,
 			},
+			Rev: evt.Commit.Rev,
 		}
 
 		if rec.Avatar != nil {
@@ -76,6 +78,7 @@ func (s *Server) handleActorProfile(ctx context.Context, evt *vyletkafka.Firehos
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE:
 		resp, err := s.db.Profile.DeleteProfile(ctx, &vyletdatabase.DeleteProfileRequest{
 			Did: evt.Did,
+			Rev: evt.Commit.Rev,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create delete profile request: %w", err)
@@ -0,0 +1,239 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/vylet-app/go/bus/headers"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// dispatcher fans events out to a bounded pool of workers. Events are assigned to a worker by did, so every repo's
+// events are handled in order while unrelated repos proceed in parallel. A partition's offset is only committed once
+// every earlier event in it has been handled.
+type dispatcher struct {
+	logger *slog.Logger
+
+	client  *kgo.Client
+	filter  *headers.Filter
+	handle  func(context.Context, *vyletkafka.FirehoseEvent) error
+	workers []chan *dispatchedEvent
+
+	lk         sync.Mutex
+	partitions map[int32]*partitionOffsets
+}
+
+type dispatchedEvent struct {
+	record    *kgo.Record
+	evt       *vyletkafka.FirehoseEvent
+	partition *partitionOffsets
+	// run is called in place of handling an event for work submitted from outside of the consumer, which has no
+	// offset to commit
+	run func(context.Context)
+}
+
+// partitionOffsets tracks the records of an assigned partition that were dispatched but not yet committed, in offset
+// order
+type partitionOffsets struct {
+	records []*kgo.Record
+	done    map[int64]bool
+}
+
+func newDispatcher(logger *slog.Logger, filter *headers.Filter, handle func(context.Context, *vyletkafka.FirehoseEvent) error, workers, queueSize int) *dispatcher {
+	if filter == nil {
+		filter = &headers.Filter{}
+	}
+
+	d := &dispatcher{
+		logger:     logger.With("component", "dispatcher"),
+		filter:     filter,
+		handle:     handle,
+		workers:    make([]chan *dispatchedEvent, workers),
+		partitions: make(map[int32]*partitionOffsets),
+	}
+
+	for i := range d.workers {
+		d.workers[i] = make(chan *dispatchedEvent, queueSize)
+	}
+
+	return d
+}
+
+// run polls the consumer and dispatches every record until ctx is cancelled or the client is closed. Events already
+// queued are left for whoever consumes the partition next, as their offsets were never committed.
+func (d *dispatcher) run(ctx context.Context) error {
+	logger := d.logger.With("name", "run")
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	for _, work := range d.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.work(ctx, work)
+		}()
+	}
+
+	defer func() {
+		cancel()
+		wg.Wait()
+
+		commitCtx, cancelCommit := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelCommit()
+		if err := d.client.CommitMarkedOffsets(commitCtx); err != nil {
+			logger.Error("failed to commit marked offsets", "err", err)
+		}
+	}()
+
+	for {
+		fetches := d.client.PollFetches(ctx)
+		if fetches.IsClientClosed() || ctx.Err() != nil {
+			return nil
+		}
+		if errs := fetches.Errors(); len(errs) > 0 {
+			var err error
+			for _, e := range errs {
+				err = errors.Join(err, fmt.Errorf("topic %s partition %d: %w", e.Topic, e.Partition, e.Err))
+			}
+			return err
+		}
+
+		for iter := fetches.RecordIter(); !iter.Done(); {
+			if err := d.dispatch(ctx, iter.Next()); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// dispatch queues a record on the worker for its repo, blocking while that worker's queue is full
+func (d *dispatcher) dispatch(ctx context.Context, r *kgo.Record) error {
+	var evt vyletkafka.FirehoseEvent
+	skip := d.filter.Skip(r)
+	if !skip {
+		if err := proto.Unmarshal(r.Value, &evt); err != nil {
+			d.logger.Error("failed to unmarshal event", "partition", r.Partition, "offset", r.Offset, "err", err)
+		}
+	}
+
+	d.lk.Lock()
+	po, ok := d.partitions[r.Partition]
+	if !ok {
+		po = &partitionOffsets{done: make(map[int64]bool)}
+		d.partitions[r.Partition] = po
+	}
+	po.records = append(po.records, r)
+	d.lk.Unlock()
+
+	de := &dispatchedEvent{
+		record:    r,
+		evt:       &evt,
+		partition: po,
+	}
+
+	// skipped and undecodable events and events without a repo have nothing to be ordered against
+	if skip || evt.Did == "" {
+		d.complete(de)
+		return nil
+	}
+
+	return d.enqueue(ctx, evt.Did, de)
+}
+
+// submit runs fn on the worker for the repo, ordered with the repo's events, blocking while that worker's queue is
+// full
+func (d *dispatcher) submit(ctx context.Context, did string, fn func(context.Context)) error {
+	return d.enqueue(ctx, did, &dispatchedEvent{run: fn})
+}
+
+func (d *dispatcher) enqueue(ctx context.Context, did string, de *dispatchedEvent) error {
+	h := fnv.New32a()
+	h.Write([]byte(did))
+	work := d.workers[h.Sum32()%uint32(len(d.workers))]
+
+	eventsQueued.Inc()
+	select {
+	case work <- de:
+		return nil
+	case <-ctx.Done():
+		eventsQueued.Dec()
+		return ctx.Err()
+	}
+}
+
+func (d *dispatcher) work(ctx context.Context, work chan *dispatchedEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case de := <-work:
+			eventsQueued.Dec()
+
+			if de.run != nil {
+				de.run(context.Background())
+				continue
+			}
+
+			// handled outside of ctx, so that shutting down does not interrupt an event halfway through
+			if err := d.handle(context.Background(), de.evt); err != nil {
+				d.logger.Error("failed to handle event", "did", de.evt.Did, "offset", de.record.Offset, "err", err)
+			}
+
+			d.complete(de)
+		}
+	}
+}
+
+// complete marks an event as handled, and marks the offset after the last contiguous handled record of its partition
+// to be committed
+func (d *dispatcher) complete(de *dispatchedEvent) {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+
+	po := de.partition
+	// the partition was revoked while the event was in flight, and is committed by its new owner
+	if d.partitions[de.record.Partition] != po {
+		return
+	}
+
+	po.done[de.record.Offset] = true
+
+	var last *kgo.Record
+	for len(po.records) > 0 && po.done[po.records[0].Offset] {
+		last = po.records[0]
+		delete(po.done, last.Offset)
+		po.records = po.records[1:]
+	}
+
+	if last != nil {
+		d.client.MarkCommitRecords(last)
+	}
+}
+
+// revoked forgets the in-flight records of partitions that are no longer assigned, then commits what was handled
+func (d *dispatcher) revoked(ctx context.Context, client *kgo.Client, revoked map[string][]int32) {
+	d.lost(ctx, client, revoked)
+
+	if err := client.CommitMarkedOffsets(ctx); err != nil {
+		d.logger.Error("failed to commit marked offsets", "err", err)
+	}
+}
+
+func (d *dispatcher) lost(_ context.Context, _ *kgo.Client, lost map[string][]int32) {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+
+	for _, partitions := range lost {
+		for _, partition := range partitions {
+			delete(d.partitions, partition)
+		}
+	}
+}
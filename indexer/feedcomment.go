@@ -0,0 +1,86 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) handleFeedComment(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
+	op := evt.Commit
+	uri := firehoseEventToUri(evt)
+	switch op.Operation {
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE:
+		rec, err := records.FeedComment(op)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal comment record: %w", err)
+		}
+
+		createdAtTime, err := time.Parse(time.RFC3339Nano, rec.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to parse time from record: %w", err)
+		}
+
+		if rec.Root == nil {
+			return fmt.Errorf("invalid comment, missing root")
+		}
+
+		// comments without a parent reply to the root post directly
+		parent := rec.Root
+		if rec.Parent != nil {
+			parent = rec.Parent
+		}
+
+		req := vyletdatabase.CreateCommentRequest{
+			Comment: &vyletdatabase.Comment{
+				Uri:       uri,
+				Cid:       evt.Commit.Cid,
+				AuthorDid: evt.Did,
+				Text:      &rec.Text,
+				RootUri:   rec.Root.Uri,
+				RootCid:   rec.Root.Cid,
+				ParentUri: parent.Uri,
+				ParentCid: parent.Cid,
+				CreatedAt: timestamppb.New(createdAtTime),
+			},
+			Rev: evt.Commit.Rev,
+		}
+
+		if rec.Facets != nil {
+			b, err := json.Marshal(rec.Facets)
+			if err != nil {
+				return fmt.Errorf("failed to marshal facets: %w", err)
+			}
+			req.Comment.Facets = b
+		}
+
+		resp, err := s.db.Comment.CreateComment(ctx, &req)
+		if err != nil {
+			return fmt.Errorf("failed to create create comment request: %w", err)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("error creating comment: %s", *resp.Error)
+		}
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE:
+		return fmt.Errorf("unsupported comment update event")
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE:
+		resp, err := s.db.Comment.DeleteComment(ctx, &vyletdatabase.DeleteCommentRequest{
+			Uri: uri,
+			Rev: evt.Commit.Rev,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create delete comment request: %w", err)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("error deleting comment %s", *resp.Error)
+		}
+	}
+
+	return nil
+}
@@ -2,43 +2,55 @@ package indexer
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	"github.com/vylet-app/go/database/client"
 	vyletdatabase "github.com/vylet-app/go/database/proto"
-	"github.com/vylet-app/go/generated/vylet"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+func likeFromEvent(evt *vyletkafka.FirehoseEvent) (*vyletdatabase.Like, error) {
+	rec, err := records.FeedLike(evt.Commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal like record: %w", err)
+	}
+
+	createdAtTime, err := time.Parse(time.RFC3339Nano, rec.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse time from record: %w", err)
+	}
+
+	if rec.Subject == nil {
+		return nil, fmt.Errorf("invalid like, missing subject")
+	}
+
+	return &vyletdatabase.Like{
+		Uri:        firehoseEventToUri(evt),
+		Cid:        evt.Commit.Cid,
+		AuthorDid:  evt.Did,
+		CreatedAt:  timestamppb.New(createdAtTime),
+		SubjectUri: rec.Subject.Uri,
+		SubjectCid: rec.Subject.Cid,
+	}, nil
+}
+
 func (s *Server) handleFeedLike(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
-	var rec vylet.FeedLike
 	op := evt.Commit
 	uri := firehoseEventToUri(evt)
 	switch op.Operation {
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE:
-		if err := json.Unmarshal(op.Record, &rec); err != nil {
-			return fmt.Errorf("failed to unmarshal like record: %w", err)
-		}
-
-		createdAtTime, err := time.Parse(time.RFC3339Nano, rec.CreatedAt)
+		like, err := likeFromEvent(evt)
 		if err != nil {
-			return fmt.Errorf("failed to parse time from record: %w", err)
+			return err
 		}
 
-		req := vyletdatabase.CreateLikeRequest{
-			Like: &vyletdatabase.Like{
-				Uri:        uri,
-				Cid:        evt.Commit.Cid,
-				AuthorDid:  evt.Did,
-				CreatedAt:  timestamppb.New(createdAtTime),
-				SubjectUri: rec.Subject.Uri,
-				SubjectCid: rec.Subject.Cid,
-			},
-		}
-
-		resp, err := s.db.Like.CreateLike(ctx, &req)
+		resp, err := s.db.Like.CreateLike(ctx, &vyletdatabase.CreateLikeRequest{
+			Like: like,
+			Rev:  evt.Commit.Rev,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to create create like request: %w", err)
 		}
@@ -46,10 +58,38 @@ func (s *Server) handleFeedLike(ctx context.Context, evt *vyletkafka.FirehoseEve
 			return fmt.Errorf("error creating like: %s", *resp.Error)
 		}
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE:
-		return fmt.Errorf("unsupported like update event")
+		like, err := likeFromEvent(evt)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.db.Like.UpdateLike(ctx, &vyletdatabase.UpdateLikeRequest{
+			Like: like,
+			Rev:  evt.Commit.Rev,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create update like request: %w", err)
+		}
+
+		// a like we never saw created is indexed as it is now
+		if client.IsNotFoundError(resp.Error) {
+			createResp, err := s.db.Like.CreateLike(ctx, &vyletdatabase.CreateLikeRequest{
+				Like: like,
+				Rev:  evt.Commit.Rev,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create create like request: %w", err)
+			}
+			if createResp.Error != nil {
+				return fmt.Errorf("error creating like: %s", *createResp.Error)
+			}
+		} else if resp.Error != nil {
+			return fmt.Errorf("error updating like: %s", *resp.Error)
+		}
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE:
 		resp, err := s.db.Like.DeleteLike(ctx, &vyletdatabase.DeleteLikeRequest{
 			Uri: uri,
+			Rev: evt.Commit.Rev,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create delete like request: %w", err)
@@ -7,76 +7,115 @@ import (
 	"time"
 
 	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	"github.com/vylet-app/go/database/client"
 	vyletdatabase "github.com/vylet-app/go/database/proto"
-	"github.com/vylet-app/go/generated/vylet"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+func postFromEvent(evt *vyletkafka.FirehoseEvent) (*vyletdatabase.Post, error) {
+	rec, err := records.FeedPost(evt.Commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal post record: %w", err)
+	}
+
+	createdAtTime, err := time.Parse(time.RFC3339Nano, rec.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse time from record: %w", err)
+	}
+
+	var images []*vyletdatabase.Image
+	if rec.Media == nil || rec.Media.MediaImages == nil || len(rec.Media.MediaImages.Images) == 0 {
+		return nil, fmt.Errorf("invalid post, missing or empty images")
+	}
+
+	for _, img := range rec.Media.MediaImages.Images {
+		dbimg := &vyletdatabase.Image{
+			Cid:  img.Image.Ref.String(),
+			Size: img.Image.Size,
+			Mime: img.Image.MimeType,
+			Alt:  &img.Alt,
+		}
+		if img.AspectRatio != nil {
+			dbimg.Width = &img.AspectRatio.Width
+			dbimg.Height = &img.AspectRatio.Height
+		}
+		images = append(images, dbimg)
+	}
+
+	post := &vyletdatabase.Post{
+		Uri:       firehoseEventToUri(evt),
+		Cid:       evt.Commit.Cid,
+		AuthorDid: evt.Did,
+		Images:    images,
+		Caption:   rec.Caption,
+		CreatedAt: timestamppb.New(createdAtTime),
+	}
+
+	if rec.Facets != nil {
+		b, err := json.Marshal(rec.Facets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal facets: %w", err)
+		}
+		post.Facets = b
+	}
+
+	return post, nil
+}
+
 func (s *Server) handleFeedPost(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
-	var rec vylet.FeedPost
 	op := evt.Commit
 	uri := firehoseEventToUri(evt)
 	switch op.Operation {
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE:
-		if err := json.Unmarshal(op.Record, &rec); err != nil {
-			return fmt.Errorf("failed to unmarshal post record: %w", err)
+		post, err := postFromEvent(evt)
+		if err != nil {
+			return err
 		}
 
-		createdAtTime, err := time.Parse(time.RFC3339Nano, rec.CreatedAt)
+		resp, err := s.db.Post.CreatePost(ctx, &vyletdatabase.CreatePostRequest{
+			Post: post,
+			Rev:  evt.Commit.Rev,
+		})
 		if err != nil {
-			return fmt.Errorf("failed to parse time from record: %w", err)
+			return fmt.Errorf("failed to create create post request: %w", err)
 		}
-
-		var images []*vyletdatabase.Image
-		if rec.Media == nil || rec.Media.MediaImages == nil || len(rec.Media.MediaImages.Images) == 0 {
-			return fmt.Errorf("invalid post, missing or empty images")
+		if resp.Error != nil {
+			return fmt.Errorf("error creating post: %s", *resp.Error)
 		}
-
-		for _, img := range rec.Media.MediaImages.Images {
-			dbimg := &vyletdatabase.Image{
-				Cid:  img.Image.Ref.String(),
-				Size: img.Image.Size,
-				Mime: img.Image.MimeType,
-				Alt:  &img.Alt,
-			}
-			if img.AspectRatio != nil {
-				dbimg.Width = &img.AspectRatio.Width
-				dbimg.Height = &img.AspectRatio.Height
-			}
-			images = append(images, dbimg)
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE:
+		post, err := postFromEvent(evt)
+		if err != nil {
+			return err
 		}
 
-		req := vyletdatabase.CreatePostRequest{
-			Post: &vyletdatabase.Post{
-				Uri:       uri,
-				Cid:       evt.Commit.Cid,
-				AuthorDid: evt.Did,
-				Images:    images,
-				Caption:   rec.Caption,
-				CreatedAt: timestamppb.New(createdAtTime),
-			},
+		resp, err := s.db.Post.UpdatePost(ctx, &vyletdatabase.UpdatePostRequest{
+			Post: post,
+			Rev:  evt.Commit.Rev,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create update post request: %w", err)
 		}
 
-		if rec.Facets != nil {
-			b, err := json.Marshal(rec.Facets)
+		// a post we never saw created is indexed as it is now
+		if client.IsNotFoundError(resp.Error) {
+			createResp, err := s.db.Post.CreatePost(ctx, &vyletdatabase.CreatePostRequest{
+				Post: post,
+				Rev:  evt.Commit.Rev,
+			})
 			if err != nil {
-				return fmt.Errorf("failed to marshal facets: %w", err)
+				return fmt.Errorf("failed to create create post request: %w", err)
 			}
-			req.Post.Facets = b
-		}
-
-		resp, err := s.db.Post.CreatePost(ctx, &req)
-		if err != nil {
-			return fmt.Errorf("failed to create create post request: %w", err)
-		}
-		if resp.Error != nil {
-			return fmt.Errorf("error creating post: %s", *resp.Error)
+			if createResp.Error != nil {
+				return fmt.Errorf("error creating post: %s", *createResp.Error)
+			}
+		} else if resp.Error != nil {
+			return fmt.Errorf("error updating post: %s", *resp.Error)
 		}
-	case vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE:
-		return fmt.Errorf("unsupported post update event")
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE:
 		resp, err := s.db.Post.DeletePost(ctx, &vyletdatabase.DeletePostRequest{
 			Uri: uri,
+			Rev: evt.Commit.Rev,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create delete post request: %w", err)
@@ -0,0 +1,63 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) handleGraphBlock(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
+	op := evt.Commit
+	uri := firehoseEventToUri(evt)
+	switch op.Operation {
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE:
+		rec, err := records.GraphBlock(op)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal block record: %w", err)
+		}
+
+		createdAtTime, err := time.Parse(time.RFC3339Nano, rec.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to parse time from record: %w", err)
+		}
+
+		req := vyletdatabase.CreateBlockRequest{
+			Block: &vyletdatabase.Block{
+				Uri:        uri,
+				Cid:        evt.Commit.Cid,
+				SubjectDid: rec.Subject,
+				AuthorDid:  evt.Did,
+				CreatedAt:  timestamppb.New(createdAtTime),
+			},
+			Rev: evt.Commit.Rev,
+		}
+
+		resp, err := s.db.Block.CreateBlock(ctx, &req)
+		if err != nil {
+			return fmt.Errorf("failed to create create block request: %w", err)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("error creating block: %s", *resp.Error)
+		}
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE:
+		return fmt.Errorf("unsupported block update event")
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE:
+		resp, err := s.db.Block.DeleteBlock(ctx, &vyletdatabase.DeleteBlockRequest{
+			Uri: uri,
+			Rev: evt.Commit.Rev,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create delete block request: %w", err)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("error deleting block %s", *resp.Error)
+		}
+	}
+
+	return nil
+}
@@ -2,23 +2,22 @@ package indexer
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
 	vyletdatabase "github.com/vylet-app/go/database/proto"
-	"github.com/vylet-app/go/generated/vylet"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) handleGraphFollow(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
-	var rec vylet.GraphFollow
 	op := evt.Commit
 	uri := firehoseEventToUri(evt)
 	switch op.Operation {
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE:
-		if err := json.Unmarshal(op.Record, &rec); err != nil {
+		rec, err := records.GraphFollow(op)
+		if err != nil {
 			return fmt.Errorf("failed to unmarshal follow record: %w", err)
 		}
 
@@ -35,6 +34,7 @@ func (s *Server) handleGraphFollow(ctx context.Context, evt *vyletkafka.Firehose
 				AuthorDid:  evt.Did,
 				CreatedAt:  timestamppb.New(createdAtTime),
 			},
+			Rev: evt.Commit.Rev,
 		}
 
 		resp, err := s.db.Follow.CreateFollow(ctx, &req)
@@ -49,6 +49,7 @@ func (s *Server) handleGraphFollow(ctx context.Context, evt *vyletkafka.Firehose
 	case vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE:
 		resp, err := s.db.Follow.DeleteFollow(ctx, &vyletdatabase.DeleteFollowRequest{
 			Uri: uri,
+			Rev: evt.Commit.Rev,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create delete follow request: %w", err)
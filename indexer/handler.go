@@ -2,13 +2,62 @@ package indexer
 
 import (
 	"context"
+	"time"
 
+	"github.com/vylet-app/go/bus/deadletter"
 	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
 )
 
+// indexedCollections are the collections whose commits are indexed
+var indexedCollections = []string{
+	records.CollectionActorProfile,
+	records.CollectionFeedPost,
+	records.CollectionFeedLike,
+	records.CollectionFeedComment,
+	records.CollectionGraphFollow,
+	records.CollectionGraphBlock,
+}
+
 func (s *Server) handleEvent(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
+	defer s.lag.Observe(evt)
+
 	if evt.Commit != nil {
-		return s.handleCommit(ctx, evt)
+		start := time.Now()
+		err := s.handleCommit(ctx, evt)
+		observeEvent(evt.Commit.Collection, evt.Commit.Operation.String(), start, err)
+		if err != nil {
+			// the consumer does not retry failed messages, so keep them around to be re-driven once fixed
+			s.deadLetters.SendEvent(ctx, deadletter.StageIndexer, evt, err)
+			return nil
+		}
+	}
+
+	if evt.Identity != nil {
+		start := time.Now()
+		err := s.handleIdentity(ctx, evt)
+		observeEvent("#identity", "", start, err)
+		if err != nil {
+			s.deadLetters.SendEvent(ctx, deadletter.StageIndexer, evt, err)
+		}
+	}
+
+	if evt.Account != nil {
+		start := time.Now()
+		err := s.handleAccount(ctx, evt)
+		observeEvent("#account", "", start, err)
+		if err != nil {
+			s.deadLetters.SendEvent(ctx, deadletter.StageIndexer, evt, err)
+		}
+	}
+
+	if evt.Sync != nil {
+		start := time.Now()
+		err := s.resyncs.mark(ctx, evt.Did)
+		observeEvent("#sync", "", start, err)
+		if err != nil {
+			s.deadLetters.SendEvent(ctx, deadletter.StageIndexer, evt, err)
+		}
 	}
 
 	return nil
@@ -22,8 +71,12 @@ func (s *Server) handleCommit(ctx context.Context, evt *vyletkafka.FirehoseEvent
 		return s.handleFeedPost(ctx, evt)
 	case "app.vylet.feed.like":
 		return s.handleFeedLike(ctx, evt)
+	case "app.vylet.feed.comment":
+		return s.handleFeedComment(ctx, evt)
 	case "app.vylet.graph.follow":
 		return s.handleGraphFollow(ctx, evt)
+	case "app.vylet.graph.block":
+		return s.handleGraphBlock(ctx, evt)
 	}
 
 	return nil
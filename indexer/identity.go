@@ -0,0 +1,55 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+)
+
+// handleIdentity stores the did's current handle. The handle in the event is only a hint, so the did document is
+// resolved again and its handle verified to resolve back to the did, storing handle.invalid when it does not.
+func (s *Server) handleIdentity(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
+	var ident comatproto.SyncSubscribeRepos_Identity
+	if err := json.Unmarshal(evt.Identity, &ident); err != nil {
+		return fmt.Errorf("failed to unmarshal identity event: %w", err)
+	}
+
+	did, err := syntax.ParseDID(evt.Did)
+	if err != nil {
+		return fmt.Errorf("failed to parse did: %w", err)
+	}
+
+	if err := s.directory.Purge(ctx, did.AtIdentifier()); err != nil {
+		return fmt.Errorf("failed to purge cached identity: %w", err)
+	}
+
+	resolved, err := s.directory.LookupDID(ctx, did)
+	if err != nil {
+		return fmt.Errorf("failed to resolve did: %w", err)
+	}
+
+	if ident.Handle != nil && *ident.Handle != resolved.Handle.String() {
+		s.logger.Debug("event handle does not match resolved handle", "did", evt.Did, "eventHandle", *ident.Handle, "handle", resolved.Handle)
+	}
+
+	resp, err := s.db.Identity.SetHandle(ctx, &vyletdatabase.SetHandleRequest{
+		Handle: &vyletdatabase.Handle{
+			Did:       evt.Did,
+			Handle:    resolved.Handle.String(),
+			UpdatedAt: evt.Timestamp,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create set handle request: %w", err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("error setting handle: %s", *resp.Error)
+	}
+
+	return nil
+}
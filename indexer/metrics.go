@@ -0,0 +1,67 @@
+package indexer
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vylet-app/go/database/client"
+	"google.golang.org/grpc"
+)
+
+const (
+	namespace = "indexer"
+)
+
+var (
+	eventsQueued = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "events_queued",
+	})
+
+	eventsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_processed",
+	}, []string{"collection", "operation", "status"})
+
+	eventDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "event_duration_seconds",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"collection", "operation"})
+
+	databaseErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "database_errors",
+	}, []string{"method"})
+)
+
+// observeEvent records the outcome and latency of handling one part of an event
+func observeEvent(collection, operation string, start time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+
+	eventsProcessed.WithLabelValues(collection, operation, status).Inc()
+	eventDuration.WithLabelValues(collection, operation).Observe(time.Since(start).Seconds())
+}
+
+// countDatabaseErrors counts failed database rpcs by method, whether the call itself failed or the response carries an
+// error. Not found responses are expected when updates fall back to creates, so they are not counted.
+func countDatabaseErrors(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err != nil {
+		databaseErrors.WithLabelValues(method).Inc()
+		return err
+	}
+
+	if resp, ok := reply.(interface{ GetError() string }); ok {
+		if msg := resp.GetError(); msg != "" && !client.IsNotFoundError(&msg) {
+			databaseErrors.WithLabelValues(method).Inc()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,496 @@
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/identity"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/bluesky-social/indigo/repo"
+	"github.com/ipfs/go-cid"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	"github.com/vylet-app/go/database/client"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// resyncPageSize is how many stored items are listed per request while reconciling a repo
+	resyncPageSize = 100
+	// resyncPollInterval is how often pending resyncs are loaded from the database, picking up those that did not fit
+	// in the queue or were pending when the indexer last stopped
+	resyncPollInterval = time.Minute
+)
+
+// resyncCollections are the collections a resync reconciles, in the order they are applied
+var resyncCollections = []string{
+	records.CollectionActorProfile,
+	records.CollectionFeedPost,
+	records.CollectionFeedComment,
+	records.CollectionFeedLike,
+	records.CollectionGraphFollow,
+	records.CollectionGraphBlock,
+}
+
+// resyncer fetches whole repos from their PDS and reconciles them against the database, for repos whose derived
+// state may be stale after a #sync event. Pending resyncs are stored in the database until they complete, and only
+// queued in memory while there is room. A DID marked again before its resync starts is only resynced once.
+type resyncer struct {
+	logger    *slog.Logger
+	server    *Server
+	directory identity.Directory
+	client    *http.Client
+
+	queue  chan string
+	lk     sync.Mutex
+	queued map[string]struct{}
+	active map[string]struct{}
+}
+
+func newResyncer(logger *slog.Logger, server *Server, directory identity.Directory, queueSize int) *resyncer {
+	return &resyncer{
+		logger:    logger.With("component", "resyncer"),
+		server:    server,
+		directory: directory,
+		client:    &http.Client{Timeout: 5 * time.Minute},
+		queue:     make(chan string, queueSize),
+		queued:    make(map[string]struct{}),
+		active:    make(map[string]struct{}),
+	}
+}
+
+// mark stores the repo as pending resync and queues it if there is room. It does not wait for the queue, so a burst
+// of #sync events never stalls the consumer.
+func (r *resyncer) mark(ctx context.Context, did string) error {
+	resp, err := r.server.db.Resync.MarkResync(ctx, &vyletdatabase.MarkResyncRequest{
+		Did:      did,
+		MarkedAt: timestamppb.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create mark resync request: %w", err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("error marking resync: %s", *resp.Error)
+	}
+
+	r.enqueue(did)
+
+	return nil
+}
+
+// enqueue queues the repo unless it is already queued or being resynced, reporting false if the queue is full. A
+// repo marked while it is being resynced stays pending in the database, and is queued again by the next poll.
+func (r *resyncer) enqueue(did string) bool {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	if _, ok := r.queued[did]; ok {
+		return true
+	}
+	if _, ok := r.active[did]; ok {
+		return true
+	}
+
+	select {
+	case r.queue <- did:
+		r.queued[did] = struct{}{}
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *resyncer) run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case did := <-r.queue:
+					r.work(ctx, did)
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.poll(ctx)
+	}()
+
+	wg.Wait()
+}
+
+// work resyncs a repo, then clears the marks made before it started. Failed resyncs are cleared too, as retrying an
+// unreachable PDS every poll would only load it, while resyncs interrupted by shutting down are left pending.
+func (r *resyncer) work(ctx context.Context, did string) {
+	r.lk.Lock()
+	delete(r.queued, did)
+	r.active[did] = struct{}{}
+	r.lk.Unlock()
+
+	defer func() {
+		r.lk.Lock()
+		delete(r.active, did)
+		r.lk.Unlock()
+	}()
+
+	startedAt := timestamppb.Now()
+
+	if err := r.resync(ctx, did); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		r.logger.Error("failed to resync repo", "did", did, "err", err)
+	}
+
+	resp, err := r.server.db.Resync.CompleteResync(ctx, &vyletdatabase.CompleteResyncRequest{
+		Did:       did,
+		StartedAt: startedAt,
+	})
+	if err != nil {
+		r.logger.Error("failed to create complete resync request", "did", did, "err", err)
+		return
+	}
+	if resp.Error != nil {
+		r.logger.Error("error completing resync", "did", did, "err", *resp.Error)
+	}
+}
+
+// poll queues the pending resyncs stored in the database, once at start and then every resyncPollInterval
+func (r *resyncer) poll(ctx context.Context) {
+	ticker := time.NewTicker(resyncPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.loadPending(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error("failed to load pending resyncs", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadPending queues stored pending resyncs until the queue is full
+func (r *resyncer) loadPending(ctx context.Context) error {
+	var cursor *string
+	for {
+		resp, err := r.server.db.Resync.GetPendingResyncs(ctx, &vyletdatabase.GetPendingResyncsRequest{
+			Limit:  resyncPageSize,
+			Cursor: cursor,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create get pending resyncs request: %w", err)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("error getting pending resyncs: %s", *resp.Error)
+		}
+
+		for _, did := range resp.Dids {
+			if !r.enqueue(did) {
+				return nil
+			}
+		}
+
+		if cursor = resp.Cursor; cursor == nil {
+			return nil
+		}
+	}
+}
+
+func (r *resyncer) resync(ctx context.Context, did string) error {
+	parsedDid, err := syntax.ParseDID(did)
+	if err != nil {
+		return fmt.Errorf("failed to parse did: %w", err)
+	}
+
+	ident, err := r.directory.LookupDID(ctx, parsedDid)
+	if err != nil {
+		return fmt.Errorf("failed to resolve did: %w", err)
+	}
+
+	pds := ident.PDSEndpoint()
+	if pds == "" {
+		return fmt.Errorf("did document has no pds endpoint")
+	}
+
+	pdsClient := atclient.NewAPIClient(pds)
+	pdsClient.Client = r.client
+
+	carBytes, err := comatproto.SyncGetRepo(ctx, pdsClient, did, "")
+	if err != nil {
+		return fmt.Errorf("failed to get repo from %s: %w", pds, err)
+	}
+
+	rr, err := repo.ReadRepoFromCar(ctx, bytes.NewReader(carBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read repo from car: %w", err)
+	}
+
+	if rr.RepoDid() != did {
+		return fmt.Errorf("pds returned repo for %s", rr.RepoDid())
+	}
+
+	// reconciled on the repo's dispatcher worker, so that it is ordered with the repo's live events instead of racing
+	// them
+	done := make(chan error, 1)
+	if err := r.server.dispatcher.submit(ctx, did, func(ctx context.Context) {
+		done <- r.reconcileRepo(ctx, did, rr)
+	}); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reconcileRepo reconciles every resynced collection of the repo against the database. It runs on the repo's
+// dispatcher worker.
+func (r *resyncer) reconcileRepo(ctx context.Context, did string, rr *repo.Repo) error {
+	rev := rr.SignedCommit().Rev
+
+	var errs []error
+	for _, collection := range resyncCollections {
+		inRepo, err := repoRecords(ctx, rr, collection)
+		if err != nil {
+			return err
+		}
+
+		stored, err := r.storedRecords(ctx, did, collection)
+		if err != nil {
+			return err
+		}
+
+		errs = append(errs, r.reconcile(ctx, did, rev, collection, rr, inRepo, stored)...)
+	}
+
+	r.logger.Info("resynced repo", "did", did, "rev", rev, "errors", len(errs))
+
+	return errors.Join(errs...)
+}
+
+// reconcile applies the commits that bring the stored records for a collection in line with the repo. Records whose
+// cid changed are updated where the handler supports it, and otherwise deleted and created again.
+func (r *resyncer) reconcile(ctx context.Context, did, rev, collection string, rr *repo.Repo, inRepo, stored map[string]string) []error {
+	var errs []error
+
+	apply := func(rkey string, operation vyletkafka.CommitOperation, recCid, rev string) {
+		evt := &vyletkafka.FirehoseEvent{
+			Did:       did,
+			Timestamp: timestamppb.Now(),
+			Commit: &vyletkafka.Commit{
+				Rev:        rev,
+				Operation:  operation,
+				Collection: collection,
+				Rkey:       rkey,
+				Cid:        recCid,
+			},
+		}
+
+		if operation != vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE {
+			c, err := cid.Decode(recCid)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to parse cid of %s/%s: %w", collection, rkey, err))
+				return
+			}
+
+			blk, err := rr.Blockstore().Get(ctx, c)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to get record %s/%s: %w", collection, rkey, err))
+				return
+			}
+
+			evt.Commit.RecordCbor = blk.RawData()
+			if err := records.SetFromCBOR(evt.Commit, evt.Commit.RecordCbor); err != nil {
+				errs = append(errs, err)
+				return
+			}
+		}
+
+		if err := r.server.handleCommit(ctx, evt); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply %s/%s: %w", collection, rkey, err))
+		}
+	}
+
+	updatable := collection == records.CollectionFeedPost || collection == records.CollectionFeedLike
+
+	for rkey, storedCid := range stored {
+		repoCid, ok := inRepo[rkey]
+		switch {
+		case !ok:
+			apply(rkey, vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE, "", rev)
+		case !updatable && storedCid != "" && storedCid != repoCid:
+			// deleted without a rev, since a tombstone at the repo's rev would make the create that follows stale
+			apply(rkey, vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE, "", "")
+		}
+	}
+
+	for rkey, repoCid := range inRepo {
+		storedCid, ok := stored[rkey]
+		switch {
+		case ok && updatable && storedCid != repoCid:
+			apply(rkey, vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE, repoCid, rev)
+		case !ok || (storedCid != "" && storedCid != repoCid):
+			apply(rkey, vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE, repoCid, rev)
+		case storedCid == "":
+			// profiles are stored without their cid, so they are always updated
+			apply(rkey, vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE, repoCid, rev)
+		}
+	}
+
+	return errs
+}
+
+// repoRecords returns the cid of every record in the collection, keyed by rkey
+func repoRecords(ctx context.Context, rr *repo.Repo, collection string) (map[string]string, error) {
+	prefix := collection + "/"
+	out := make(map[string]string)
+
+	if err := rr.ForEach(ctx, prefix, func(k string, v cid.Cid) error {
+		if !strings.HasPrefix(k, prefix) {
+			return repo.ErrDoneIterating
+		}
+		out[strings.TrimPrefix(k, prefix)] = v.String()
+		return nil
+	}); err != nil && !errors.Is(err, repo.ErrDoneIterating) {
+		return nil, fmt.Errorf("failed to list %s records: %w", collection, err)
+	}
+
+	return out, nil
+}
+
+// storedRecords returns the cid of every stored record in the collection for the repo, keyed by rkey
+func (r *resyncer) storedRecords(ctx context.Context, did, collection string) (map[string]string, error) {
+	db := r.server.db
+	out := make(map[string]string)
+
+	add := func(uri, recCid string) {
+		aturi, err := syntax.ParseATURI(uri)
+		if err != nil {
+			return
+		}
+		out[aturi.RecordKey().String()] = recCid
+	}
+
+	var cursor *string
+	switch collection {
+	case records.CollectionActorProfile:
+		resp, err := db.Profile.GetProfile(ctx, &vyletdatabase.GetProfileRequest{Did: did})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create get profile request: %w", err)
+		}
+		if resp.Error != nil {
+			if client.IsNotFoundError(resp.Error) {
+				return out, nil
+			}
+			return nil, fmt.Errorf("error getting profile: %s", *resp.Error)
+		}
+		out["self"] = ""
+	case records.CollectionFeedPost:
+		for {
+			resp, err := db.Post.GetPostsByActor(ctx, &vyletdatabase.GetPostsByActorRequest{Did: did, Limit: resyncPageSize, Cursor: cursor})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create get posts by actor request: %w", err)
+			}
+			if resp.Error != nil {
+				return nil, fmt.Errorf("error getting posts by actor: %s", *resp.Error)
+			}
+			for _, post := range resp.Posts {
+				add(post.Uri, post.Cid)
+			}
+			if cursor = resp.Cursor; cursor == nil {
+				break
+			}
+		}
+	case records.CollectionFeedComment:
+		for {
+			resp, err := db.Comment.GetCommentsByActor(ctx, &vyletdatabase.GetCommentsByActorRequest{Did: did, Limit: resyncPageSize, Cursor: cursor})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create get comments by actor request: %w", err)
+			}
+			if resp.Error != nil {
+				return nil, fmt.Errorf("error getting comments by actor: %s", *resp.Error)
+			}
+			for _, comment := range resp.Comments {
+				add(comment.Uri, comment.Cid)
+			}
+			if cursor = resp.Cursor; cursor == nil {
+				break
+			}
+		}
+	case records.CollectionFeedLike:
+		for {
+			resp, err := db.Like.GetLikesByActor(ctx, &vyletdatabase.GetLikesByActorRequest{Did: did, Limit: resyncPageSize, Cursor: cursor})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create get likes by actor request: %w", err)
+			}
+			if resp.Error != nil {
+				return nil, fmt.Errorf("error getting likes by actor: %s", *resp.Error)
+			}
+			for _, like := range resp.Likes {
+				add(like.Uri, like.Cid)
+			}
+			if cursor = resp.Cursor; cursor == nil {
+				break
+			}
+		}
+	case records.CollectionGraphFollow:
+		for {
+			resp, err := db.Follow.GetFollowsByActor(ctx, &vyletdatabase.GetFollowsByActorRequest{Did: did, Limit: resyncPageSize, Cursor: cursor})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create get follows by actor request: %w", err)
+			}
+			if resp.Error != nil {
+				return nil, fmt.Errorf("error getting follows by actor: %s", *resp.Error)
+			}
+			for _, follow := range resp.Follows {
+				add(follow.Uri, follow.Cid)
+			}
+			if cursor = resp.Cursor; cursor == nil {
+				break
+			}
+		}
+	case records.CollectionGraphBlock:
+		for {
+			resp, err := db.Block.GetBlocksByActor(ctx, &vyletdatabase.GetBlocksByActorRequest{Did: did, Limit: resyncPageSize, Cursor: cursor})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create get blocks by actor request: %w", err)
+			}
+			if resp.Error != nil {
+				return nil, fmt.Errorf("error getting blocks by actor: %s", *resp.Error)
+			}
+			for _, block := range resp.Blocks {
+				add(block.Uri, block.Cid)
+			}
+			if cursor = resp.Cursor; cursor == nil {
+				break
+			}
+		}
+	}
+
+	return out, nil
+}
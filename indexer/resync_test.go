@@ -0,0 +1,485 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	"github.com/bluesky-social/indigo/atproto/identity"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	lexutil "github.com/bluesky-social/indigo/lex/util"
+	"github.com/ipfs/go-cid"
+	"github.com/vylet-app/go/bus/records"
+	"github.com/vylet-app/go/database/client"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/generated/vylet"
+	"github.com/vylet-app/go/internal/helpers"
+	"github.com/vylet-app/go/internal/repotest"
+	"google.golang.org/grpc"
+)
+
+const testCreatedAt = "2025-01-01T00:00:00Z"
+
+// fakeDB stands in for the database services a resync reads and writes. It keeps the cid of every stored record by
+// uri and records every write as "operation uri".
+type fakeDB struct {
+	lk      sync.Mutex
+	records map[string]string
+	writes  []string
+
+	pendingResyncs map[string]int
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{
+		records:        make(map[string]string),
+		pendingResyncs: make(map[string]int),
+	}
+}
+
+func (db *fakeDB) client() *client.Client {
+	return &client.Client{
+		Profile: &fakeProfiles{db: db},
+		Post:    &fakePosts{db: db},
+		Comment: &fakeComments{db: db},
+		Like:    &fakeLikes{db: db},
+		Follow:  &fakeFollows{db: db},
+		Block:   &fakeBlocks{db: db},
+		Resync:  &fakeResyncs{db: db},
+	}
+}
+
+func (db *fakeDB) put(uri, recCid string) {
+	db.lk.Lock()
+	defer db.lk.Unlock()
+
+	db.records[uri] = recCid
+}
+
+func (db *fakeDB) write(operation, uri, recCid string) {
+	db.lk.Lock()
+	defer db.lk.Unlock()
+
+	db.writes = append(db.writes, operation+" "+uri)
+	if operation == "delete" {
+		delete(db.records, uri)
+	} else {
+		db.records[uri] = recCid
+	}
+}
+
+func (db *fakeDB) has(uri string) bool {
+	db.lk.Lock()
+	defer db.lk.Unlock()
+
+	_, ok := db.records[uri]
+	return ok
+}
+
+func (db *fakeDB) byCollection(did, collection string) map[string]string {
+	db.lk.Lock()
+	defer db.lk.Unlock()
+
+	prefix := fmt.Sprintf("at://%s/%s/", did, collection)
+	out := make(map[string]string)
+	for uri, recCid := range db.records {
+		if strings.HasPrefix(uri, prefix) {
+			out[uri] = recCid
+		}
+	}
+	return out
+}
+
+type fakeProfiles struct {
+	vyletdatabase.ProfileServiceClient
+	db *fakeDB
+}
+
+func (f *fakeProfiles) GetProfile(_ context.Context, req *vyletdatabase.GetProfileRequest, _ ...grpc.CallOption) (*vyletdatabase.GetProfileResponse, error) {
+	if !f.db.has(profileUri(req.Did)) {
+		return &vyletdatabase.GetProfileResponse{Error: helpers.ToStringPtr("not found")}, nil
+	}
+	return &vyletdatabase.GetProfileResponse{Profile: &vyletdatabase.Profile{Did: req.Did}}, nil
+}
+
+func (f *fakeProfiles) CreateProfile(_ context.Context, req *vyletdatabase.CreateProfileRequest, _ ...grpc.CallOption) (*vyletdatabase.CreateProfileResponse, error) {
+	f.db.write("create", profileUri(req.Profile.Did), "")
+	return &vyletdatabase.CreateProfileResponse{}, nil
+}
+
+func (f *fakeProfiles) UpdateProfile(_ context.Context, req *vyletdatabase.CreateProfileRequest, _ ...grpc.CallOption) (*vyletdatabase.CreateProfileResponse, error) {
+	f.db.write("update", profileUri(req.Profile.Did), "")
+	return &vyletdatabase.CreateProfileResponse{}, nil
+}
+
+func (f *fakeProfiles) DeleteProfile(_ context.Context, req *vyletdatabase.DeleteProfileRequest, _ ...grpc.CallOption) (*vyletdatabase.DeleteProfileResponse, error) {
+	f.db.write("delete", profileUri(req.Did), "")
+	return &vyletdatabase.DeleteProfileResponse{}, nil
+}
+
+func profileUri(did string) string {
+	return fmt.Sprintf("at://%s/%s/self", did, records.CollectionActorProfile)
+}
+
+type fakePosts struct {
+	vyletdatabase.PostServiceClient
+	db *fakeDB
+}
+
+func (f *fakePosts) GetPostsByActor(_ context.Context, req *vyletdatabase.GetPostsByActorRequest, _ ...grpc.CallOption) (*vyletdatabase.GetPostsByActorResponse, error) {
+	posts := make(map[string]*vyletdatabase.Post)
+	for uri, recCid := range f.db.byCollection(req.Did, records.CollectionFeedPost) {
+		posts[uri] = &vyletdatabase.Post{Uri: uri, Cid: recCid}
+	}
+	return &vyletdatabase.GetPostsByActorResponse{Posts: posts}, nil
+}
+
+func (f *fakePosts) CreatePost(_ context.Context, req *vyletdatabase.CreatePostRequest, _ ...grpc.CallOption) (*vyletdatabase.CreatePostResponse, error) {
+	f.db.write("create", req.Post.Uri, req.Post.Cid)
+	return &vyletdatabase.CreatePostResponse{}, nil
+}
+
+func (f *fakePosts) UpdatePost(_ context.Context, req *vyletdatabase.UpdatePostRequest, _ ...grpc.CallOption) (*vyletdatabase.UpdatePostResponse, error) {
+	f.db.write("update", req.Post.Uri, req.Post.Cid)
+	return &vyletdatabase.UpdatePostResponse{}, nil
+}
+
+func (f *fakePosts) DeletePost(_ context.Context, req *vyletdatabase.DeletePostRequest, _ ...grpc.CallOption) (*vyletdatabase.DeletePostResponse, error) {
+	f.db.write("delete", req.Uri, "")
+	return &vyletdatabase.DeletePostResponse{}, nil
+}
+
+type fakeComments struct {
+	vyletdatabase.CommentServiceClient
+	db *fakeDB
+}
+
+func (f *fakeComments) GetCommentsByActor(_ context.Context, req *vyletdatabase.GetCommentsByActorRequest, _ ...grpc.CallOption) (*vyletdatabase.GetCommentsByActorResponse, error) {
+	var comments []*vyletdatabase.Comment
+	for uri, recCid := range f.db.byCollection(req.Did, records.CollectionFeedComment) {
+		comments = append(comments, &vyletdatabase.Comment{Uri: uri, Cid: recCid})
+	}
+	return &vyletdatabase.GetCommentsByActorResponse{Comments: comments}, nil
+}
+
+func (f *fakeComments) CreateComment(_ context.Context, req *vyletdatabase.CreateCommentRequest, _ ...grpc.CallOption) (*vyletdatabase.CreateCommentResponse, error) {
+	f.db.write("create", req.Comment.Uri, req.Comment.Cid)
+	return &vyletdatabase.CreateCommentResponse{}, nil
+}
+
+func (f *fakeComments) DeleteComment(_ context.Context, req *vyletdatabase.DeleteCommentRequest, _ ...grpc.CallOption) (*vyletdatabase.DeleteCommentResponse, error) {
+	f.db.write("delete", req.Uri, "")
+	return &vyletdatabase.DeleteCommentResponse{}, nil
+}
+
+type fakeLikes struct {
+	vyletdatabase.LikeServiceClient
+	db *fakeDB
+}
+
+func (f *fakeLikes) GetLikesByActor(_ context.Context, req *vyletdatabase.GetLikesByActorRequest, _ ...grpc.CallOption) (*vyletdatabase.GetLikesByActorResponse, error) {
+	var likes []*vyletdatabase.Like
+	for uri, recCid := range f.db.byCollection(req.Did, records.CollectionFeedLike) {
+		likes = append(likes, &vyletdatabase.Like{Uri: uri, Cid: recCid})
+	}
+	return &vyletdatabase.GetLikesByActorResponse{Likes: likes}, nil
+}
+
+func (f *fakeLikes) CreateLike(_ context.Context, req *vyletdatabase.CreateLikeRequest, _ ...grpc.CallOption) (*vyletdatabase.CreateLikeResponse, error) {
+	f.db.write("create", req.Like.Uri, req.Like.Cid)
+	return &vyletdatabase.CreateLikeResponse{}, nil
+}
+
+func (f *fakeLikes) UpdateLike(_ context.Context, req *vyletdatabase.UpdateLikeRequest, _ ...grpc.CallOption) (*vyletdatabase.UpdateLikeResponse, error) {
+	f.db.write("update", req.Like.Uri, req.Like.Cid)
+	return &vyletdatabase.UpdateLikeResponse{}, nil
+}
+
+func (f *fakeLikes) DeleteLike(_ context.Context, req *vyletdatabase.DeleteLikeRequest, _ ...grpc.CallOption) (*vyletdatabase.DeleteLikeResponse, error) {
+	f.db.write("delete", req.Uri, "")
+	return &vyletdatabase.DeleteLikeResponse{}, nil
+}
+
+type fakeFollows struct {
+	vyletdatabase.FollowServiceClient
+	db *fakeDB
+}
+
+func (f *fakeFollows) GetFollowsByActor(_ context.Context, req *vyletdatabase.GetFollowsByActorRequest, _ ...grpc.CallOption) (*vyletdatabase.GetFollowsByActorResponse, error) {
+	var follows []*vyletdatabase.Follow
+	for uri, recCid := range f.db.byCollection(req.Did, records.CollectionGraphFollow) {
+		follows = append(follows, &vyletdatabase.Follow{Uri: uri, Cid: recCid})
+	}
+	return &vyletdatabase.GetFollowsByActorResponse{Follows: follows}, nil
+}
+
+func (f *fakeFollows) CreateFollow(_ context.Context, req *vyletdatabase.CreateFollowRequest, _ ...grpc.CallOption) (*vyletdatabase.CreateFollowResponse, error) {
+	f.db.write("create", req.Follow.Uri, req.Follow.Cid)
+	return &vyletdatabase.CreateFollowResponse{}, nil
+}
+
+func (f *fakeFollows) DeleteFollow(_ context.Context, req *vyletdatabase.DeleteFollowRequest, _ ...grpc.CallOption) (*vyletdatabase.DeleteFollowResponse, error) {
+	f.db.write("delete", req.Uri, "")
+	return &vyletdatabase.DeleteFollowResponse{}, nil
+}
+
+type fakeBlocks struct {
+	vyletdatabase.BlockServiceClient
+	db *fakeDB
+}
+
+func (f *fakeBlocks) GetBlocksByActor(context.Context, *vyletdatabase.GetBlocksByActorRequest, ...grpc.CallOption) (*vyletdatabase.GetBlocksByActorResponse, error) {
+	return &vyletdatabase.GetBlocksByActorResponse{}, nil
+}
+
+type fakeResyncs struct {
+	vyletdatabase.ResyncServiceClient
+	db *fakeDB
+}
+
+func (f *fakeResyncs) MarkResync(_ context.Context, req *vyletdatabase.MarkResyncRequest, _ ...grpc.CallOption) (*vyletdatabase.MarkResyncResponse, error) {
+	f.db.lk.Lock()
+	defer f.db.lk.Unlock()
+
+	f.db.pendingResyncs[req.Did]++
+	return &vyletdatabase.MarkResyncResponse{}, nil
+}
+
+func (f *fakeResyncs) CompleteResync(_ context.Context, req *vyletdatabase.CompleteResyncRequest, _ ...grpc.CallOption) (*vyletdatabase.CompleteResyncResponse, error) {
+	f.db.lk.Lock()
+	defer f.db.lk.Unlock()
+
+	delete(f.db.pendingResyncs, req.Did)
+	return &vyletdatabase.CompleteResyncResponse{}, nil
+}
+
+func (f *fakeResyncs) GetPendingResyncs(context.Context, *vyletdatabase.GetPendingResyncsRequest, ...grpc.CallOption) (*vyletdatabase.GetPendingResyncsResponse, error) {
+	f.db.lk.Lock()
+	defer f.db.lk.Unlock()
+
+	var dids []string
+	for did := range f.db.pendingResyncs {
+		dids = append(dids, did)
+	}
+	slices.Sort(dids)
+	return &vyletdatabase.GetPendingResyncsResponse{Dids: dids}, nil
+}
+
+// newTestResyncer returns a resyncer whose reconciles run on started dispatcher workers, resolving the repos to the
+// stub PDS serving them
+func newTestResyncer(t *testing.T, db *fakeDB, queueSize int, repos ...*repotest.Repo) *resyncer {
+	pds := repotest.NewPDS(t, repos...)
+
+	directory := identity.NewMockDirectory()
+	for _, r := range repos {
+		directory.Insert(identity.Identity{
+			DID: syntax.DID(r.Did()),
+			Services: map[string]identity.ServiceEndpoint{
+				"atproto_pds": {Type: "AtprotoPersonalDataServer", URL: pds.URL},
+			},
+		})
+	}
+
+	logger := slog.New(slog.DiscardHandler)
+	s := &Server{
+		logger: logger,
+		db:     db.client(),
+	}
+	s.dispatcher = newDispatcher(logger, nil, s.handleEvent, 2, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	for _, work := range s.dispatcher.workers {
+		go s.dispatcher.work(ctx, work)
+	}
+
+	s.resyncs = newResyncer(logger, s, &directory, queueSize)
+
+	return s.resyncs
+}
+
+func testImage() *vylet.FeedPost_Media {
+	c, err := cid.Decode("bafkreiesoy5p2kcc73o7qv4iywlxnzssdjivvsoa3ivhnaqy2uyjgmmnbq")
+	if err != nil {
+		panic(err)
+	}
+
+	return &vylet.FeedPost_Media{
+		MediaImages: &vylet.MediaImages{
+			LexiconTypeID: "app.vylet.media.images",
+			Images: []*vylet.MediaImages_Image{{
+				Image: &lexutil.LexBlob{Ref: lexutil.LexLink(c), MimeType: "image/jpeg", Size: 1},
+			}},
+		},
+	}
+}
+
+func testPost(caption string) *vylet.FeedPost {
+	return &vylet.FeedPost{
+		LexiconTypeID: records.CollectionFeedPost,
+		Caption:       helpers.ToStringPtr(caption),
+		CreatedAt:     testCreatedAt,
+		Media:         testImage(),
+	}
+}
+
+func testComment(root string) *vylet.FeedComment {
+	return &vylet.FeedComment{
+		LexiconTypeID: records.CollectionFeedComment,
+		CreatedAt:     testCreatedAt,
+		Text:          "nice",
+		Root: &comatproto.RepoStrongRef{
+			Uri: root,
+			Cid: "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm",
+		},
+	}
+}
+
+func testLike(subject string) *vylet.FeedLike {
+	return &vylet.FeedLike{
+		LexiconTypeID: records.CollectionFeedLike,
+		CreatedAt:     testCreatedAt,
+		Subject: &comatproto.RepoStrongRef{
+			Uri: subject,
+			Cid: "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm",
+		},
+	}
+}
+
+func testFollow(subject string) *vylet.GraphFollow {
+	return &vylet.GraphFollow{
+		LexiconTypeID: records.CollectionGraphFollow,
+		CreatedAt:     testCreatedAt,
+		Subject:       subject,
+	}
+}
+
+func TestResyncReconcilesStaleRecords(t *testing.T) {
+	const did = "did:plc:alice"
+	uri := func(collection, rkey string) string {
+		return fmt.Sprintf("at://%s/%s/%s", did, collection, rkey)
+	}
+
+	alice := repotest.NewRepo(t, did)
+	alice.Put(records.CollectionActorProfile, "self", &vylet.ActorProfile{
+		LexiconTypeID: records.CollectionActorProfile,
+		DisplayName:   helpers.ToStringPtr("Alice"),
+		CreatedAt:     testCreatedAt,
+	})
+	unchangedCid := alice.Put(records.CollectionFeedPost, "3kunchanged", testPost("unchanged"))
+	editedCid := alice.Put(records.CollectionFeedPost, "3kedited", testPost("edited"))
+	missedCid := alice.Put(records.CollectionFeedPost, "3kmissed", testPost("missed"))
+	commentCid := alice.Put(records.CollectionFeedComment, "3kcomment", testComment("at://did:plc:bob/app.vylet.feed.post/3kbob"))
+	likeCid := alice.Put(records.CollectionFeedLike, "3klike", testLike("at://did:plc:bob/app.vylet.feed.post/3kbob"))
+	followCid := alice.Put(records.CollectionGraphFollow, "3kfollow", testFollow("did:plc:bob"))
+	alice.Commit()
+
+	// the database as it was before the events the #sync replaces
+	db := newFakeDB()
+	db.put(profileUri(did), "")
+	db.put(uri(records.CollectionFeedPost, "3kunchanged"), unchangedCid.String())
+	db.put(uri(records.CollectionFeedPost, "3kedited"), "bafyreistalecid")
+	db.put(uri(records.CollectionFeedPost, "3kdeleted"), "bafyreistalecid")
+	db.put(uri(records.CollectionFeedComment, "3kuncommented"), "bafyreistalecid")
+	db.put(uri(records.CollectionFeedLike, "3kunliked"), "bafyreistalecid")
+	db.put(uri(records.CollectionGraphFollow, "3kfollow"), "bafyreistalecid")
+	db.put(uri(records.CollectionGraphFollow, "3kunfollowed"), "bafyreistalecid")
+	// other repos are left alone
+	db.put("at://did:plc:bob/app.vylet.feed.post/3kbob", "bafyreibobcid")
+
+	r := newTestResyncer(t, db, 10, alice)
+
+	if err := r.resync(t.Context(), did); err != nil {
+		t.Fatalf("failed to resync: %v", err)
+	}
+
+	want := map[string]string{
+		profileUri(did): "",
+		uri(records.CollectionFeedPost, "3kunchanged"):  unchangedCid.String(),
+		uri(records.CollectionFeedPost, "3kedited"):     editedCid.String(),
+		uri(records.CollectionFeedPost, "3kmissed"):     missedCid.String(),
+		uri(records.CollectionFeedComment, "3kcomment"): commentCid.String(),
+		uri(records.CollectionFeedLike, "3klike"):       likeCid.String(),
+		uri(records.CollectionGraphFollow, "3kfollow"):  followCid.String(),
+		"at://did:plc:bob/app.vylet.feed.post/3kbob":    "bafyreibobcid",
+	}
+	for uri, recCid := range want {
+		if got, ok := db.records[uri]; !ok || got != recCid {
+			t.Errorf("record %s has cid %q (stored %v), want %q", uri, got, ok, recCid)
+		}
+	}
+	for uri := range db.records {
+		if _, ok := want[uri]; !ok {
+			t.Errorf("stale record %s was not deleted", uri)
+		}
+	}
+
+	// follows can't be updated, so a changed one is deleted and created again
+	wantWrites := []string{
+		"update " + profileUri(did),
+		"update " + uri(records.CollectionFeedPost, "3kedited"),
+		"create " + uri(records.CollectionFeedPost, "3kmissed"),
+		"delete " + uri(records.CollectionFeedPost, "3kdeleted"),
+		"create " + uri(records.CollectionFeedComment, "3kcomment"),
+		"delete " + uri(records.CollectionFeedComment, "3kuncommented"),
+		"create " + uri(records.CollectionFeedLike, "3klike"),
+		"delete " + uri(records.CollectionFeedLike, "3kunliked"),
+		"delete " + uri(records.CollectionGraphFollow, "3kfollow"),
+		"create " + uri(records.CollectionGraphFollow, "3kfollow"),
+		"delete " + uri(records.CollectionGraphFollow, "3kunfollowed"),
+	}
+	slices.Sort(wantWrites)
+	gotWrites := slices.Clone(db.writes)
+	slices.Sort(gotWrites)
+	if !slices.Equal(gotWrites, wantWrites) {
+		t.Fatalf("writes\n%s\nwant\n%s", strings.Join(gotWrites, "\n"), strings.Join(wantWrites, "\n"))
+	}
+
+	// a second resync of an up to date repo writes nothing but the profile, which is stored without its cid
+	db.writes = nil
+	if err := r.resync(t.Context(), did); err != nil {
+		t.Fatalf("failed to resync: %v", err)
+	}
+	if want := []string{"update " + profileUri(did)}; !slices.Equal(db.writes, want) {
+		t.Fatalf("second resync wrote %v, want %v", db.writes, want)
+	}
+}
+
+func TestResyncMarkDoesNotBlock(t *testing.T) {
+	db := newFakeDB()
+	r := newTestResyncer(t, db, 1)
+
+	// the queue only has room for one repo, the others are only stored
+	for _, did := range []string{"did:plc:alice", "did:plc:bob", "did:plc:carol", "did:plc:alice"} {
+		if err := r.mark(t.Context(), did); err != nil {
+			t.Fatalf("failed to mark %s: %v", did, err)
+		}
+	}
+
+	if got := <-r.queue; got != "did:plc:alice" {
+		t.Fatalf("queued %s, want did:plc:alice", got)
+	}
+	if len(db.pendingResyncs) != 3 {
+		t.Fatalf("stored pending resyncs %v, want 3 repos", db.pendingResyncs)
+	}
+
+	// as after a restart, the stored resyncs are queued as room frees up
+	r.queued = make(map[string]struct{})
+	if err := r.loadPending(t.Context()); err != nil {
+		t.Fatalf("failed to load pending resyncs: %v", err)
+	}
+	if got := <-r.queue; got != "did:plc:alice" {
+		t.Fatalf("queued %s, want did:plc:alice", got)
+	}
+
+	// a repo that failed to resync is completed too, rather than retried on every poll
+	r.work(t.Context(), "did:plc:alice")
+	if _, ok := db.pendingResyncs["did:plc:alice"]; ok {
+		t.Fatalf("resync of did:plc:alice still pending after it was attempted")
+	}
+}
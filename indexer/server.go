@@ -4,21 +4,36 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/bluesky-social/go-util/pkg/bus/consumer"
-	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/bluesky-social/go-util/pkg/bus/kafka"
+	"github.com/bluesky-social/indigo/atproto/identity"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/vylet-app/go/bus/deadletter"
+	"github.com/vylet-app/go/bus/headers"
+	"github.com/vylet-app/go/bus/lag"
 	"github.com/vylet-app/go/database/client"
+	"google.golang.org/grpc"
 )
 
 type Server struct {
 	logger *slog.Logger
 
-	consumer *consumer.Consumer[*vyletkafka.FirehoseEvent]
-	db       *client.Client
+	consumer    *kgo.Client
+	dispatcher  *dispatcher
+	deadLetters *deadletter.Producer
+	lag         *lag.Exporter
+	db          *client.Client
+
+	// directory is shared with the resyncer, so that identity events also refresh the identities it resolves
+	directory *identity.CacheDirectory
+
+	resyncs       *resyncer
+	resyncWorkers int
 }
 
 type Args struct {
@@ -29,9 +44,17 @@ type Args struct {
 	ConsumerGroup    string
 
 	DatabaseHost string
+
+	// Workers is the number of events handled concurrently, each worker handling the events of the repos hashed to it
+	Workers         int
+	WorkerQueueSize int
+
+	PLCHost         string
+	ResyncWorkers   int
+	ResyncQueueSize int
 }
 
-func New(args *Args) (*Server, error) {
+func New(ctx context.Context, args *Args) (*Server, error) {
 	if args.Logger == nil {
 		args.Logger = slog.Default()
 	}
@@ -39,30 +62,96 @@ func New(args *Args) (*Server, error) {
 	logger := args.Logger
 
 	db, err := client.New(&client.Args{
-		Addr: args.DatabaseHost,
+		Addr:         args.DatabaseHost,
+		Interceptors: []grpc.UnaryClientInterceptor{countDatabaseErrors},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a new database client: %w", err)
 	}
 
+	deadLetters, err := deadletter.New(ctx, &deadletter.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lagExporter, err := lag.New(&lag.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lag exporter: %w", err)
+	}
+
+	if args.PLCHost == "" {
+		args.PLCHost = "https://plc.directory"
+	}
+
+	if args.Workers <= 0 {
+		args.Workers = 16
+	}
+
+	if args.WorkerQueueSize <= 0 {
+		args.WorkerQueueSize = 100
+	}
+
+	if args.ResyncWorkers <= 0 {
+		args.ResyncWorkers = 4
+	}
+
+	if args.ResyncQueueSize <= 0 {
+		args.ResyncQueueSize = 10_000
+	}
+
+	baseDirectory := identity.BaseDirectory{
+		PLCURL: args.PLCHost,
+		HTTPClient: http.Client{
+			Timeout: time.Second * 5,
+		},
+		TryAuthoritativeDNS:   false,
+		SkipDNSDomainSuffixes: []string{".bsky.social", ".staging.bsky.dev"},
+	}
+	directory := identity.NewCacheDirectory(&baseDirectory, 50_000, time.Hour, time.Minute*15, time.Minute*15)
+
 	server := Server{
 		logger: logger,
 
-		db: db,
-	}
+		deadLetters: deadLetters,
+		lag:         lagExporter,
+		db:          db,
+		directory:   &directory,
 
-	busConsumer, err := consumer.New(
-		logger.With("component", "consumer"),
-		args.BootstrapServers,
-		args.InputTopic,
-		args.ConsumerGroup,
-		consumer.WithOffset[*vyletkafka.FirehoseEvent](consumer.OffsetStart),
-		consumer.WithMessageHandler(server.handleEvent),
-	)
+		resyncWorkers: args.ResyncWorkers,
+	}
+	server.resyncs = newResyncer(logger, &server, &directory, args.ResyncQueueSize)
+
+	server.dispatcher = newDispatcher(logger, &headers.Filter{
+		Kinds:       []string{headers.KindCommit, headers.KindIdentity, headers.KindAccount, headers.KindSync},
+		Collections: indexedCollections,
+	}, server.handleEvent, args.Workers, args.WorkerQueueSize)
+
+	consumerOpts := kafka.DefaultConsumerOpts()
+	consumerOpts.AutoCommitMarks = true
+	consumerOpts.OnPartitionsRevoked = server.dispatcher.revoked
+	consumerOpts.OnPartitionsLost = server.dispatcher.lost
+
+	busConsumer, err := kafka.NewKafkaClient(kafka.Config{
+		BootstrapServers: args.BootstrapServers,
+		ClientID:         "vylet-indexer",
+		Group:            args.ConsumerGroup,
+		Topic:            args.InputTopic,
+	}, consumerOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new consumer: %w", err)
 	}
 	server.consumer = busConsumer
+	server.dispatcher.client = busConsumer
 
 	return &server, nil
 }
@@ -70,25 +159,22 @@ func New(args *Args) (*Server, error) {
 func (s *Server) Run(ctx context.Context) error {
 	logger := s.logger.With("name", "Run")
 
-	shutdownConsumer := make(chan struct{}, 1)
-	consumerShutdown := make(chan struct{}, 1)
-	consumerErr := make(chan error, 1)
+	ctx, cancelResyncs := context.WithCancel(ctx)
+	defer cancelResyncs()
+
+	go s.resyncs.run(ctx, s.resyncWorkers)
+	go s.lag.Run(ctx)
+
+	// the dispatcher commits what was handled once it stops, so the consumer is only closed after it returns
+	dispatchCtx, stopDispatching := context.WithCancel(ctx)
+	defer stopDispatching()
+
+	dispatcherShutdown := make(chan struct{})
 	go func() {
-		go func() {
-			if err := s.consumer.Consume(ctx); err != nil {
-				consumerErr <- err
-			}
-		}()
-
-		select {
-		case <-shutdownConsumer:
-		case err := <-consumerErr:
+		defer close(dispatcherShutdown)
+		if err := s.dispatcher.run(dispatchCtx); err != nil {
 			s.logger.Error("error consuming", "err", err)
 		}
-
-		s.consumer.Close()
-
-		close(consumerShutdown)
 	}()
 
 	signals := make(chan os.Signal, 1)
@@ -97,18 +183,21 @@ func (s *Server) Run(ctx context.Context) error {
 	select {
 	case sig := <-signals:
 		logger.Info("received exit signal", "signal", sig)
-		close(shutdownConsumer)
 	case <-ctx.Done():
 		logger.Info("context cancelled")
-		close(shutdownConsumer)
-	case <-consumerShutdown:
+	case <-dispatcherShutdown:
 		logger.Warn("consumer shut down unexpectedly")
 	}
 
+	stopDispatching()
+	<-dispatcherShutdown
+
 	_, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	s.consumer.Close()
+	s.deadLetters.Close()
+	s.lag.Close()
 
 	if err := s.db.Close(); err != nil {
 		logger.Error("failed to close database client", "err", err)
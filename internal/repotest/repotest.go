@@ -0,0 +1,237 @@
+// Package repotest builds repos in memory and serves them from a fake relay and PDS, for testing code that enumerates
+// and fetches repos over xrpc.
+package repotest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	"github.com/bluesky-social/indigo/repo"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	car "github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
+)
+
+// Repo is a repo held in memory. Writes are only visible to the relay and PDS once committed.
+type Repo struct {
+	tb  testing.TB
+	did string
+
+	lk   sync.Mutex
+	bs   *blockstore
+	repo *repo.Repo
+	rev  string
+	car  []byte
+}
+
+func NewRepo(tb testing.TB, did string) *Repo {
+	bs := &blockstore{blocks: make(map[cid.Cid]blocks.Block)}
+
+	return &Repo{
+		tb:   tb,
+		did:  did,
+		bs:   bs,
+		repo: repo.NewRepo(context.Background(), did, bs),
+	}
+}
+
+func (r *Repo) Did() string {
+	return r.did
+}
+
+// Put creates or updates a record, returning its cid
+func (r *Repo) Put(collection, rkey string, rec repo.CborMarshaler) cid.Cid {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	c, err := r.repo.PutRecord(context.Background(), collection+"/"+rkey, rec)
+	if err != nil {
+		r.tb.Fatalf("failed to put record %s/%s: %v", collection, rkey, err)
+	}
+
+	return c
+}
+
+func (r *Repo) Delete(collection, rkey string) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	if err := r.repo.DeleteRecord(context.Background(), collection+"/"+rkey); err != nil {
+		r.tb.Fatalf("failed to delete record %s/%s: %v", collection, rkey, err)
+	}
+}
+
+// Commit signs the pending writes with a placeholder signature and returns the new rev
+func (r *Repo) Commit() string {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	ctx := context.Background()
+
+	root, rev, err := r.repo.Commit(ctx, func(context.Context, string, []byte) ([]byte, error) {
+		return []byte("signature"), nil
+	})
+	if err != nil {
+		r.tb.Fatalf("failed to commit repo: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := car.WriteHeader(&car.CarHeader{Roots: []cid.Cid{root}, Version: 1}, &buf); err != nil {
+		r.tb.Fatalf("failed to write car header: %v", err)
+	}
+	for _, c := range r.bs.order {
+		if err := carutil.LdWrite(&buf, c.Bytes(), r.bs.blocks[c].RawData()); err != nil {
+			r.tb.Fatalf("failed to write car block: %v", err)
+		}
+	}
+
+	r.rev = rev
+	r.car = buf.Bytes()
+
+	return rev
+}
+
+// Rev is the rev of the last commit
+func (r *Repo) Rev() string {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return r.rev
+}
+
+// CAR is the repo as of the last commit, as com.atproto.sync.getRepo returns it
+func (r *Repo) CAR() []byte {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return r.car
+}
+
+func (r *Repo) hasCollection(collection string) bool {
+	if r.CAR() == nil {
+		return false
+	}
+
+	rr, err := repo.ReadRepoFromCar(context.Background(), bytes.NewReader(r.CAR()))
+	if err != nil {
+		r.tb.Fatalf("failed to read repo from car: %v", err)
+	}
+
+	found := false
+	if err := rr.ForEach(context.Background(), collection+"/", func(k string, _ cid.Cid) error {
+		if strings.HasPrefix(k, collection+"/") {
+			found = true
+			return repo.ErrDoneIterating
+		}
+		return nil
+	}); err != nil && !errors.Is(err, repo.ErrDoneIterating) {
+		r.tb.Fatalf("failed to walk repo: %v", err)
+	}
+
+	return found
+}
+
+// NewPDS serves com.atproto.sync.getRepo for the repos. It is closed when the test ends.
+func NewPDS(tb testing.TB, repos ...*Repo) *httptest.Server {
+	byDid := make(map[string]*Repo, len(repos))
+	for _, r := range repos {
+		byDid[r.did] = r
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/xrpc/com.atproto.sync.getRepo", func(w http.ResponseWriter, req *http.Request) {
+		r, ok := byDid[req.URL.Query().Get("did")]
+		if !ok || r.CAR() == nil {
+			writeError(w, http.StatusBadRequest, "RepoNotFound")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.ipld.car")
+		w.Write(r.CAR())
+	})
+
+	srv := httptest.NewServer(mux)
+	tb.Cleanup(srv.Close)
+
+	return srv
+}
+
+// NewRelay serves com.atproto.sync.listReposByCollection for the repos, paginated in the order they are given. It is
+// closed when the test ends.
+func NewRelay(tb testing.TB, repos ...*Repo) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/xrpc/com.atproto.sync.listReposByCollection", func(w http.ResponseWriter, req *http.Request) {
+		collection := req.URL.Query().Get("collection")
+
+		limit := 500
+		if l, err := strconv.Atoi(req.URL.Query().Get("limit")); err == nil && l > 0 {
+			limit = l
+		}
+
+		start := 0
+		if c, err := strconv.Atoi(req.URL.Query().Get("cursor")); err == nil {
+			start = c
+		}
+
+		out := comatproto.SyncListReposByCollection_Output{
+			Repos: []*comatproto.SyncListReposByCollection_Repo{},
+		}
+		i := start
+		for ; i < len(repos) && len(out.Repos) < limit; i++ {
+			if repos[i].hasCollection(collection) {
+				out.Repos = append(out.Repos, &comatproto.SyncListReposByCollection_Repo{Did: repos[i].did})
+			}
+		}
+		if i < len(repos) {
+			cursor := strconv.Itoa(i)
+			out.Cursor = &cursor
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(out)
+	})
+
+	srv := httptest.NewServer(mux)
+	tb.Cleanup(srv.Close)
+
+	return srv
+}
+
+func writeError(w http.ResponseWriter, status int, name string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": name})
+}
+
+// blockstore keeps blocks in insertion order, so that CARs are written deterministically
+type blockstore struct {
+	blocks map[cid.Cid]blocks.Block
+	order  []cid.Cid
+}
+
+func (bs *blockstore) Get(_ context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, ok := bs.blocks[c]
+	if !ok {
+		return nil, &ipld.ErrNotFound{Cid: c}
+	}
+	return blk, nil
+}
+
+func (bs *blockstore) Put(_ context.Context, blk blocks.Block) error {
+	if _, ok := bs.blocks[blk.Cid()]; !ok {
+		bs.order = append(bs.order, blk.Cid())
+	}
+	bs.blocks[blk.Cid()] = blk
+	return nil
+}
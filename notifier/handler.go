@@ -0,0 +1,233 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/vylet-app/go/bus/deadletter"
+	vyletkafka "github.com/vylet-app/go/bus/proto"
+	"github.com/vylet-app/go/bus/records"
+	vyletdatabase "github.com/vylet-app/go/database/proto"
+	"github.com/vylet-app/go/generated/vylet"
+	"github.com/vylet-app/go/internal/helpers"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	reasonLike    = "like"
+	reasonFollow  = "follow"
+	reasonComment = "comment"
+	reasonMention = "mention"
+)
+
+// notifiedCollections are the collections whose records may notify someone
+var notifiedCollections = []string{
+	records.CollectionFeedPost,
+	records.CollectionFeedLike,
+	records.CollectionFeedComment,
+	records.CollectionGraphFollow,
+}
+
+func (s *Server) handleEvent(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
+	defer s.lag.Observe(evt)
+
+	if evt.Commit == nil {
+		return nil
+	}
+
+	if !slices.Contains(notifiedCollections, evt.Commit.Collection) {
+		return nil
+	}
+
+	if !s.skipBefore.IsZero() && evt.Timestamp != nil && evt.Timestamp.AsTime().Before(s.skipBefore) {
+		eventsProcessed.WithLabelValues(evt.Commit.Collection, evt.Commit.Operation.String(), "skipped").Inc()
+		return nil
+	}
+
+	if err := s.handleCommit(ctx, evt); err != nil {
+		// the consumer does not retry failed messages, so keep them around to be re-driven once fixed
+		s.deadLetters.SendEvent(ctx, deadletter.StageNotifier, evt, err)
+		eventsProcessed.WithLabelValues(evt.Commit.Collection, evt.Commit.Operation.String(), "error").Inc()
+		return nil
+	}
+
+	eventsProcessed.WithLabelValues(evt.Commit.Collection, evt.Commit.Operation.String(), "ok").Inc()
+
+	return nil
+}
+
+func (s *Server) handleCommit(ctx context.Context, evt *vyletkafka.FirehoseEvent) error {
+	uri := fmt.Sprintf("at://%s/%s/%s", evt.Did, evt.Commit.Collection, evt.Commit.Rkey)
+
+	switch evt.Commit.Operation {
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_CREATE, vyletkafka.CommitOperation_COMMIT_OPERATION_UPDATE:
+		notifications, err := notificationsFor(evt, uri)
+		if err != nil {
+			return err
+		}
+
+		// an update replaces the notifications of the record, so an edited caption that drops a mention drops its
+		// notification as well
+		resp, err := s.db.Notification.PutNotifications(ctx, &vyletdatabase.PutNotificationsRequest{
+			Uri:           uri,
+			Notifications: notifications,
+			Rev:           evt.Commit.Rev,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create put notifications request: %w", err)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("error putting notifications: %s", *resp.Error)
+		}
+
+		for _, n := range notifications {
+			notificationsDerived.WithLabelValues(n.Reason).Inc()
+		}
+	case vyletkafka.CommitOperation_COMMIT_OPERATION_DELETE:
+		resp, err := s.db.Notification.DeleteNotifications(ctx, &vyletdatabase.DeleteNotificationsRequest{
+			Uri: uri,
+			Rev: evt.Commit.Rev,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create delete notifications request: %w", err)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("error deleting notifications: %s", *resp.Error)
+		}
+	}
+
+	return nil
+}
+
+// notificationsFor returns the notifications a created or updated record causes, at most one per recipient. Actors
+// are never notified of their own records.
+func notificationsFor(evt *vyletkafka.FirehoseEvent, uri string) ([]*vyletdatabase.Notification, error) {
+	var (
+		createdAt string
+		// candidates in order of precedence, as a comment on a post that also mentions its author is a comment
+		candidates []*vyletdatabase.Notification
+	)
+
+	add := func(recipientDid, reason string, reasonSubject *string) {
+		candidates = append(candidates, &vyletdatabase.Notification{
+			RecipientDid:  recipientDid,
+			Uri:           uri,
+			Cid:           evt.Commit.Cid,
+			AuthorDid:     evt.Did,
+			Reason:        reason,
+			ReasonSubject: reasonSubject,
+		})
+	}
+
+	switch evt.Commit.Collection {
+	case records.CollectionFeedLike:
+		rec, err := records.FeedLike(evt.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal like record: %w", err)
+		}
+		if rec.Subject == nil {
+			return nil, fmt.Errorf("invalid like, missing subject")
+		}
+		createdAt = rec.CreatedAt
+
+		add(didFromUri(rec.Subject.Uri), reasonLike, helpers.ToStringPtr(rec.Subject.Uri))
+	case records.CollectionGraphFollow:
+		rec, err := records.GraphFollow(evt.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal follow record: %w", err)
+		}
+		createdAt = rec.CreatedAt
+
+		if _, err := syntax.ParseDID(rec.Subject); err != nil {
+			return nil, fmt.Errorf("invalid follow subject: %w", err)
+		}
+		add(rec.Subject, reasonFollow, nil)
+	case records.CollectionFeedComment:
+		rec, err := records.FeedComment(evt.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal comment record: %w", err)
+		}
+		if rec.Root == nil {
+			return nil, fmt.Errorf("invalid comment, missing root")
+		}
+		createdAt = rec.CreatedAt
+
+		// the author of the comment replied to is told before the author of the post
+		if rec.Parent != nil {
+			add(didFromUri(rec.Parent.Uri), reasonComment, helpers.ToStringPtr(rec.Parent.Uri))
+		}
+		add(didFromUri(rec.Root.Uri), reasonComment, helpers.ToStringPtr(rec.Root.Uri))
+		for _, did := range mentionedDids(rec.Facets) {
+			add(did, reasonMention, nil)
+		}
+	case records.CollectionFeedPost:
+		rec, err := records.FeedPost(evt.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal post record: %w", err)
+		}
+		createdAt = rec.CreatedAt
+
+		for _, did := range mentionedDids(rec.Facets) {
+			add(did, reasonMention, nil)
+		}
+	}
+
+	createdAtTime, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse time from record: %w", err)
+	}
+
+	notified := make(map[string]bool, len(candidates))
+	notifications := make([]*vyletdatabase.Notification, 0, len(candidates))
+	for _, n := range candidates {
+		if n.RecipientDid == "" || n.RecipientDid == evt.Did || notified[n.RecipientDid] {
+			continue
+		}
+		notified[n.RecipientDid] = true
+
+		n.CreatedAt = timestamppb.New(createdAtTime)
+		notifications = append(notifications, n)
+	}
+
+	return notifications, nil
+}
+
+// didFromUri returns the did of the repo an at-uri points into, or an empty string if it is not a valid at-uri with
+// a did authority
+func didFromUri(uri string) string {
+	parsed, err := syntax.ParseATURI(uri)
+	if err != nil {
+		return ""
+	}
+
+	did, err := parsed.Authority().AsDID()
+	if err != nil {
+		return ""
+	}
+
+	return did.String()
+}
+
+// mentionedDids returns every did mentioned by the facets, in the order they are mentioned
+func mentionedDids(facets []*vylet.RichtextFacet) []string {
+	var dids []string
+	for _, facet := range facets {
+		if facet == nil {
+			continue
+		}
+		for _, feature := range facet.Features {
+			if feature == nil || feature.RichtextFacet_Mention == nil {
+				continue
+			}
+			if _, err := syntax.ParseDID(feature.RichtextFacet_Mention.Did); err != nil {
+				continue
+			}
+			dids = append(dids, feature.RichtextFacet_Mention.Did)
+		}
+	}
+
+	return dids
+}
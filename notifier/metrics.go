@@ -0,0 +1,22 @@
+package notifier
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace = "notifier"
+)
+
+var (
+	eventsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_processed",
+	}, []string{"collection", "operation", "status"})
+
+	notificationsDerived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_derived",
+	}, []string{"reason"})
+)
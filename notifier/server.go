@@ -0,0 +1,162 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/vylet-app/go/bus/consume"
+	"github.com/vylet-app/go/bus/deadletter"
+	"github.com/vylet-app/go/bus/headers"
+	"github.com/vylet-app/go/bus/lag"
+	"github.com/vylet-app/go/database/client"
+)
+
+// Server consumes firehose events and keeps the notifications that likes, follows, comments and mentions cause
+type Server struct {
+	logger *slog.Logger
+
+	consumer    *consume.Consumer
+	deadLetters *deadletter.Producer
+	lag         *lag.Exporter
+	db          *client.Client
+
+	skipBefore time.Time
+}
+
+type Args struct {
+	Logger *slog.Logger
+
+	BootstrapServers []string
+	InputTopic       string
+	ConsumerGroup    string
+
+	DatabaseHost string
+
+	// SkipBefore skips events the firehose received before it, none if zero
+	SkipBefore time.Time
+}
+
+func New(ctx context.Context, args *Args) (*Server, error) {
+	if args.Logger == nil {
+		args.Logger = slog.Default()
+	}
+
+	logger := args.Logger
+
+	db, err := client.New(&client.Args{
+		Addr: args.DatabaseHost,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new database client: %w", err)
+	}
+
+	deadLetters, err := deadletter.New(ctx, &deadletter.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lagExporter, err := lag.New(&lag.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lag exporter: %w", err)
+	}
+
+	server := Server{
+		logger: logger,
+
+		deadLetters: deadLetters,
+		lag:         lagExporter,
+		db:          db,
+
+		skipBefore: args.SkipBefore,
+	}
+
+	busConsumer, err := consume.New(&consume.Args{
+		Logger:           logger,
+		BootstrapServers: args.BootstrapServers,
+		Topic:            args.InputTopic,
+		ConsumerGroup:    args.ConsumerGroup,
+		ClientID:         "vylet-notifier",
+		// backfilled records were created before the firehose caught up with them, and are too old to notify about
+		Filter: &headers.Filter{
+			Kinds:        []string{headers.KindCommit},
+			Collections:  notifiedCollections,
+			SkipBackfill: true,
+		},
+		Handler: server.handleEvent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new consumer: %w", err)
+	}
+	server.consumer = busConsumer
+
+	return &server, nil
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	logger := s.logger.With("name", "Run")
+
+	ctx, cancelLag := context.WithCancel(ctx)
+	defer cancelLag()
+
+	go s.lag.Run(ctx)
+
+	shutdownConsumer := make(chan struct{}, 1)
+	consumerShutdown := make(chan struct{}, 1)
+	consumerErr := make(chan error, 1)
+	go func() {
+		go func() {
+			if err := s.consumer.Consume(ctx); err != nil {
+				consumerErr <- err
+			}
+		}()
+
+		select {
+		case <-shutdownConsumer:
+		case err := <-consumerErr:
+			s.logger.Error("error consuming", "err", err)
+		}
+
+		s.consumer.Close()
+
+		close(consumerShutdown)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case sig := <-signals:
+		logger.Info("received exit signal", "signal", sig)
+		close(shutdownConsumer)
+	case <-ctx.Done():
+		logger.Info("context cancelled")
+		close(shutdownConsumer)
+	case <-consumerShutdown:
+		logger.Warn("consumer shut down unexpectedly")
+	}
+
+	s.consumer.Close()
+	s.deadLetters.Close()
+	s.lag.Close()
+
+	if err := s.db.Close(); err != nil {
+		logger.Error("failed to close database client", "err", err)
+	}
+
+	return nil
+}